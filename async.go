@@ -0,0 +1,321 @@
+// ©Hayabusa Cloud Co., Ltd. 2026. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package kont
+
+import "slices"
+
+// Async effect operations.
+// RunAsync multiplexes computations on a single-threaded event loop built
+// on the stepping boundary: Fork enqueues a task and returns its Future,
+// Await parks the current task until the Future completes. Tasks only
+// switch where they park, and run in the order of an [AsyncScheduler], FIFO by
+// default, so scheduling is deterministic for a deterministic scheduler.
+// With an [AsyncBatcher], operations it recognizes park their tasks until
+// no task is runnable, then are dispatched together as one batch, so tasks
+// loading records one by one cost one round trip per batch.
+
+// Future is the eventual result of a forked task.
+type Future[A any] struct {
+	done    bool
+	value   A
+	waiters []*asyncTask
+}
+
+// Done reports whether the task has completed.
+func (f *Future[A]) Done() bool { return f.done }
+
+// Get returns the result and true once the task has completed, or zero and
+// false before.
+func (f *Future[A]) Get() (A, bool) { return f.value, f.done }
+
+func (f *Future[A]) complete(a A) {
+	f.done = true
+	f.value = a
+	for _, w := range f.waiters {
+		w.wake(a)
+	}
+	f.waiters = nil
+}
+
+// Fork is the effect operation for starting Body as a concurrent task.
+// Resumes immediately with the task's Future.
+type Fork[A any] struct{ Body Cont[Resumed, A] }
+
+func (Fork[A]) OpResult() *Future[A] { panic("phantom") }
+
+func (o Fork[A]) fork(rt *asyncRuntime) Resumed {
+	f := &Future[A]{}
+	rt.spawn(Map(o.Body, func(a A) struct{} {
+		f.complete(a)
+		return struct{}{}
+	}))
+	return f
+}
+
+// Await is the effect operation for waiting on a Future.
+// Resumes with the result once the task has completed.
+type Await[A any] struct{ Future *Future[A] }
+
+func (Await[A]) OpResult() A { panic("phantom") }
+
+func (o Await[A]) await(t *asyncTask) (Resumed, bool) {
+	if o.Future.done {
+		return o.Future.value, true
+	}
+	o.Future.waiters = append(o.Future.waiters, t)
+	return nil, false
+}
+
+// ForkAsync performs Fork.
+func ForkAsync[A any](body Cont[Resumed, A]) Cont[Resumed, *Future[A]] {
+	return Perform(Fork[A]{Body: body})
+}
+
+// AwaitAsync performs Await.
+func AwaitAsync[A any](f *Future[A]) Cont[Resumed, A] {
+	return Perform(Await[A]{Future: f})
+}
+
+// AsyncTask is a runnable task handed to an [AsyncScheduler].
+type AsyncTask struct{ t *asyncTask }
+
+// ID returns the task's spawn index: 0 for the main task, then 1, 2, ...
+// in Fork order.
+func (t AsyncTask) ID() int { return t.t.id }
+
+// AsyncScheduler orders the runnable tasks of [RunAsyncWith]. Push adds a
+// task that has been forked or whose awaited Future has completed; Pop
+// removes the task to run next and reports false when none is runnable.
+// A scheduler is used by one event loop and needs no locking.
+type AsyncScheduler interface {
+	Push(t AsyncTask)
+	Pop() (AsyncTask, bool)
+}
+
+// fifoScheduler runs tasks in the order they become runnable.
+type fifoScheduler struct {
+	queue []AsyncTask
+}
+
+func (s *fifoScheduler) Push(t AsyncTask) { s.queue = append(s.queue, t) }
+
+func (s *fifoScheduler) Pop() (AsyncTask, bool) {
+	if len(s.queue) == 0 {
+		return AsyncTask{}, false
+	}
+	t := s.queue[0]
+	s.queue[0] = AsyncTask{}
+	s.queue = s.queue[1:]
+	return t, true
+}
+
+// FIFOScheduler creates the scheduler used by [RunAsync], which runs tasks
+// in the order they become runnable.
+// Returns a concrete scheduler.
+func FIFOScheduler() *fifoScheduler {
+	return &fifoScheduler{}
+}
+
+// asyncTask is a computation scheduled on the event loop. origin starts
+// it again under PanicRestart.
+type asyncTask struct {
+	rt     *asyncRuntime
+	id     int
+	origin func() (struct{}, *Suspension[struct{}])
+	start  func() (struct{}, *Suspension[struct{}])
+	susp   *Suspension[struct{}]
+	resume Resumed
+}
+
+// wake makes a parked task runnable, to be resumed with v.
+func (t *asyncTask) wake(v Resumed) {
+	t.resume = v
+	t.rt.sched.Push(AsyncTask{t: t})
+}
+
+type asyncRuntime struct {
+	dispatch func(Operation) (Resumed, bool)
+	sched    AsyncScheduler
+	panic    PanicPolicy
+	onPanic  func(AsyncTask, error)
+	batcher  AsyncBatcher
+	batches  []*asyncBatch
+	spawned  int
+}
+
+// AsyncBatcher coalesces operations pending in several tasks of an event
+// loop into one dispatch.
+type AsyncBatcher interface {
+	// BatchKey reports whether op is batched, and the comparable key of
+	// the batch it joins.
+	BatchKey(op Operation) (key any, ok bool)
+	// DispatchBatch handles the operations of one batch, in the order
+	// they were performed, and returns one result per operation.
+	// Identical operations may appear more than once.
+	DispatchBatch(key any, ops []Operation) []Resumed
+}
+
+// asyncBatch is the tasks parked on operations with one batch key.
+type asyncBatch struct {
+	key   any
+	tasks []*asyncTask
+	ops   []Operation
+}
+
+// batch parks t on op in the batch for key.
+func (rt *asyncRuntime) batch(key any, t *asyncTask, op Operation) {
+	i := slices.IndexFunc(rt.batches, func(b *asyncBatch) bool { return b.key == key })
+	if i < 0 {
+		i = len(rt.batches)
+		rt.batches = append(rt.batches, &asyncBatch{key: key})
+	}
+	b := rt.batches[i]
+	b.tasks = append(b.tasks, t)
+	b.ops = append(b.ops, op)
+}
+
+// flush dispatches the pending batches in the order their keys were first
+// seen, waking their tasks. Reports whether there were any.
+func (rt *asyncRuntime) flush() bool {
+	batches := rt.batches
+	rt.batches = nil
+	for _, b := range batches {
+		results := rt.batcher.DispatchBatch(b.key, b.ops)
+		if len(results) != len(b.ops) {
+			panic("kont: AsyncBatcher returned a result count different from its batch")
+		}
+		for i, t := range b.tasks {
+			t.wake(results[i])
+		}
+	}
+	return len(batches) > 0
+}
+
+func (rt *asyncRuntime) spawn(m Cont[Resumed, struct{}]) {
+	start := func() (struct{}, *Suspension[struct{}]) { return Step(m) }
+	t := &asyncTask{rt: rt, id: rt.spawned, origin: start, start: start}
+	rt.spawned++
+	rt.sched.Push(AsyncTask{t: t})
+}
+
+// recoverTask applies the panic policy to a panic raised while running t.
+func (rt *asyncRuntime) recoverTask(t *asyncTask) {
+	r := recover()
+	if r == nil {
+		return
+	}
+	if rt.onPanic != nil {
+		rt.onPanic(AsyncTask{t: t}, PanicError(r))
+	}
+	if rt.panic == PanicRestart {
+		t.start, t.susp, t.resume = t.origin, nil, nil
+		rt.sched.Push(AsyncTask{t: t})
+	}
+}
+
+// run advances t until it completes, parks on Await, or short-circuits.
+func (rt *asyncRuntime) run(t *asyncTask) {
+	if rt.panic != PanicCrash {
+		defer rt.recoverTask(t)
+	}
+	var susp *Suspension[struct{}]
+	if t.start != nil {
+		_, susp = t.start()
+		t.start = nil
+	} else {
+		_, susp = t.susp.Resume(t.resume)
+		t.susp, t.resume = nil, nil
+	}
+	for susp != nil {
+		var v Resumed
+		switch op := susp.Op().(type) {
+		case interface{ fork(*asyncRuntime) Resumed }:
+			v = op.fork(rt)
+		case interface {
+			await(*asyncTask) (Resumed, bool)
+		}:
+			var ready bool
+			if v, ready = op.await(t); !ready {
+				t.susp = susp
+				return
+			}
+		default:
+			if rt.batcher != nil {
+				if key, ok := rt.batcher.BatchKey(op); ok {
+					rt.batch(key, t, op)
+					t.susp = susp
+					return
+				}
+			}
+			if rt.dispatch == nil {
+				unhandledEffect("RunAsync", op)
+			}
+			var shouldResume bool
+			if v, shouldResume = rt.dispatch(op); !shouldResume {
+				susp.Discard()
+				return
+			}
+		}
+		_, susp = susp.Resume(v)
+	}
+}
+
+// RunAsync runs m as the main task of an event loop and returns its result
+// once no task can make progress. dispatch handles operations other than
+// Fork and Await and may be nil. A task whose operation dispatch
+// short-circuits is abandoned and its Future never completes.
+// Panics if the main task never completes, because it awaits a Future
+// that cannot complete or was abandoned.
+func RunAsync[A any](m Cont[Resumed, A], dispatch func(Operation) (Resumed, bool)) A {
+	return RunAsyncWith(m, dispatch, FIFOScheduler())
+}
+
+// RunAsyncWith is [RunAsync] with tasks run in the order chosen by sched.
+func RunAsyncWith[A any](m Cont[Resumed, A], dispatch func(Operation) (Resumed, bool), sched AsyncScheduler) A {
+	return RunAsyncWithOptions(AsyncOptions{Dispatch: dispatch, Scheduler: sched}, m)
+}
+
+// AsyncOptions configures [RunAsyncWithOptions].
+type AsyncOptions struct {
+	// Dispatch handles operations other than those of the event loop.
+	// Nil means tasks perform no others.
+	Dispatch func(Operation) (Resumed, bool)
+	// Scheduler orders runnable tasks. Nil means [FIFOScheduler].
+	Scheduler AsyncScheduler
+	// Panic chooses what the loop does when a task panics, in its own
+	// code or in Dispatch. The zero value, PanicCrash, propagates the
+	// panic out of the loop. A stopped task's Future never completes.
+	Panic PanicPolicy
+	// OnPanic, if set, is called with the task and [PanicError] of each
+	// panic Panic recovers.
+	OnPanic func(t AsyncTask, err error)
+	// Batcher, if set, is offered every operation before Dispatch and
+	// batches those it recognizes. Nil batches nothing.
+	Batcher AsyncBatcher
+}
+
+// RunAsyncWithOptions is [RunAsync] configured by opts.
+func RunAsyncWithOptions[A any](opts AsyncOptions, m Cont[Resumed, A]) A {
+	sched := opts.Scheduler
+	if sched == nil {
+		sched = FIFOScheduler()
+	}
+	rt := &asyncRuntime{dispatch: opts.Dispatch, sched: sched, panic: opts.Panic, onPanic: opts.OnPanic, batcher: opts.Batcher}
+	main := Fork[A]{Body: m}.fork(rt).(*Future[A])
+	for {
+		t, ok := sched.Pop()
+		if !ok {
+			if rt.flush() {
+				continue
+			}
+			break
+		}
+		rt.run(t.t)
+	}
+	if !main.done {
+		panic("kont: async main task did not complete")
+	}
+	return main.value
+}
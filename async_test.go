@@ -0,0 +1,228 @@
+// ©Hayabusa Cloud Co., Ltd. 2026. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package kont_test
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+
+	"code.hybscloud.com/kont"
+)
+
+// forkTwo forks workers a and b, then awaits both, logging each step.
+func forkTwo(log *[]string) kont.Eff[int] {
+	worker := func(name string, n int) kont.Eff[int] {
+		return kont.Bind(kont.Pure(n), func(n int) kont.Eff[int] {
+			*log = append(*log, name)
+			return kont.Pure(n * n)
+		})
+	}
+	return kont.Bind(kont.ForkAsync(worker("a", 3)), func(fa *kont.Future[int]) kont.Eff[int] {
+		return kont.Bind(kont.ForkAsync(worker("b", 4)), func(fb *kont.Future[int]) kont.Eff[int] {
+			*log = append(*log, "main")
+			return kont.Bind(kont.AwaitAsync(fa), func(a int) kont.Eff[int] {
+				return kont.Map(kont.AwaitAsync(fb), func(b int) int { return a + b })
+			})
+		})
+	})
+}
+
+func TestRunAsyncForkAwait(t *testing.T) {
+	var log []string
+	if got := kont.RunAsync(forkTwo(&log), nil); got != 25 {
+		t.Fatalf("got %d, want 25", got)
+	}
+	if want := []string{"main", "a", "b"}; !slices.Equal(log, want) {
+		t.Fatalf("got %v, want %v", log, want)
+	}
+}
+
+// lifoScheduler runs the most recently runnable task first.
+type lifoScheduler struct {
+	stack []kont.AsyncTask
+	ids   []int
+}
+
+func (s *lifoScheduler) Push(t kont.AsyncTask) { s.stack = append(s.stack, t) }
+
+func (s *lifoScheduler) Pop() (kont.AsyncTask, bool) {
+	if len(s.stack) == 0 {
+		return kont.AsyncTask{}, false
+	}
+	t := s.stack[len(s.stack)-1]
+	s.stack = s.stack[:len(s.stack)-1]
+	s.ids = append(s.ids, t.ID())
+	return t, true
+}
+
+func TestRunAsyncWithScheduler(t *testing.T) {
+	var log []string
+	sched := &lifoScheduler{}
+	if got := kont.RunAsyncWith(forkTwo(&log), nil, sched); got != 25 {
+		t.Fatalf("got %d, want 25", got)
+	}
+	if want := []string{"main", "b", "a"}; !slices.Equal(log, want) {
+		t.Fatalf("got %v, want %v", log, want)
+	}
+	if want := []int{0, 2, 1, 0}; !slices.Equal(sched.ids, want) {
+		t.Fatalf("ran task ids %v, want %v", sched.ids, want)
+	}
+}
+
+func TestRunAsyncChainedAwaits(t *testing.T) {
+	// A task awaiting a task forked after it is woken on completion.
+	comp := kont.Bind(kont.ForkAsync(kont.Pure(1)), func(f1 *kont.Future[int]) kont.Eff[int] {
+		waiter := kont.Map(kont.AwaitAsync(f1), func(x int) int { return x + 1 })
+		return kont.Bind(kont.ForkAsync(waiter), func(f2 *kont.Future[int]) kont.Eff[int] {
+			return kont.AwaitAsync(f2)
+		})
+	})
+	if got := kont.RunAsync(comp, nil); got != 2 {
+		t.Fatalf("got %d", got)
+	}
+}
+
+func TestRunAsyncDispatch(t *testing.T) {
+	comp := kont.Bind(kont.ForkAsync(kont.Perform(kont.Ask[int]{})), kont.AwaitAsync[int])
+	got := kont.RunAsync(comp, func(op kont.Operation) (kont.Resumed, bool) { return 7, true })
+	if got != 7 {
+		t.Fatalf("got %d", got)
+	}
+}
+
+func TestRunAsyncMainIncomplete(t *testing.T) {
+	comp := kont.Bind(kont.ForkAsync(kont.Perform(kont.Ask[int]{})), kont.AwaitAsync[int])
+	defer func() {
+		if r := recover(); r != "kont: async main task did not complete" {
+			t.Fatalf("got panic %v", r)
+		}
+	}()
+	kont.RunAsync(comp, func(kont.Operation) (kont.Resumed, bool) { return nil, false })
+}
+
+func TestRunAsyncUnhandledPanic(t *testing.T) {
+	defer func() {
+		if r := recover(); unhandledIn(r) != "RunAsync" {
+			t.Fatalf("got panic %v", r)
+		}
+	}()
+	kont.RunAsync(kont.Perform(kont.Get[int]{}), nil)
+}
+
+// panicOnce panics the first time it runs, then returns n.
+func panicOnce(runs *int, n int) kont.Eff[int] {
+	return kont.Bind(kont.Pure(n), func(n int) kont.Eff[int] {
+		if *runs++; *runs == 1 {
+			panic("boom")
+		}
+		return kont.Pure(n)
+	})
+}
+
+func TestRunAsyncPanicStop(t *testing.T) {
+	var ids []int
+	runs := 0
+	comp := kont.Bind(kont.ForkAsync(panicOnce(&runs, 1)), func(f *kont.Future[int]) kont.Eff[bool] {
+		return kont.Pure(f.Done())
+	})
+	got := kont.RunAsyncWithOptions(kont.AsyncOptions{
+		Panic: kont.PanicStop,
+		OnPanic: func(task kont.AsyncTask, err error) {
+			if kont.Kind(err) != kont.KindHandlerFailure {
+				t.Errorf("OnPanic got %v", err)
+			}
+			ids = append(ids, task.ID())
+		},
+	}, comp)
+	if got || runs != 1 || !slices.Equal(ids, []int{1}) {
+		t.Fatalf("done %v, runs %d, panicked tasks %v", got, runs, ids)
+	}
+}
+
+func TestRunAsyncPanicRestart(t *testing.T) {
+	runs := 0
+	comp := kont.Bind(kont.ForkAsync(panicOnce(&runs, 7)), kont.AwaitAsync[int])
+	got := kont.RunAsyncWithOptions(kont.AsyncOptions{Panic: kont.PanicRestart}, comp)
+	if got != 7 || runs != 2 {
+		t.Fatalf("got %d after %d runs", got, runs)
+	}
+}
+
+func TestRunAsyncPanicCrash(t *testing.T) {
+	runs := 0
+	defer func() {
+		if r := recover(); r != "boom" {
+			t.Fatalf("got panic %v", r)
+		}
+	}()
+	kont.RunAsyncWithOptions(kont.AsyncOptions{}, panicOnce(&runs, 0))
+}
+
+// loadUser is a batchable lookup.
+type loadUser struct{ id int }
+
+func (loadUser) OpResult() string { panic("phantom") }
+
+// userBatcher batches loadUser and records each batch.
+type userBatcher struct{ batches [][]int }
+
+func (b *userBatcher) BatchKey(op kont.Operation) (any, bool) {
+	_, ok := op.(loadUser)
+	return "users", ok
+}
+
+func (b *userBatcher) DispatchBatch(key any, ops []kont.Operation) []kont.Resumed {
+	var ids []int
+	results := make([]kont.Resumed, len(ops))
+	for i, op := range ops {
+		id := op.(loadUser).id
+		ids = append(ids, id)
+		results[i] = fmt.Sprint("user", id)
+	}
+	b.batches = append(b.batches, ids)
+	return results
+}
+
+func TestRunAsyncBatcher(t *testing.T) {
+	load := func(id int) kont.Eff[string] {
+		return kont.Bind(kont.Perform(kont.Ask[int]{}), func(int) kont.Eff[string] {
+			return kont.Perform(loadUser{id: id})
+		})
+	}
+	var futures []*kont.Future[string]
+	var forkAll func(ids []int) kont.Eff[[]string]
+	forkAll = func(ids []int) kont.Eff[[]string] {
+		if len(ids) > 0 {
+			return kont.Bind(kont.ForkAsync(load(ids[0])), func(f *kont.Future[string]) kont.Eff[[]string] {
+				futures = append(futures, f)
+				return forkAll(ids[1:])
+			})
+		}
+		var names []string
+		var awaitAll func(i int) kont.Eff[[]string]
+		awaitAll = func(i int) kont.Eff[[]string] {
+			if i == len(futures) {
+				return kont.Pure(names)
+			}
+			return kont.Bind(kont.AwaitAsync(futures[i]), func(name string) kont.Eff[[]string] {
+				names = append(names, name)
+				return awaitAll(i + 1)
+			})
+		}
+		return awaitAll(0)
+	}
+	b := &userBatcher{}
+	got := kont.RunAsyncWithOptions(kont.AsyncOptions{
+		Dispatch: func(kont.Operation) (kont.Resumed, bool) { return 0, true },
+		Batcher:  b,
+	}, forkAll([]int{3, 1, 3}))
+	if want := []string{"user3", "user1", "user3"}; !slices.Equal(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	if len(b.batches) != 1 || !slices.Equal(b.batches[0], []int{3, 1, 3}) {
+		t.Fatalf("batches %v, want one batch [3 1 3]", b.batches)
+	}
+}
@@ -0,0 +1,160 @@
+// ©Hayabusa Cloud Co., Ltd. 2026. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package kont
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Access-control effect operations.
+// Authorize[Act, Res] asks a policy whether an action on a resource is
+// permitted; denials surface as ErrForbidden through the Error effect.
+
+// ErrForbidden is the sentinel matched by errors.Is for every denied access.
+var ErrForbidden = errors.New("kont: forbidden")
+
+// ForbiddenError reports a denied access.
+// It matches [ErrForbidden] under errors.Is.
+type ForbiddenError struct {
+	Action   any
+	Resource any
+}
+
+func (e *ForbiddenError) Error() string {
+	return fmt.Sprintf("kont: forbidden: %v on %v", e.Action, e.Resource)
+}
+
+// Unwrap returns ErrForbidden.
+func (e *ForbiddenError) Unwrap() error { return ErrForbidden }
+
+// Verdict is a policy's answer to an authorization request.
+type Verdict uint8
+
+const (
+	// VerdictAllow permits the action on the unmodified resource.
+	VerdictAllow Verdict = iota + 1
+	// VerdictDeny forbids the action.
+	VerdictDeny
+	// VerdictFilter permits the action on a redacted resource (row/field-level).
+	VerdictFilter
+)
+
+// permits reports whether v grants access. Anything other than
+// VerdictAllow or VerdictFilter, including the zero Verdict, denies.
+func (v Verdict) permits() bool { return v == VerdictAllow || v == VerdictFilter }
+
+// Policy decides whether action may be performed on resource.
+// For [VerdictFilter] it returns the redacted resource; for [VerdictAllow] the
+// resource as-is. Any other verdict, including the zero Verdict, denies.
+type Policy[Act, Res any] func(action Act, resource Res) (Verdict, Res)
+
+// Authorize is the effect operation for an authorization request.
+// Perform(Authorize[Act, Res]{Action: a, Resource: r}) returns the policy's
+// verdict and the resource the caller may observe.
+type Authorize[Act, Res any] struct {
+	Action   Act
+	Resource Res
+}
+
+func (Authorize[Act, Res]) OpResult() Pair[Verdict, Res] { panic("phantom") }
+
+// DispatchAuthorize handles Authorize in Authorization handler dispatch.
+func (o Authorize[Act, Res]) DispatchAuthorize(policy Policy[Act, Res]) (Resumed, bool) {
+	v, res := policy(o.Action, o.Resource)
+	return Pair[Verdict, Res]{Fst: v, Snd: res}, true
+}
+
+// CheckAccess performs Authorize and returns the permitted (possibly filtered)
+// resource, or throws a [*ForbiddenError] as Error[error] unless the verdict
+// is [VerdictAllow] or [VerdictFilter].
+func CheckAccess[Act, Res any](action Act, resource Res) Cont[Resumed, Res] {
+	return Bind(Perform(Authorize[Act, Res]{Action: action, Resource: resource}), func(r Pair[Verdict, Res]) Cont[Resumed, Res] {
+		if !r.Fst.permits() {
+			return ThrowError[error, Res](&ForbiddenError{Action: action, Resource: resource})
+		}
+		return Pure(r.Snd)
+	})
+}
+
+// ExprCheckAccess is the Expr counterpart of [CheckAccess].
+func ExprCheckAccess[Act, Res any](action Act, resource Res) Expr[Res] {
+	return ExprBind(ExprPerform(Authorize[Act, Res]{Action: action, Resource: resource}), func(r Pair[Verdict, Res]) Expr[Res] {
+		if !r.Fst.permits() {
+			return ExprThrowError[error, Res](&ForbiddenError{Action: action, Resource: resource})
+		}
+		return ExprReturn(r.Snd)
+	})
+}
+
+// authorizationHandler implements Handler for authorization handling.
+type authorizationHandler[Act, Res, R any] struct {
+	policy Policy[Act, Res]
+}
+
+// Dispatch implements Handler for authorization handling.
+func (h *authorizationHandler[Act, Res, R]) Dispatch(op Operation) (Resumed, bool) {
+	if aop, ok := op.(interface {
+		DispatchAuthorize(policy Policy[Act, Res]) (Resumed, bool)
+	}); ok {
+		return aop.DispatchAuthorize(h.policy)
+	}
+	unhandledEffect("AuthorizationHandler", op)
+	return nil, false
+}
+
+// AuthorizationHandler creates a handler for Authorize effects decided by policy.
+// Returns a concrete handler.
+func AuthorizationHandler[Act, Res, R any](policy Policy[Act, Res]) *authorizationHandler[Act, Res, R] {
+	return &authorizationHandler[Act, Res, R]{policy: policy}
+}
+
+// authorizationErrorHandler handles both Authorize and Error[error] effects.
+type authorizationErrorHandler[Act, Res, A any] struct {
+	policy Policy[Act, Res]
+	ctx    *ErrorContext[error]
+}
+
+// Dispatch implements Handler for the composed Authorization+Error handler.
+// Dispatch order: Authorization → Error.
+func (h *authorizationErrorHandler[Act, Res, A]) Dispatch(op Operation) (Resumed, bool) {
+	if aop, ok := op.(interface {
+		DispatchAuthorize(policy Policy[Act, Res]) (Resumed, bool)
+	}); ok {
+		return aop.DispatchAuthorize(h.policy)
+	}
+	if eop, ok := op.(interface {
+		DispatchError(ctx *ErrorContext[error]) (Resumed, bool)
+	}); ok {
+		v, _ := eop.DispatchError(h.ctx)
+		if h.ctx.HasErr {
+			return Left[error, A](h.ctx.Err), false
+		}
+		return v, true
+	}
+	unhandledEffect("AuthorizationErrorHandler", op)
+	return nil, false
+}
+
+// RunAuthorized runs a computation with Authorize decided by policy and
+// Error[error] effects. Denied accesses end in Left([*ForbiddenError]).
+func RunAuthorized[Act, Res, A any](policy Policy[Act, Res], m Cont[Resumed, A]) Either[error, A] {
+	var ctx ErrorContext[error]
+	h := &authorizationErrorHandler[Act, Res, A]{policy: policy, ctx: &ctx}
+	result := m(rightCont[error, A])
+	if result == nil {
+		var zero A
+		return Right[error, A](zero)
+	}
+	return handleDispatch[*authorizationErrorHandler[Act, Res, A], Either[error, A]](result, h)
+}
+
+// RunAuthorizedExpr runs an Expr with Authorize decided by policy and Error[error] effects.
+func RunAuthorizedExpr[Act, Res, A any](policy Policy[Act, Res], m Expr[A]) Either[error, A] {
+	wrapped := ExprMap(m, func(a A) Either[error, A] { return Right[error, A](a) })
+	var ctx ErrorContext[error]
+	h := &authorizationErrorHandler[Act, Res, A]{policy: policy, ctx: &ctx}
+	return HandleExpr(wrapped, h)
+}
@@ -0,0 +1,141 @@
+// ©Hayabusa Cloud Co., Ltd. 2026. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package kont_test
+
+import (
+	"errors"
+	"testing"
+
+	"code.hybscloud.com/kont"
+)
+
+type account struct {
+	Owner   string
+	Balance int
+}
+
+// ownerPolicy allows reads of any account with the balance redacted,
+// full reads only for "alice", and denies every write.
+func ownerPolicy(action string, acct account) (kont.Verdict, account) {
+	switch {
+	case action == "write":
+		return kont.VerdictDeny, account{}
+	case acct.Owner == "alice":
+		return kont.VerdictAllow, acct
+	default:
+		acct.Balance = 0
+		return kont.VerdictFilter, acct
+	}
+}
+
+func TestCheckAccessAllow(t *testing.T) {
+	comp := kont.CheckAccess("read", account{Owner: "alice", Balance: 10})
+	result := kont.RunAuthorized[string, account](ownerPolicy, comp)
+	acct, ok := result.GetRight()
+	if !ok || acct.Balance != 10 {
+		t.Fatalf("got %+v, want balance 10", result)
+	}
+}
+
+func TestCheckAccessUnknownVerdictDenies(t *testing.T) {
+	for _, v := range []kont.Verdict{0, kont.VerdictFilter + 1} {
+		policy := func(string, account) (kont.Verdict, account) { return v, account{} }
+		result := kont.RunAuthorized[string, account](policy, kont.CheckAccess("read", account{Owner: "alice"}))
+		if err, ok := result.GetLeft(); !ok || !errors.Is(err, kont.ErrForbidden) {
+			t.Fatalf("verdict %d: got %+v, want ErrForbidden", v, result)
+		}
+		resultExpr := kont.RunAuthorizedExpr[string, account](policy, kont.ExprCheckAccess("read", account{Owner: "alice"}))
+		if err, ok := resultExpr.GetLeft(); !ok || !errors.Is(err, kont.ErrForbidden) {
+			t.Fatalf("Expr verdict %d: got %+v, want ErrForbidden", v, resultExpr)
+		}
+	}
+}
+
+func TestCheckAccessFilter(t *testing.T) {
+	comp := kont.CheckAccess("read", account{Owner: "bob", Balance: 10})
+	result := kont.RunAuthorized[string, account](ownerPolicy, comp)
+	acct, ok := result.GetRight()
+	if !ok || acct.Owner != "bob" || acct.Balance != 0 {
+		t.Fatalf("got %+v, want redacted balance", result)
+	}
+}
+
+func TestCheckAccessDeny(t *testing.T) {
+	comp := kont.Then(kont.CheckAccess("write", account{Owner: "alice"}), kont.Pure("written"))
+	result := kont.RunAuthorized[string, account](ownerPolicy, comp)
+	err, ok := result.GetLeft()
+	if !ok {
+		t.Fatal("expected Left")
+	}
+	if !errors.Is(err, kont.ErrForbidden) {
+		t.Fatalf("got %v, want ErrForbidden", err)
+	}
+	var fe *kont.ForbiddenError
+	if !errors.As(err, &fe) || fe.Action != "write" {
+		t.Fatalf("got %#v", err)
+	}
+	if err.Error() != "kont: forbidden: write on {alice 0}" {
+		t.Fatalf("got message %q", err.Error())
+	}
+}
+
+func TestAuthorizationHandler(t *testing.T) {
+	h := kont.AuthorizationHandler[string, account, kont.Pair[kont.Verdict, account]](ownerPolicy)
+	comp := kont.Perform(kont.Authorize[string, account]{Action: "read", Resource: account{Owner: "bob", Balance: 3}})
+	got := kont.Handle(comp, h)
+	if got.Fst != kont.VerdictFilter || got.Snd.Balance != 0 {
+		t.Fatalf("got %+v", got)
+	}
+}
+
+func TestRunAuthorizedExpr(t *testing.T) {
+	allowed := kont.ExprCheckAccess("read", account{Owner: "alice", Balance: 7})
+	if r := kont.RunAuthorizedExpr[string, account](ownerPolicy, allowed); !r.IsRight() {
+		t.Fatalf("got %+v, want Right", r)
+	}
+	denied := kont.ExprCheckAccess("write", account{Owner: "alice"})
+	r := kont.RunAuthorizedExpr[string, account](ownerPolicy, denied)
+	if err, ok := r.GetLeft(); !ok || !errors.Is(err, kont.ErrForbidden) {
+		t.Fatalf("got %+v, want ErrForbidden", r)
+	}
+}
+
+func TestRunAuthorizedNilResult(t *testing.T) {
+	comp := kont.Suspend[kont.Resumed, error](func(k func(error) kont.Resumed) kont.Resumed {
+		return nil
+	})
+	r := kont.RunAuthorized[string, account](ownerPolicy, comp)
+	if v, ok := r.GetRight(); !ok || v != nil {
+		t.Fatalf("got %+v, want Right(nil)", r)
+	}
+}
+
+func TestAuthorizationHandlerUnhandledPanic(t *testing.T) {
+	defer func() {
+		if r := recover(); unhandledIn(r) != "AuthorizationHandler" {
+			t.Fatalf("got panic %v", r)
+		}
+	}()
+	h := kont.AuthorizationHandler[string, account, int](ownerPolicy)
+	kont.Handle(kont.Perform(kont.Get[int]{}), h)
+}
+
+func TestAuthorizationErrorHandlerUnhandledPanic(t *testing.T) {
+	defer func() {
+		if r := recover(); unhandledIn(r) != "AuthorizationErrorHandler" {
+			t.Fatalf("got panic %v", r)
+		}
+	}()
+	kont.RunAuthorized[string, account](ownerPolicy, kont.Perform(kont.Get[int]{}))
+}
+
+func TestOpResultPanicAuthorize(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Fatal("expected phantom panic")
+		}
+	}()
+	kont.Authorize[string, int]{}.OpResult()
+}
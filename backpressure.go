@@ -0,0 +1,83 @@
+// ©Hayabusa Cloud Co., Ltd. 2026. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package kont
+
+// Backpressure-aware Writer output.
+// TellBlocking delivers output to a bounded channel sink. Under Handle the
+// handler blocks while the sink is saturated; under Step the driver calls
+// TryDeliver and keeps the suspension parked until the sink drains, so
+// output never grows memory beyond the sink's capacity.
+
+// TellBlocking is the effect operation for delivering output to a bounded sink.
+// Perform(TellBlocking[W]{Value: w}) resumes once w has been accepted.
+type TellBlocking[W any] struct{ Value W }
+
+func (TellBlocking[W]) OpResult() struct{} { panic("phantom") }
+
+// TryDeliver offers the value to sink without blocking.
+// Reports false when the sink is saturated; the caller should keep the
+// suspension parked and retry after the sink drains.
+func (o TellBlocking[W]) TryDeliver(sink chan<- W) bool {
+	select {
+	case sink <- o.Value:
+		return true
+	default:
+		return false
+	}
+}
+
+// TellBlockingWriter fuses TellBlocking + Then: delivers w, then runs next.
+func TellBlockingWriter[W, B any](w W, next Cont[Resumed, B]) Cont[Resumed, B] {
+	resume := thenMarkerResume[B]
+	at := captureProvenance()
+	return func(k func(B) Resumed) Resumed {
+		m := acquireMarker()
+		m.op = TellBlocking[W]{Value: w}
+		m.f = next
+		m.k = k
+		m.resume = resume
+		m.at = at
+		return m
+	}
+}
+
+// ExprTellBlocking performs TellBlocking in an Expr computation.
+func ExprTellBlocking[W any](w W) Expr[struct{}] {
+	return ExprPerform(TellBlocking[W]{Value: w})
+}
+
+// blockingTellHandler implements Handler for backpressured writer handling.
+type blockingTellHandler[W, R any] struct {
+	sink chan<- W
+}
+
+// Dispatch implements Handler for backpressured writer handling.
+// Blocks while the sink is saturated.
+func (h *blockingTellHandler[W, R]) Dispatch(op Operation) (Resumed, bool) {
+	if t, ok := op.(TellBlocking[W]); ok {
+		h.sink <- t.Value
+		return struct{}{}, true
+	}
+	unhandledEffect("BlockingTellHandler", op)
+	return nil, false
+}
+
+// BlockingTellHandler creates a handler delivering TellBlocking output to sink.
+// Returns a concrete handler.
+func BlockingTellHandler[W, R any](sink chan<- W) *blockingTellHandler[W, R] {
+	return &blockingTellHandler[W, R]{sink: sink}
+}
+
+// RunTellBlocking runs a computation delivering TellBlocking output to sink,
+// blocking while the sink is saturated. A consumer must drain sink
+// concurrently, or the run deadlocks once the sink fills.
+func RunTellBlocking[W, A any](sink chan<- W, m Cont[Resumed, A]) A {
+	return Handle(m, BlockingTellHandler[W, A](sink))
+}
+
+// RunTellBlockingExpr is the Expr counterpart of [RunTellBlocking].
+func RunTellBlockingExpr[W, A any](sink chan<- W, m Expr[A]) A {
+	return HandleExpr(m, BlockingTellHandler[W, A](sink))
+}
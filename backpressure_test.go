@@ -0,0 +1,84 @@
+// ©Hayabusa Cloud Co., Ltd. 2026. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package kont_test
+
+import (
+	"slices"
+	"testing"
+
+	"code.hybscloud.com/kont"
+)
+
+func TestTellBlockingStepParksWhenSaturated(t *testing.T) {
+	sink := make(chan int, 1)
+	comp := kont.TellBlockingWriter(1, kont.TellBlockingWriter(2, kont.Pure("done")))
+
+	_, susp := kont.Step(comp)
+	tb := susp.Op().(kont.TellBlocking[int])
+	if !tb.TryDeliver(sink) {
+		t.Fatal("empty sink must accept")
+	}
+	_, susp = susp.Resume(struct{}{})
+
+	tb = susp.Op().(kont.TellBlocking[int])
+	if tb.TryDeliver(sink) {
+		t.Fatal("saturated sink must refuse")
+	}
+	// Parked: drain, then retry the same suspension.
+	if got := <-sink; got != 1 {
+		t.Fatalf("drained %d, want 1", got)
+	}
+	if !tb.TryDeliver(sink) {
+		t.Fatal("drained sink must accept")
+	}
+	result, susp := susp.Resume(struct{}{})
+	if susp != nil || result != "done" {
+		t.Fatalf("got (%q, %v), want done", result, susp)
+	}
+	if got := <-sink; got != 2 {
+		t.Fatalf("drained %d, want 2", got)
+	}
+}
+
+func TestRunTellBlockingWithConsumer(t *testing.T) {
+	sink := make(chan int, 2)
+	done := make(chan []int)
+	go func() {
+		var got []int
+		for v := range sink {
+			got = append(got, v)
+		}
+		done <- got
+	}()
+	comp := kont.Pure(0)
+	for i := 9; i >= 0; i-- {
+		comp = kont.TellBlockingWriter(i, comp)
+	}
+	kont.RunTellBlocking(sink, comp)
+	close(sink)
+	if got := <-done; !slices.Equal(got, []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}) {
+		t.Fatalf("got %v", got)
+	}
+}
+
+func TestRunTellBlockingExpr(t *testing.T) {
+	sink := make(chan string, 2)
+	comp := kont.ExprThen(kont.ExprTellBlocking("a"), kont.ExprThen(kont.ExprTellBlocking("b"), kont.ExprReturn(2)))
+	if got := kont.RunTellBlockingExpr(sink, comp); got != 2 {
+		t.Fatalf("got %d, want 2", got)
+	}
+	if a, b := <-sink, <-sink; a != "a" || b != "b" {
+		t.Fatalf("got %q %q", a, b)
+	}
+}
+
+func TestBlockingTellHandlerUnhandledPanic(t *testing.T) {
+	defer func() {
+		if r := recover(); unhandledIn(r) != "BlockingTellHandler" {
+			t.Fatalf("got panic %v", r)
+		}
+	}()
+	kont.RunTellBlocking(make(chan int, 1), kont.Perform(kont.Tell[int]{Value: 1}))
+}
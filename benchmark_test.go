@@ -282,3 +282,46 @@ func BenchmarkBracket(b *testing.B) {
 			}))
 	}
 }
+
+// mapChain builds an Expr that reads an int and applies n Map frames
+// producing values of type A.
+func mapChain[A any](n int, f func(int) A, g func(A) A) kont.Expr[A] {
+	m := kont.ExprMap(kont.ExprPerform(kont.Ask[int]{}), f)
+	for range n {
+		m = kont.ExprMap(m, g)
+	}
+	return m
+}
+
+// BenchmarkExprMapChainBoxing measures how the type flowing through a Map
+// chain affects allocation. ExprMap fuses consecutive maps into one frame,
+// so only the chain's result passes through the evaluator's Erased
+// register: zero-size values and integers below 256 box without
+// allocating, while larger integers and strings allocate once.
+func BenchmarkExprMapChainBoxing(b *testing.B) {
+	const n = 8
+	b.Run("struct{}", func(b *testing.B) {
+		m := mapChain(n, func(int) struct{} { return struct{}{} }, func(s struct{}) struct{} { return s })
+		for b.Loop() {
+			_ = kont.RunReaderExpr[int, struct{}](1, m)
+		}
+	})
+	b.Run("int/small", func(b *testing.B) {
+		m := mapChain(n, func(x int) int { return x }, func(x int) int { return x + 1 })
+		for b.Loop() {
+			_ = kont.RunReaderExpr[int, int](1, m)
+		}
+	})
+	b.Run("int/large", func(b *testing.B) {
+		m := mapChain(n, func(x int) int { return x }, func(x int) int { return x + 1 })
+		for b.Loop() {
+			_ = kont.RunReaderExpr[int, int](1<<20, m)
+		}
+	})
+	b.Run("string", func(b *testing.B) {
+		m := mapChain(n, func(int) string { return "kont" }, func(s string) string { return s[1:] + s[:1] })
+		for b.Loop() {
+			_ = kont.RunReaderExpr[int, string](1, m)
+		}
+	})
+}
@@ -41,8 +41,11 @@ func fromResumed[A any](r Resumed) Expr[A] {
 	return Expr[A]{
 		Value: zero,
 		Frame: &EffectFrame[Erased]{
-			Operation: s.Op(),
-			Resume:    func(v Erased) Erased { return s.Resume(v) },
+			Operation:       s.Op(),
+			Resume:          func(v Erased) Erased { return s.Resume(v) },
+			forwards:        true,
+			resumeWithError: func(throw Operation) Erased { return s.resumeWithError(throw) },
+			at:              s.site(),
 			Next: &BindFrame[Erased, Erased]{
 				F: func(v Erased) Expr[Erased] {
 					e := fromResumed[A](v)
@@ -87,6 +90,7 @@ func (p reflectProcessor[A]) processEffect(f *EffectFrame[Erased], rest Frame) (
 	capturedF := f
 	m := acquireMarker()
 	m.op = capturedF.Operation
+	m.at = capturedF.at
 	m.k = func(v Erased) Resumed {
 		return evalFrames[reflectProcessor[A], Resumed](capturedF.Resume(v), rest, p)
 	}
@@ -97,3 +101,5 @@ func (p reflectProcessor[A]) processEffect(f *EffectFrame[Erased], rest Frame) (
 func (p reflectProcessor[A]) processReturn(current Erased) Resumed {
 	return p.k(valueOrZero[A](current))
 }
+
+func (reflectProcessor[A]) ownsFrames() bool { return true }
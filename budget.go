@@ -0,0 +1,121 @@
+// ©Hayabusa Cloud Co., Ltd. 2026. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package kont
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Construction budgets.
+// Untrusted program builders (rule engines, user scripts) can construct
+// unbounded Expr structures. The Try constructors charge every frame node
+// they allocate against a FrameBudget and fail once it is exhausted.
+// Since the frame chain is the only structure Expr construction grows, the
+// count also bounds chain depth.
+
+// ErrFrameLimit is the sentinel matched by errors.Is when a budget is exhausted.
+var ErrFrameLimit = errors.New("kont: frame limit exceeded")
+
+// FrameLimitError reports an Expr construction that would exceed its budget.
+// It matches [ErrFrameLimit] under errors.Is.
+type FrameLimitError struct {
+	Limit int
+}
+
+func (e *FrameLimitError) Error() string {
+	return fmt.Sprintf("kont: frame limit %d exceeded", e.Limit)
+}
+
+// Unwrap returns ErrFrameLimit.
+func (e *FrameLimitError) Unwrap() error { return ErrFrameLimit }
+
+// FrameBudget bounds the number of frame nodes allocated through the Try
+// constructors. A nil *FrameBudget is unlimited.
+// A FrameBudget is not safe for concurrent use.
+type FrameBudget struct {
+	max  int
+	used int
+}
+
+// WithMaxFrames creates a FrameBudget allowing up to n frame nodes.
+func WithMaxFrames(n int) *FrameBudget {
+	return &FrameBudget{max: n}
+}
+
+// Used returns the number of frame nodes charged so far.
+func (b *FrameBudget) Used() int {
+	if b == nil {
+		return 0
+	}
+	return b.used
+}
+
+// charge reserves n frame nodes, reporting an error if the budget is exceeded.
+func (b *FrameBudget) charge(n int) error {
+	if b == nil || n == 0 {
+		return nil
+	}
+	if b.used+n > b.max {
+		return &FrameLimitError{Limit: b.max}
+	}
+	b.used += n
+	return nil
+}
+
+// chainCost returns the number of frame nodes ChainFrames allocates.
+func chainCost(first, second Frame) int {
+	if _, ok := first.(ReturnFrame); ok {
+		return 0
+	}
+	if _, ok := second.(ReturnFrame); ok {
+		return 0
+	}
+	return 1
+}
+
+// TryChainFrames is [ChainFrames] charged against b.
+func TryChainFrames(b *FrameBudget, first, second Frame) (Frame, error) {
+	if err := b.charge(chainCost(first, second)); err != nil {
+		return nil, err
+	}
+	return ChainFrames(first, second), nil
+}
+
+// TryExprBind is [ExprBind] charged against b.
+// Charges the bind frame and its chain node; nothing when m is completed.
+func TryExprBind[A, B any](b *FrameBudget, m Expr[A], f func(A) Expr[B]) (Expr[B], error) {
+	if err := b.charge(exprCost(m.Frame)); err != nil {
+		return Expr[B]{}, err
+	}
+	return ExprBind(m, f), nil
+}
+
+// TryExprMap is [ExprMap] charged against b.
+// Charges the map frame and its chain node; nothing when m is completed.
+func TryExprMap[A, B any](b *FrameBudget, m Expr[A], f func(A) B) (Expr[B], error) {
+	if err := b.charge(exprCost(m.Frame)); err != nil {
+		return Expr[B]{}, err
+	}
+	return ExprMap(m, f), nil
+}
+
+// TryExprThen is [ExprThen] charged against b.
+// Charges the then frame and its chain node; nothing when m is completed.
+func TryExprThen[A, B any](b *FrameBudget, m Expr[A], n Expr[B]) (Expr[B], error) {
+	if err := b.charge(exprCost(m.Frame)); err != nil {
+		return Expr[B]{}, err
+	}
+	return ExprThen(m, n), nil
+}
+
+// exprCost returns the number of frame nodes ExprBind, ExprMap, and ExprThen
+// allocate for a computation with frame m: the new frame plus its chain node.
+func exprCost(m Frame) int {
+	if _, ok := m.(ReturnFrame); ok {
+		return 0
+	}
+	return 2
+}
@@ -0,0 +1,95 @@
+// ©Hayabusa Cloud Co., Ltd. 2026. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package kont_test
+
+import (
+	"errors"
+	"testing"
+
+	"code.hybscloud.com/kont"
+)
+
+func TestTryExprBindWithinBudget(t *testing.T) {
+	b := kont.WithMaxFrames(4)
+	m := kont.ExprPerform(kont.Get[int]{})
+	e, err := kont.TryExprBind(b, m, func(s int) kont.Expr[int] { return kont.ExprReturn(s + 1) })
+	if err != nil {
+		t.Fatal(err)
+	}
+	e2, err := kont.TryExprMap(b, e, func(x int) int { return x * 2 })
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b.Used() != 4 {
+		t.Fatalf("used %d, want 4", b.Used())
+	}
+	got, _ := kont.RunStateExpr[int, int](3, e2)
+	if got != 8 {
+		t.Fatalf("got %d, want 8", got)
+	}
+}
+
+func TestTryExprLoopExceedsBudget(t *testing.T) {
+	b := kont.WithMaxFrames(10)
+	e := kont.ExprPerform(kont.Get[int]{})
+	var err error
+	var built int
+	for range 100 {
+		e, err = kont.TryExprThen(b, e, kont.ExprPerform(kont.Get[int]{}))
+		if err != nil {
+			break
+		}
+		built++
+	}
+	if !errors.Is(err, kont.ErrFrameLimit) {
+		t.Fatalf("got %v, want ErrFrameLimit", err)
+	}
+	var fe *kont.FrameLimitError
+	if !errors.As(err, &fe) || fe.Limit != 10 || err.Error() != "kont: frame limit 10 exceeded" {
+		t.Fatalf("got %v", err)
+	}
+	if built != 5 || b.Used() != 10 {
+		t.Fatalf("built %d (used %d), want 5 (10)", built, b.Used())
+	}
+}
+
+func TestTryExprCompletedIsFree(t *testing.T) {
+	b := kont.WithMaxFrames(0)
+	e, err := kont.TryExprMap(b, kont.ExprReturn(1), func(x int) int { return x + 1 })
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := kont.RunPure(e); got != 2 {
+		t.Fatalf("got %d, want 2", got)
+	}
+}
+
+func TestTryChainFrames(t *testing.T) {
+	b := kont.WithMaxFrames(1)
+	f := kont.ExprPerform(kont.Get[int]{}).Frame
+	if _, err := kont.TryChainFrames(b, kont.ReturnFrame{}, f); err != nil || b.Used() != 0 {
+		t.Fatalf("chaining onto ReturnFrame must be free: %v, used %d", err, b.Used())
+	}
+	if _, err := kont.TryChainFrames(b, f, f); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := kont.TryChainFrames(b, f, f); !errors.Is(err, kont.ErrFrameLimit) {
+		t.Fatalf("got %v, want ErrFrameLimit", err)
+	}
+}
+
+func TestNilFrameBudgetUnlimited(t *testing.T) {
+	var b *kont.FrameBudget
+	e := kont.ExprPerform(kont.Get[int]{})
+	for range 1000 {
+		var err error
+		if e, err = kont.TryExprThen(b, e, kont.ExprPerform(kont.Get[int]{})); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if b.Used() != 0 {
+		t.Fatalf("nil budget used %d", b.Used())
+	}
+}
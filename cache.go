@@ -0,0 +1,332 @@
+// ©Hayabusa Cloud Co., Ltd. 2026. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package kont
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+// Cache effect operations.
+// Cache[K, V] provides keyed lookups with time-to-live expiry.
+
+// CacheGet is the effect operation for reading a cached value.
+// Perform(CacheGet[K, V]{Key: k}) returns the value and true on a hit,
+// or the zero value and false on a miss or expired entry.
+type CacheGet[K comparable, V any] struct{ Key K }
+
+func (CacheGet[K, V]) OpResult() Pair[V, bool] { panic("phantom") }
+
+// DispatchCache handles CacheGet in Cache handler dispatch.
+func (o CacheGet[K, V]) DispatchCache(c *LRUCache[K, V]) (Resumed, bool) {
+	v, ok := c.lead(o.Key)
+	return Pair[V, bool]{Fst: v, Snd: ok}, true
+}
+
+// CachePut is the effect operation for storing a value.
+// Perform(CachePut[K, V]{Key: k, Value: v, TTL: d}) stores v for d;
+// a non-positive TTL never expires.
+type CachePut[K comparable, V any] struct {
+	Key   K
+	Value V
+	TTL   time.Duration
+}
+
+func (CachePut[K, V]) OpResult() struct{} { panic("phantom") }
+
+// DispatchCache handles CachePut in Cache handler dispatch.
+func (o CachePut[K, V]) DispatchCache(c *LRUCache[K, V]) (Resumed, bool) {
+	c.Put(o.Key, o.Value, o.TTL)
+	return struct{}{}, true
+}
+
+// CacheAbandon is the effect operation for giving up a fill.
+// Perform(CacheAbandon[K, V]{Key: k}) wakes misses waiting on k without
+// storing a value, so that they compute k themselves.
+type CacheAbandon[K comparable, V any] struct{ Key K }
+
+func (CacheAbandon[K, V]) OpResult() struct{} { panic("phantom") }
+
+// DispatchCache handles CacheAbandon in Cache handler dispatch.
+func (o CacheAbandon[K, V]) DispatchCache(c *LRUCache[K, V]) (Resumed, bool) {
+	c.Abandon(o.Key)
+	return struct{}{}, true
+}
+
+// Cached returns the cached value for key, or runs compute on a miss and
+// stores its result for ttl. Effects in compute stay on the enclosing
+// computation. Concurrent misses on the same key are coalesced by the
+// handler's cache (see [CacheOptions.FillWait]); if compute throws,
+// CacheAbandon is performed before the Throw so that waiting misses do not
+// wait out FillWait. A compute that panics, or is discarded or
+// short-circuited by another effect, still leaves them waiting.
+func Cached[K comparable, V any](key K, ttl time.Duration, compute Cont[Resumed, V]) Cont[Resumed, V] {
+	return Bind(Perform(CacheGet[K, V]{Key: key}), func(r Pair[V, bool]) Cont[Resumed, V] {
+		if r.Snd {
+			return Pure(r.Fst)
+		}
+		return Bind(abandonOnThrow(compute, CacheAbandon[K, V]{Key: key}), func(v V) Cont[Resumed, V] {
+			return Then(Perform(CachePut[K, V]{Key: key, Value: v, TTL: ttl}), Pure(v))
+		})
+	})
+}
+
+// ExprCached is the Expr counterpart of [Cached].
+func ExprCached[K comparable, V any](key K, ttl time.Duration, compute Expr[V]) Expr[V] {
+	return ExprBind(ExprPerform(CacheGet[K, V]{Key: key}), func(r Pair[V, bool]) Expr[V] {
+		if r.Snd {
+			return ExprReturn(r.Fst)
+		}
+		return ExprBind(Reify(abandonOnThrow(Reflect(compute), CacheAbandon[K, V]{Key: key})), func(v V) Expr[V] {
+			return ExprThen(ExprPerform(CachePut[K, V]{Key: key, Value: v, TTL: ttl}), ExprReturn(v))
+		})
+	})
+}
+
+// abandonOnThrow runs m, performing abandon before the first Throw raised
+// by m. Other operations pass through unchanged.
+func abandonOnThrow[A any](m Cont[Resumed, A], abandon Operation) Cont[Resumed, A] {
+	return func(k func(A) Resumed) Resumed {
+		return abandonResult(m(scopeDoneCont[A]), abandon, k)
+	}
+}
+
+// abandonSuspension wraps a suspension raised inside abandonOnThrow. While
+// pending, it presents abandon in place of the inner Throw, which is
+// presented once abandon is resumed.
+type abandonSuspension[A any] struct {
+	inner   effectSuspension
+	abandon Operation
+	pending bool
+	k       func(A) Resumed
+}
+
+func (s *abandonSuspension[A]) Op() Operation {
+	if s.pending {
+		return s.abandon
+	}
+	return s.inner.Op()
+}
+
+func (s *abandonSuspension[A]) Resume(v Resumed) Resumed {
+	if s.pending {
+		return &abandonSuspension[A]{inner: s.inner, k: s.k}
+	}
+	return abandonResult(s.inner.Resume(v), s.abandon, s.k)
+}
+
+func (s *abandonSuspension[A]) resumeWithError(throw Operation) Resumed {
+	if s.pending {
+		return &abandonSuspension[A]{inner: s.inner, k: s.k}
+	}
+	return abandonResult(s.inner.resumeWithError(throw), s.abandon, s.k)
+}
+
+func (s *abandonSuspension[A]) release()         { s.inner.release() }
+func (s *abandonSuspension[A]) site() provenance { return s.inner.site() }
+
+func abandonResult[A any](r Resumed, abandon Operation, k func(A) Resumed) Resumed {
+	switch r := r.(type) {
+	case scopeDone[A]:
+		return k(r.value)
+	case effectSuspension:
+		_, thrown := r.Op().(interface{ throw() })
+		return &abandonSuspension[A]{inner: r, abandon: abandon, pending: abandon != nil && thrown, k: k}
+	}
+	return r
+}
+
+// CacheOptions configures an [LRUCache].
+type CacheOptions struct {
+	// Capacity bounds the number of entries; the least recently used entry
+	// is evicted first. Zero means unbounded.
+	Capacity int
+	// Now reads the clock used for expiry. Nil means time.Now; pass
+	// (*VirtualClock).Now to test expiry deterministically.
+	Now func() time.Time
+	// FillWait enables stampede protection: while one miss is computing a
+	// key, concurrent misses on that key wait up to FillWait for its CachePut
+	// before computing themselves. Zero disables coalescing.
+	FillWait time.Duration
+	// After measures FillWait. Nil means time.After; pass
+	// (*VirtualClock).After to test stampede timeouts deterministically.
+	After func(time.Duration) <-chan time.Time
+}
+
+type cacheEntry[K comparable, V any] struct {
+	key     K
+	value   V
+	expires time.Time
+}
+
+// LRUCache is an in-memory least-recently-used cache with per-entry expiry.
+// It is safe for concurrent use and may be shared by handlers on several goroutines.
+type LRUCache[K comparable, V any] struct {
+	mu      sync.Mutex
+	opts    CacheOptions
+	order   *list.List
+	entries map[K]*list.Element
+	filling map[K]chan struct{}
+}
+
+// NewLRUCache creates an empty LRUCache.
+func NewLRUCache[K comparable, V any](opts CacheOptions) *LRUCache[K, V] {
+	if opts.Now == nil {
+		opts.Now = time.Now
+	}
+	if opts.After == nil {
+		opts.After = time.After
+	}
+	return &LRUCache[K, V]{
+		opts:    opts,
+		order:   list.New(),
+		entries: make(map[K]*list.Element),
+		filling: make(map[K]chan struct{}),
+	}
+}
+
+// Get returns the live value for key and marks it recently used.
+func (c *LRUCache[K, V]) Get(key K) (V, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.getLocked(key)
+}
+
+func (c *LRUCache[K, V]) getLocked(key K) (V, bool) {
+	el, ok := c.entries[key]
+	if !ok {
+		var zero V
+		return zero, false
+	}
+	e := el.Value.(*cacheEntry[K, V])
+	if !e.expires.IsZero() && !c.opts.Now().Before(e.expires) {
+		c.order.Remove(el)
+		delete(c.entries, key)
+		var zero V
+		return zero, false
+	}
+	c.order.MoveToFront(el)
+	return e.value, true
+}
+
+// lead is Get with stampede protection: on a miss the caller becomes the
+// filler of key, unless another filler is in flight, in which case it waits
+// up to FillWait for that fill.
+func (c *LRUCache[K, V]) lead(key K) (V, bool) {
+	c.mu.Lock()
+	v, ok := c.getLocked(key)
+	if ok || c.opts.FillWait <= 0 {
+		c.mu.Unlock()
+		return v, ok
+	}
+	done, inflight := c.filling[key]
+	if !inflight {
+		c.filling[key] = make(chan struct{})
+		c.mu.Unlock()
+		return v, false
+	}
+	c.mu.Unlock()
+
+	select {
+	case <-done:
+		return c.Get(key)
+	case <-c.opts.After(c.opts.FillWait):
+		var zero V
+		return zero, false
+	}
+}
+
+// Put stores value under key for ttl and wakes misses waiting on key.
+// A non-positive ttl never expires.
+func (c *LRUCache[K, V]) Put(key K, value V, ttl time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	var expires time.Time
+	if ttl > 0 {
+		expires = c.opts.Now().Add(ttl)
+	}
+	c.wakeLocked(key)
+	if el, ok := c.entries[key]; ok {
+		e := el.Value.(*cacheEntry[K, V])
+		e.value = value
+		e.expires = expires
+		c.order.MoveToFront(el)
+		return
+	}
+	c.entries[key] = c.order.PushFront(&cacheEntry[K, V]{key: key, value: value, expires: expires})
+	if c.opts.Capacity > 0 && c.order.Len() > c.opts.Capacity {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*cacheEntry[K, V]).key)
+	}
+}
+
+// Abandon wakes misses waiting on key without storing a value, so that
+// they compute key themselves. It is a no-op when no fill is in flight.
+func (c *LRUCache[K, V]) Abandon(key K) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.wakeLocked(key)
+}
+
+func (c *LRUCache[K, V]) wakeLocked(key K) {
+	if done, ok := c.filling[key]; ok {
+		close(done)
+		delete(c.filling, key)
+	}
+}
+
+// Len returns the number of stored entries, including expired entries not yet evicted.
+func (c *LRUCache[K, V]) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.order.Len()
+}
+
+// Keys returns the stored keys from most to least recently used, including
+// expired entries not yet evicted. The order depends only on the sequence
+// of Get and Put calls, so it is stable across replays.
+func (c *LRUCache[K, V]) Keys() []K {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	keys := make([]K, 0, c.order.Len())
+	for el := c.order.Front(); el != nil; el = el.Next() {
+		keys = append(keys, el.Value.(*cacheEntry[K, V]).key)
+	}
+	return keys
+}
+
+// cacheHandler implements Handler for cache handling.
+type cacheHandler[K comparable, V, R any] struct {
+	cache *LRUCache[K, V]
+}
+
+// Dispatch implements Handler for cache handling.
+func (h *cacheHandler[K, V, R]) Dispatch(op Operation) (Resumed, bool) {
+	if cop, ok := op.(interface {
+		DispatchCache(c *LRUCache[K, V]) (Resumed, bool)
+	}); ok {
+		return cop.DispatchCache(h.cache)
+	}
+	unhandledEffect("CacheHandler", op)
+	return nil, false
+}
+
+// CacheHandler creates a handler for Cache effects backed by c.
+// Returns a concrete handler.
+func CacheHandler[K comparable, V, R any](c *LRUCache[K, V]) *cacheHandler[K, V, R] {
+	return &cacheHandler[K, V, R]{cache: c}
+}
+
+// RunCache runs a computation whose only effects are Cache operations.
+func RunCache[K comparable, V, A any](c *LRUCache[K, V], m Cont[Resumed, A]) A {
+	return Handle(m, CacheHandler[K, V, A](c))
+}
+
+// RunCacheExpr runs an Expr computation whose only effects are Cache operations.
+func RunCacheExpr[K comparable, V, A any](c *LRUCache[K, V], m Expr[A]) A {
+	return HandleExpr(m, CacheHandler[K, V, A](c))
+}
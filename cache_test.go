@@ -0,0 +1,200 @@
+// ©Hayabusa Cloud Co., Ltd. 2026. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package kont_test
+
+import (
+	"slices"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"code.hybscloud.com/kont"
+)
+
+func countingCompute(n *atomic.Int32, v string) kont.Eff[string] {
+	return kont.Suspend[kont.Resumed, string](func(k func(string) kont.Resumed) kont.Resumed {
+		n.Add(1)
+		return k(v)
+	})
+}
+
+func TestCachedHitAndMiss(t *testing.T) {
+	c := kont.NewLRUCache[string, string](kont.CacheOptions{})
+	var n atomic.Int32
+	comp := kont.Cached("user:1", 0, countingCompute(&n, "alice"))
+
+	for range 3 {
+		if got := kont.RunCache(c, comp); got != "alice" {
+			t.Fatalf("got %q, want alice", got)
+		}
+	}
+	if n.Load() != 1 {
+		t.Fatalf("computed %d times, want 1", n.Load())
+	}
+}
+
+func TestCachedExpiryVirtualClock(t *testing.T) {
+	clock := kont.NewVirtualClock(time.Unix(0, 0))
+	c := kont.NewLRUCache[string, int](kont.CacheOptions{Now: clock.Now})
+	kont.RunCache(c, kont.Perform(kont.CachePut[string, int]{Key: "k", Value: 1, TTL: time.Minute}))
+
+	clock.Advance(59 * time.Second)
+	if r := kont.RunCache(c, kont.Perform(kont.CacheGet[string, int]{Key: "k"})); !r.Snd || r.Fst != 1 {
+		t.Fatalf("got %+v, want hit 1", r)
+	}
+	clock.Advance(time.Second)
+	if r := kont.RunCache(c, kont.Perform(kont.CacheGet[string, int]{Key: "k"})); r.Snd {
+		t.Fatalf("got %+v, want miss after expiry", r)
+	}
+	if c.Len() != 0 {
+		t.Fatalf("expired entry not evicted, len %d", c.Len())
+	}
+}
+
+func TestLRUCacheEviction(t *testing.T) {
+	c := kont.NewLRUCache[int, int](kont.CacheOptions{Capacity: 2})
+	c.Put(1, 10, 0)
+	c.Put(2, 20, 0)
+	c.Get(1) // 2 is now least recently used
+	c.Put(3, 30, 0)
+
+	if _, ok := c.Get(2); ok {
+		t.Fatal("least recently used entry should be evicted")
+	}
+	if v, ok := c.Get(1); !ok || v != 10 {
+		t.Fatalf("got %d, %v, want 10", v, ok)
+	}
+	c.Put(1, 11, 0)
+	if v, _ := c.Get(1); v != 11 || c.Len() != 2 {
+		t.Fatalf("got %d, len %d, want 11, 2", v, c.Len())
+	}
+}
+
+func TestCachedStampedeCoalesced(t *testing.T) {
+	c := kont.NewLRUCache[string, string](kont.CacheOptions{FillWait: time.Minute})
+	// First miss becomes the filler.
+	if r := kont.RunCache(c, kont.Perform(kont.CacheGet[string, string]{Key: "k"})); r.Snd {
+		t.Fatal("expected miss")
+	}
+
+	var n atomic.Int32
+	done := make(chan string)
+	go func() {
+		done <- kont.RunCache(c, kont.Cached("k", 0, countingCompute(&n, "follower")))
+	}()
+	kont.RunCache(c, kont.Perform(kont.CachePut[string, string]{Key: "k", Value: "leader"}))
+
+	if got := <-done; got != "leader" {
+		t.Fatalf("got %q, want leader's value", got)
+	}
+	if n.Load() != 0 {
+		t.Fatal("follower should not recompute")
+	}
+}
+
+func TestCachedStampedeFillTimeout(t *testing.T) {
+	clock := kont.NewVirtualClock(time.Unix(0, 0))
+	armed := make(chan struct{})
+	c := kont.NewLRUCache[string, string](kont.CacheOptions{
+		Now:      clock.Now,
+		FillWait: time.Minute,
+		After: func(d time.Duration) <-chan time.Time {
+			defer close(armed)
+			return clock.After(d)
+		},
+	})
+	kont.RunCache(c, kont.Perform(kont.CacheGet[string, string]{Key: "k"})) // filler never puts
+
+	go func() {
+		<-armed
+		clock.Advance(time.Minute)
+	}()
+	var n atomic.Int32
+	got := kont.RunCache(c, kont.Cached("k", 0, countingCompute(&n, "fallback")))
+	if got != "fallback" || n.Load() != 1 {
+		t.Fatalf("got %q after %d computes, want fallback after 1", got, n.Load())
+	}
+}
+
+func TestCachedThrowAbandonsFill(t *testing.T) {
+	c := kont.NewLRUCache[string, string](kont.CacheOptions{FillWait: time.Minute})
+	h := kont.ChainHandlers[string](kont.CacheHandler[string, string, string](c).Dispatch, func(op kont.Operation) (kont.Resumed, bool) {
+		return "threw: " + op.(kont.Throw[string]).Err, false
+	})
+	throwing := map[string]kont.Eff[string]{
+		"cont": kont.Cached("cont", 0, kont.ThrowError[string, string]("boom")),
+		"expr": kont.Reflect(kont.ExprCached("expr", 0, kont.ExprThrowError[string, string]("boom"))),
+	}
+	for key, m := range throwing {
+		if got := kont.Handle(m, h); got != "threw: boom" {
+			t.Fatalf("got %q, want threw: boom", got)
+		}
+		var n atomic.Int32
+		start := time.Now()
+		got := kont.RunCache(c, kont.Cached(key, 0, countingCompute(&n, "fresh")))
+		if got != "fresh" || n.Load() != 1 {
+			t.Fatalf("got %q after %d computes, want fresh after 1", got, n.Load())
+		}
+		if waited := time.Since(start); waited > 10*time.Second {
+			t.Fatalf("miss waited %v for an abandoned fill", waited)
+		}
+	}
+}
+
+func TestExprCached(t *testing.T) {
+	c := kont.NewLRUCache[int, int](kont.CacheOptions{})
+	runs := 0
+	compute := kont.ExprMap(kont.ExprReturn(6), func(x int) int {
+		runs++
+		return x * 7
+	})
+	for range 2 {
+		if got := kont.RunCacheExpr(c, kont.ExprCached(1, time.Hour, compute)); got != 42 {
+			t.Fatalf("got %d, want 42", got)
+		}
+	}
+	if runs != 1 {
+		t.Fatalf("computed %d times, want 1", runs)
+	}
+}
+
+func TestCacheHandlerUnhandledPanic(t *testing.T) {
+	defer func() {
+		if r := recover(); unhandledIn(r) != "CacheHandler" {
+			t.Fatalf("got panic %v", r)
+		}
+	}()
+	c := kont.NewLRUCache[int, int](kont.CacheOptions{})
+	kont.RunCache(c, kont.Perform(kont.Get[int]{}))
+}
+
+func TestOpResultPanicCache(t *testing.T) {
+	for _, f := range []func(){
+		func() { kont.CacheGet[int, int]{}.OpResult() },
+		func() { kont.CachePut[int, int]{}.OpResult() },
+		func() { kont.CacheAbandon[int, int]{}.OpResult() },
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Fatal("expected phantom panic")
+				}
+			}()
+			f()
+		}()
+	}
+}
+
+func TestLRUCacheKeysRecencyOrder(t *testing.T) {
+	c := kont.NewLRUCache[string, int](kont.CacheOptions{Capacity: 3})
+	c.Put("a", 1, 0)
+	c.Put("b", 2, 0)
+	c.Put("c", 3, 0)
+	c.Get("a")
+	c.Put("d", 4, 0)
+	if got := c.Keys(); !slices.Equal(got, []string{"d", "a", "c"}) {
+		t.Fatalf("Keys %v", got)
+	}
+}
@@ -0,0 +1,82 @@
+// ©Hayabusa Cloud Co., Ltd. 2026. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package kont
+
+import (
+	"context"
+	"errors"
+)
+
+// Cooperative cancellation.
+// Cancellation is observed at explicit check points and raised as a thrown
+// Go error, so it unwinds through Bracket regions like any other error:
+// each region's release runs before the cancellation reaches the caller.
+
+// ErrCancelled is the sentinel matched by errors.Is for cancellation raised
+// by [CheckCancel].
+var ErrCancelled = errors.New("kont: cancelled")
+
+// CancelledError reports a computation cancelled at a check point.
+// It matches [ErrCancelled] under errors.Is, and Unwrap returns the
+// context's cancellation cause.
+type CancelledError struct {
+	Cause error
+}
+
+func (e *CancelledError) Error() string {
+	return "kont: cancelled: " + e.Cause.Error()
+}
+
+// Is reports whether target is ErrCancelled.
+func (e *CancelledError) Is(target error) bool { return target == ErrCancelled }
+
+// Unwrap returns the cancellation cause.
+func (e *CancelledError) Unwrap() error { return e.Cause }
+
+// CheckCancel is a cancellation check point. When it is reached, not when
+// it is constructed, it throws a [*CancelledError] via [ThrowErr] if ctx is
+// done; otherwise it continues with struct{}{}. Unlike [CheckCtx], whose
+// CheckCancelled operation makes a context-aware runner abandon the
+// computation, CheckCancel checks an explicit ctx under any Error handler
+// and unwinds enclosing [BracketErr] regions.
+func CheckCancel(ctx context.Context) Cont[Resumed, struct{}] {
+	return func(k func(struct{}) Resumed) Resumed {
+		if ctx.Err() != nil {
+			return ThrowErr[struct{}](cancelledBy(ctx))(k)
+		}
+		return k(struct{}{})
+	}
+}
+
+// ExprCheckCancel is the Expr counterpart of [CheckCancel].
+func ExprCheckCancel(ctx context.Context) Expr[struct{}] {
+	return ExprSuspend[struct{}](&BindFrame[Erased, Erased]{
+		F: func(Erased) Expr[Erased] {
+			if ctx.Err() != nil {
+				return ExprThrowErr[Erased](cancelledBy(ctx))
+			}
+			return ExprReturn[Erased](struct{}{})
+		},
+		Next: ReturnFrame{},
+	})
+}
+
+// BracketErr is [Bracket] over Go errors that rethrows the error from use
+// after release has run. Nested BracketErr regions therefore unwind
+// innermost first, each releasing its resource, until the error reaches an
+// enclosing [CatchErr] or [RunErr].
+func BracketErr[R, A any](
+	acquire Cont[Resumed, R],
+	release func(R) Cont[Resumed, struct{}],
+	use func(R) Cont[Resumed, A],
+) Cont[Resumed, A] {
+	return Bind(Bracket[error](acquire, release, use), func(result Either[error, A]) Cont[Resumed, A] {
+		if err, ok := result.GetLeft(); ok {
+			return ThrowErr[A](err)
+		}
+		a, _ := result.GetRight()
+		return Pure(a)
+	})
+}
@@ -0,0 +1,77 @@
+// ©Hayabusa Cloud Co., Ltd. 2026. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package kont_test
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	"code.hybscloud.com/kont"
+)
+
+func TestCheckCancelUnwindsBrackets(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	var log []string
+	region := func(name string, use func(string) kont.Eff[int]) kont.Eff[int] {
+		return kont.BracketErr(
+			kont.Pure(name),
+			func(r string) kont.Eff[struct{}] {
+				log = append(log, "release "+r)
+				return kont.Pure(struct{}{})
+			},
+			use,
+		)
+	}
+	comp := region("outer", func(string) kont.Eff[int] {
+		return region("inner", func(string) kont.Eff[int] {
+			cancel()
+			return kont.Then(kont.CheckCancel(ctx), kont.Pure(1))
+		})
+	})
+
+	_, err := kont.RunErr(comp)
+	if !errors.Is(err, kont.ErrCancelled) || !errors.Is(err, context.Canceled) {
+		t.Fatalf("got %v, want ErrCancelled wrapping context.Canceled", err)
+	}
+	if want := []string{"release inner", "release outer"}; !slices.Equal(log, want) {
+		t.Fatalf("got %v, want %v", log, want)
+	}
+}
+
+func TestCheckCancelNotDone(t *testing.T) {
+	comp := kont.Then(kont.CheckCancel(context.Background()), kont.Pure(7))
+	if v, err := kont.RunErr(comp); err != nil || v != 7 {
+		t.Fatalf("got %d, %v", v, err)
+	}
+}
+
+func TestExprCheckCancelCause(t *testing.T) {
+	cause := errors.New("shutdown")
+	ctx, cancel := context.WithCancelCause(context.Background())
+	cancel(cause)
+	_, err := kont.RunErrExpr(kont.ExprThen(kont.ExprCheckCancel(ctx), kont.ExprReturn(1)))
+	var ce *kont.CancelledError
+	if !errors.As(err, &ce) || ce.Cause != cause || err.Error() != "kont: cancelled: shutdown" {
+		t.Fatalf("got %v", err)
+	}
+}
+
+func TestCheckCancelChecksWhenReached(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	comp := kont.Then(kont.CheckCancel(ctx), kont.Pure(1))
+	expr := kont.ExprThen(kont.ExprCheckCancel(ctx), kont.ExprReturn(1))
+	if v, err := kont.RunErr(comp); err != nil || v != 1 {
+		t.Fatalf("before cancel: got %d, %v", v, err)
+	}
+	cancel()
+	if _, err := kont.RunErr(comp); !errors.Is(err, kont.ErrCancelled) {
+		t.Fatalf("Cont: got %v, want ErrCancelled", err)
+	}
+	if _, err := kont.RunErrExpr(expr); !errors.Is(err, kont.ErrCancelled) {
+		t.Fatalf("Expr: got %v, want ErrCancelled", err)
+	}
+}
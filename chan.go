@@ -0,0 +1,181 @@
+// ©Hayabusa Cloud Co., Ltd. 2026. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package kont
+
+import (
+	"context"
+	"reflect"
+	"slices"
+)
+
+// Channel effect operations.
+// A computation performs ChanRecv and ChanSend as straight-line code; a
+// ChanLoop parks it on the channel and selects across every parked
+// computation, resuming each one when its channel is ready.
+
+// ChanRecv is the effect operation for receiving from Ch.
+// Resumes with Some(v), or None once Ch is closed and drained.
+type ChanRecv[T any] struct{ Ch <-chan T }
+
+func (ChanRecv[T]) OpResult() Option[T] { panic("phantom") }
+
+func (o ChanRecv[T]) selectCase() reflect.SelectCase {
+	return reflect.SelectCase{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(o.Ch)}
+}
+
+func (ChanRecv[T]) selected(v reflect.Value, ok bool) Resumed {
+	if !ok {
+		return None[T]()
+	}
+	return Some(v.Interface().(T))
+}
+
+// ChanSend is the effect operation for sending Value on Ch.
+// Sending on a closed channel panics, as with a plain send.
+type ChanSend[T any] struct {
+	Ch    chan<- T
+	Value T
+}
+
+func (ChanSend[T]) OpResult() struct{} { panic("phantom") }
+
+func (o ChanSend[T]) selectCase() reflect.SelectCase {
+	return reflect.SelectCase{Dir: reflect.SelectSend, Chan: reflect.ValueOf(o.Ch), Send: reflect.ValueOf(&o.Value).Elem()}
+}
+
+func (ChanSend[T]) selected(reflect.Value, bool) Resumed { return struct{}{} }
+
+// chanOp is implemented by ChanRecv and ChanSend.
+type chanOp interface {
+	selectCase() reflect.SelectCase
+	selected(v reflect.Value, ok bool) Resumed
+}
+
+// RecvChan performs ChanRecv.
+func RecvChan[T any](ch <-chan T) Cont[Resumed, Option[T]] {
+	return Perform(ChanRecv[T]{Ch: ch})
+}
+
+// SendChan performs ChanSend.
+func SendChan[T any](ch chan<- T, v T) Cont[Resumed, struct{}] {
+	return Perform(ChanSend[T]{Ch: ch, Value: v})
+}
+
+// ExprRecvChan is the Expr counterpart of [RecvChan].
+func ExprRecvChan[T any](ch <-chan T) Expr[Option[T]] {
+	return ExprPerform(ChanRecv[T]{Ch: ch})
+}
+
+// ExprSendChan is the Expr counterpart of [SendChan].
+func ExprSendChan[T any](ch chan<- T, v T) Expr[struct{}] {
+	return ExprPerform(ChanSend[T]{Ch: ch, Value: v})
+}
+
+// ChanLoop drives computations that block on channels.
+// A ChanLoop is not safe for concurrent use; the channels it selects on may
+// be used by any goroutine.
+type ChanLoop struct {
+	dispatch func(Operation) (Resumed, bool)
+	pending  []func() (struct{}, *Suspension[struct{}])
+	parked   []*Suspension[struct{}]
+	cases    []reflect.SelectCase
+}
+
+// NewChanLoop creates a loop. dispatch handles operations other than
+// ChanRecv and ChanSend; it may be nil if computations perform no others.
+// A computation whose operation dispatch short-circuits is stopped.
+func NewChanLoop(dispatch func(Operation) (Resumed, bool)) *ChanLoop {
+	return &ChanLoop{dispatch: dispatch}
+}
+
+// Spawn adds m to the loop. It starts on the next Poll or Run.
+func (l *ChanLoop) Spawn(m Cont[Resumed, struct{}]) {
+	l.pending = append(l.pending, func() (struct{}, *Suspension[struct{}]) { return Step(m) })
+}
+
+// SpawnExpr adds an Expr computation to the loop. It starts on the next
+// Poll or Run.
+func (l *ChanLoop) SpawnExpr(m Expr[struct{}]) {
+	l.pending = append(l.pending, func() (struct{}, *Suspension[struct{}]) { return StepExpr(m) })
+}
+
+// Live returns the number of computations that have not completed.
+func (l *ChanLoop) Live() int {
+	return len(l.parked) + len(l.pending)
+}
+
+// Poll starts newly spawned computations, then resumes parked ones whose
+// channels are ready without blocking, until none is.
+// Returns the number of computations still live.
+func (l *ChanLoop) Poll() int {
+	l.start()
+	for len(l.parked) > 0 {
+		if !l.selectOne(reflect.SelectCase{Dir: reflect.SelectDefault}) {
+			break
+		}
+	}
+	return l.Live()
+}
+
+// Run resumes computations as their channels become ready until every
+// computation has completed or ctx is done, returning ctx.Err() in the
+// latter case.
+func (l *ChanLoop) Run(ctx context.Context) error {
+	done := reflect.SelectCase{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(ctx.Done())}
+	for l.start(); len(l.parked) > 0; l.start() {
+		if !l.selectOne(done) {
+			return ctx.Err()
+		}
+	}
+	return nil
+}
+
+func (l *ChanLoop) start() {
+	pending := l.pending
+	l.pending = nil
+	for _, start := range pending {
+		_, susp := start()
+		l.run(susp)
+	}
+}
+
+// selectOne selects across the parked computations and extra, resuming the
+// chosen computation. Reports false if extra was chosen.
+func (l *ChanLoop) selectOne(extra reflect.SelectCase) bool {
+	for _, susp := range l.parked {
+		l.cases = append(l.cases, susp.Op().(chanOp).selectCase())
+	}
+	l.cases = append(l.cases, extra)
+	chosen, v, ok := reflect.Select(l.cases)
+	clear(l.cases)
+	l.cases = l.cases[:0]
+	if chosen == len(l.parked) {
+		return false
+	}
+	susp := l.parked[chosen]
+	l.parked = slices.Delete(l.parked, chosen, chosen+1)
+	_, susp = susp.Resume(susp.Op().(chanOp).selected(v, ok))
+	l.run(susp)
+	return true
+}
+
+// run advances susp until it parks on a channel or completes.
+func (l *ChanLoop) run(susp *Suspension[struct{}]) {
+	for susp != nil {
+		if _, ok := susp.Op().(chanOp); ok {
+			l.parked = append(l.parked, susp)
+			return
+		}
+		if l.dispatch == nil {
+			unhandledEffect("ChanLoop", susp.Op())
+		}
+		v, shouldResume := l.dispatch(susp.Op())
+		if !shouldResume {
+			susp.Discard()
+			return
+		}
+		_, susp = susp.Resume(v)
+	}
+}
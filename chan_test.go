@@ -0,0 +1,104 @@
+// ©Hayabusa Cloud Co., Ltd. 2026. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package kont_test
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"code.hybscloud.com/kont"
+)
+
+// relay forwards every value from in to out, doubled, and closes out once
+// in is closed.
+func relay(in <-chan int, out chan<- int) kont.Eff[struct{}] {
+	return kont.Bind(kont.RecvChan(in), func(o kont.Option[int]) kont.Eff[struct{}] {
+		v, ok := o.Get()
+		if !ok {
+			close(out)
+			return kont.Pure(struct{}{})
+		}
+		return kont.Then(kont.SendChan(out, v*2), relay(in, out))
+	})
+}
+
+func TestChanLoopRun(t *testing.T) {
+	in := make(chan int)
+	out := make(chan int, 8)
+	loop := kont.NewChanLoop(nil)
+	loop.Spawn(relay(in, out))
+	go func() {
+		for i := 1; i <= 3; i++ {
+			in <- i
+		}
+		close(in)
+	}()
+	if err := loop.Run(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	var got []int
+	for v := range out {
+		got = append(got, v)
+	}
+	if want := []int{2, 4, 6}; !slices.Equal(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+}
+
+func TestChanLoopPoll(t *testing.T) {
+	in := make(chan int, 1)
+	out := make(chan int)
+	loop := kont.NewChanLoop(nil)
+	loop.SpawnExpr(kont.Reify(relay(in, out)))
+	if live := loop.Poll(); live != 1 {
+		t.Fatalf("live %d", live)
+	}
+	in <- 5
+	// The relay receives 5, then parks on the unbuffered send.
+	if live := loop.Poll(); live != 1 {
+		t.Fatalf("live %d", live)
+	}
+	done := make(chan int)
+	go func() { done <- <-out }()
+	if got := waitPoll(loop, done); got != 10 {
+		t.Fatalf("got %d", got)
+	}
+	close(in)
+	if live := loop.Poll(); live != 0 {
+		t.Fatalf("live %d after close", live)
+	}
+}
+
+// waitPoll polls loop until a value arrives on done.
+func waitPoll(loop *kont.ChanLoop, done <-chan int) int {
+	for {
+		loop.Poll()
+		select {
+		case v := <-done:
+			return v
+		case <-time.After(time.Millisecond):
+		}
+	}
+}
+
+func TestChanLoopDispatchAndCancel(t *testing.T) {
+	ch := make(chan int)
+	var asked int
+	loop := kont.NewChanLoop(func(op kont.Operation) (kont.Resumed, bool) { return 3, true })
+	loop.Spawn(kont.Bind(kont.Perform(kont.Ask[int]{}), func(n int) kont.Eff[struct{}] {
+		asked = n
+		return kont.Map(kont.RecvChan(ch), func(kont.Option[int]) struct{} { return struct{}{} })
+	}))
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := loop.Run(ctx); err != context.DeadlineExceeded {
+		t.Fatalf("got %v", err)
+	}
+	if asked != 3 || loop.Live() != 1 {
+		t.Fatalf("asked %d, live %d", asked, loop.Live())
+	}
+}
@@ -0,0 +1,270 @@
+// ©Hayabusa Cloud Co., Ltd. 2026. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package kont
+
+import (
+	"slices"
+	"sync"
+	"time"
+)
+
+// VirtualClock is a manually advanced clock for deterministic tests.
+// It is safe for concurrent use. Its Now method plugs into any option that
+// takes a func() time.Time.
+type VirtualClock struct {
+	mu     sync.Mutex
+	now    time.Time
+	timers []virtualTimer
+}
+
+// virtualTimer is a pending After or ticker channel and the time it next
+// fires. A ticker fires again every period.
+type virtualTimer struct {
+	at     time.Time
+	ch     chan time.Time
+	period time.Duration
+}
+
+// NewVirtualClock creates a VirtualClock reading start.
+func NewVirtualClock(start time.Time) *VirtualClock {
+	return &VirtualClock{now: start}
+}
+
+// Now returns the current virtual time.
+func (c *VirtualClock) Now() time.Time {
+	c.mu.Lock()
+	now := c.now
+	c.mu.Unlock()
+	return now
+}
+
+// Advance moves the virtual time forward by d, firing every After channel
+// and ticker that falls due.
+func (c *VirtualClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	c.now = c.now.Add(d)
+	pending := c.timers[:0]
+	for _, t := range c.timers {
+		if t.at.After(c.now) {
+			pending = append(pending, t)
+			continue
+		}
+		if t.period == 0 {
+			t.ch <- c.now
+			continue
+		}
+		select {
+		case t.ch <- c.now:
+		default: // drop the tick, like time.Ticker
+		}
+		for !t.at.After(c.now) {
+			t.at = t.at.Add(t.period)
+		}
+		pending = append(pending, t)
+	}
+	clear(c.timers[len(pending):])
+	c.timers = pending
+	c.mu.Unlock()
+}
+
+// After returns a channel that receives the virtual time once the clock has
+// been advanced by at least d. A non-positive d fires immediately.
+func (c *VirtualClock) After(d time.Duration) <-chan time.Time {
+	ch := make(chan time.Time, 1)
+	c.mu.Lock()
+	if d <= 0 {
+		ch <- c.now
+	} else {
+		c.timers = append(c.timers, virtualTimer{at: c.now.Add(d), ch: ch})
+	}
+	c.mu.Unlock()
+	return ch
+}
+
+// NewTicker returns a Ticker that fires every d of virtual time as the
+// clock is advanced. An Advance spanning several periods delivers one tick.
+// Panics if d is not positive.
+func (c *VirtualClock) NewTicker(d time.Duration) *Ticker {
+	if d <= 0 {
+		panic("kont: NewTicker requires a positive interval")
+	}
+	ch := make(chan time.Time, 1)
+	c.mu.Lock()
+	c.timers = append(c.timers, virtualTimer{at: c.now.Add(d), ch: ch, period: d})
+	c.mu.Unlock()
+	return &Ticker{C: ch, stop: func() { c.stopTicker(ch) }}
+}
+
+func (c *VirtualClock) stopTicker(ch chan time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for i, t := range c.timers {
+		if t.ch == ch {
+			c.timers = slices.Delete(c.timers, i, i+1)
+			return
+		}
+	}
+}
+
+// Ticker delivers ticks at a fixed interval, like time.Ticker.
+type Ticker struct {
+	// C receives the time of each tick. A tick is dropped while the
+	// previous one is unread.
+	C    <-chan time.Time
+	stop func()
+}
+
+// Stop turns off the ticker. No tick is sent after Stop returns; C is not
+// closed.
+func (t *Ticker) Stop() { t.stop() }
+
+// Clock effect operations.
+// Programs read the time and wait through effects, so timeout logic runs
+// against the real clock in production and a VirtualClock in tests.
+
+// Now is the effect operation for reading the current time.
+type Now struct{}
+
+func (Now) OpResult() time.Time { panic("phantom") }
+
+// Sleep is the effect operation for pausing for Duration.
+type Sleep struct{ Duration time.Duration }
+
+func (Sleep) OpResult() struct{} { panic("phantom") }
+
+// After is the effect operation for a timer. Resumes with a channel that
+// receives the time once Duration has elapsed.
+type After struct{ Duration time.Duration }
+
+func (After) OpResult() <-chan time.Time { panic("phantom") }
+
+// Every is the effect operation for a periodic timer. Resumes with a
+// Ticker that fires every Interval; a task receives its ticks on C, for
+// example with RecvChan under a ChanLoop, and stops it when done.
+type Every struct{ Interval time.Duration }
+
+func (Every) OpResult() *Ticker { panic("phantom") }
+
+// ClockNow performs Now.
+func ClockNow() Cont[Resumed, time.Time] {
+	return Perform(Now{})
+}
+
+// ClockSleep performs Sleep.
+func ClockSleep(d time.Duration) Cont[Resumed, struct{}] {
+	return Perform(Sleep{Duration: d})
+}
+
+// ClockAfter performs After.
+func ClockAfter(d time.Duration) Cont[Resumed, <-chan time.Time] {
+	return Perform(After{Duration: d})
+}
+
+// ClockEvery performs Every.
+func ClockEvery(d time.Duration) Cont[Resumed, *Ticker] {
+	return Perform(Every{Interval: d})
+}
+
+// ExprClockNow is the Expr counterpart of [ClockNow].
+func ExprClockNow() Expr[time.Time] {
+	return ExprPerform(Now{})
+}
+
+// ExprClockSleep is the Expr counterpart of [ClockSleep].
+func ExprClockSleep(d time.Duration) Expr[struct{}] {
+	return ExprPerform(Sleep{Duration: d})
+}
+
+// ExprClockAfter is the Expr counterpart of [ClockAfter].
+func ExprClockAfter(d time.Duration) Expr[<-chan time.Time] {
+	return ExprPerform(After{Duration: d})
+}
+
+// ExprClockEvery is the Expr counterpart of [ClockEvery].
+func ExprClockEvery(d time.Duration) Expr[*Ticker] {
+	return ExprPerform(Every{Interval: d})
+}
+
+// clockHandler implements Handler for the Clock effect in real time.
+type clockHandler[R any] struct{}
+
+// Dispatch implements Handler for the Clock effect.
+func (*clockHandler[R]) Dispatch(op Operation) (Resumed, bool) {
+	switch o := op.(type) {
+	case Now:
+		return time.Now(), true
+	case Sleep:
+		time.Sleep(o.Duration)
+		return struct{}{}, true
+	case After:
+		return time.After(o.Duration), true
+	case Every:
+		t := time.NewTicker(o.Interval)
+		return &Ticker{C: t.C, stop: t.Stop}, true
+	}
+	unhandledEffect("ClockHandler", op)
+	return nil, false
+}
+
+// ClockHandler creates a Clock handler backed by the time package.
+// Returns a concrete handler.
+func ClockHandler[R any]() *clockHandler[R] {
+	return &clockHandler[R]{}
+}
+
+// virtualClockHandler implements Handler for the Clock effect on a
+// VirtualClock.
+type virtualClockHandler[R any] struct {
+	clock *VirtualClock
+}
+
+// Dispatch implements Handler for the Clock effect. Sleep advances the
+// clock instead of blocking.
+func (h *virtualClockHandler[R]) Dispatch(op Operation) (Resumed, bool) {
+	switch o := op.(type) {
+	case Now:
+		return h.clock.Now(), true
+	case Sleep:
+		h.clock.Advance(o.Duration)
+		return struct{}{}, true
+	case After:
+		return h.clock.After(o.Duration), true
+	case Every:
+		return h.clock.NewTicker(o.Interval), true
+	}
+	unhandledEffect("VirtualClockHandler", op)
+	return nil, false
+}
+
+// Advance moves the handler's clock forward by d, firing due timers.
+func (h *virtualClockHandler[R]) Advance(d time.Duration) { h.clock.Advance(d) }
+
+// VirtualClockHandler creates a Clock handler reading clock. Sleep advances
+// clock by its duration, and After channels and Every tickers fire as clock
+// is advanced, either by Sleep or by the handler's Advance method.
+// Returns a concrete handler.
+func VirtualClockHandler[R any](clock *VirtualClock) *virtualClockHandler[R] {
+	return &virtualClockHandler[R]{clock: clock}
+}
+
+// RunClock runs a computation with the Clock effect in real time.
+func RunClock[A any](m Cont[Resumed, A]) A {
+	return Handle(m, ClockHandler[A]())
+}
+
+// RunClockExpr runs an Expr computation with the Clock effect in real time.
+func RunClockExpr[A any](m Expr[A]) A {
+	return HandleExpr(m, ClockHandler[A]())
+}
+
+// RunVirtualClock runs a computation with the Clock effect on clock.
+func RunVirtualClock[A any](clock *VirtualClock, m Cont[Resumed, A]) A {
+	return Handle(m, VirtualClockHandler[A](clock))
+}
+
+// RunVirtualClockExpr runs an Expr computation with the Clock effect on clock.
+func RunVirtualClockExpr[A any](clock *VirtualClock, m Expr[A]) A {
+	return HandleExpr(m, VirtualClockHandler[A](clock))
+}
@@ -0,0 +1,172 @@
+// ©Hayabusa Cloud Co., Ltd. 2026. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package kont_test
+
+import (
+	"testing"
+	"time"
+
+	"code.hybscloud.com/kont"
+)
+
+func TestVirtualClockAdvance(t *testing.T) {
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	clock := kont.NewVirtualClock(start)
+	if !clock.Now().Equal(start) {
+		t.Fatalf("got %v, want %v", clock.Now(), start)
+	}
+	clock.Advance(90 * time.Second)
+	if got := clock.Now().Sub(start); got != 90*time.Second {
+		t.Fatalf("advanced %v, want 90s", got)
+	}
+}
+
+func TestVirtualClockAfter(t *testing.T) {
+	clock := kont.NewVirtualClock(time.Unix(0, 0))
+	late := clock.After(2 * time.Second)
+	soon := clock.After(time.Second)
+	clock.Advance(time.Second)
+	select {
+	case at := <-soon:
+		if at.Unix() != 1 {
+			t.Fatalf("fired at %v", at)
+		}
+	default:
+		t.Fatal("timer due at 1s did not fire")
+	}
+	select {
+	case <-late:
+		t.Fatal("timer due at 2s fired early")
+	default:
+	}
+	clock.Advance(time.Second)
+	if at := <-late; at.Unix() != 2 {
+		t.Fatalf("fired at %v", at)
+	}
+	if at := <-clock.After(0); at.Unix() != 2 {
+		t.Fatalf("immediate timer fired at %v", at)
+	}
+}
+
+// withDeadline reports whether work finishes before the timer fires,
+// checking between steps of the given duration.
+func withDeadline(limit, step time.Duration, steps int) kont.Eff[bool] {
+	return kont.Bind(kont.ClockAfter(limit), func(timer <-chan time.Time) kont.Eff[bool] {
+		var loop func(n int) kont.Eff[bool]
+		loop = func(n int) kont.Eff[bool] {
+			select {
+			case <-timer:
+				return kont.Pure(false)
+			default:
+			}
+			if n == 0 {
+				return kont.Pure(true)
+			}
+			return kont.Bind(kont.ClockSleep(step), func(struct{}) kont.Eff[bool] { return loop(n - 1) })
+		}
+		return loop(steps)
+	})
+}
+
+func TestRunVirtualClock(t *testing.T) {
+	clock := kont.NewVirtualClock(time.Unix(0, 0))
+	if !kont.RunVirtualClock(clock, withDeadline(time.Minute, time.Second, 10)) {
+		t.Fatal("10s of work should beat a 1m deadline")
+	}
+	if kont.RunVirtualClock(clock, withDeadline(5*time.Second, time.Second, 10)) {
+		t.Fatal("10s of work should miss a 5s deadline")
+	}
+	elapsed := kont.RunVirtualClockExpr(clock, kont.ExprBind(kont.ExprClockNow(), func(start time.Time) kont.Expr[time.Duration] {
+		return kont.ExprThen(kont.ExprClockSleep(time.Hour), kont.ExprMap(kont.ExprClockNow(), func(end time.Time) time.Duration {
+			return end.Sub(start)
+		}))
+	}))
+	if elapsed != time.Hour {
+		t.Fatalf("elapsed %v", elapsed)
+	}
+}
+
+func TestVirtualClockHandlerAdvance(t *testing.T) {
+	h := kont.VirtualClockHandler[<-chan time.Time](kont.NewVirtualClock(time.Unix(0, 0)))
+	timer := kont.Handle(kont.ClockAfter(time.Second), h)
+	h.Advance(time.Second)
+	select {
+	case <-timer:
+	default:
+		t.Fatal("timer did not fire after Advance")
+	}
+}
+
+func TestRunClock(t *testing.T) {
+	d := kont.RunClock(kont.Bind(kont.ClockNow(), func(start time.Time) kont.Eff[time.Duration] {
+		return kont.Then(kont.ClockSleep(time.Millisecond), kont.Map(kont.ClockNow(), func(end time.Time) time.Duration {
+			return end.Sub(start)
+		}))
+	}))
+	if d < time.Millisecond {
+		t.Fatalf("slept %v", d)
+	}
+	<-kont.RunClockExpr(kont.ExprClockAfter(time.Millisecond))
+}
+
+func TestEveryUnderChanLoop(t *testing.T) {
+	clock := kont.NewVirtualClock(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+	ticks := 0
+	var tick func(tk *kont.Ticker, n int) kont.Eff[struct{}]
+	tick = func(tk *kont.Ticker, n int) kont.Eff[struct{}] {
+		if n == 0 {
+			tk.Stop()
+			return kont.Pure(struct{}{})
+		}
+		return kont.Bind(kont.RecvChan(tk.C), func(kont.Option[time.Time]) kont.Eff[struct{}] {
+			ticks++
+			return tick(tk, n-1)
+		})
+	}
+	l := kont.NewChanLoop(kont.VirtualClockHandler[struct{}](clock).Dispatch)
+	l.Spawn(kont.Bind(kont.ClockEvery(10*time.Second), func(tk *kont.Ticker) kont.Eff[struct{}] {
+		return tick(tk, 3)
+	}))
+	if l.Poll() != 1 || ticks != 0 {
+		t.Fatalf("ticked %d before the first period", ticks)
+	}
+	clock.Advance(9 * time.Second)
+	l.Poll()
+	clock.Advance(time.Second)
+	l.Poll()
+	if ticks != 1 {
+		t.Fatalf("ticks %d after one period, want 1", ticks)
+	}
+	clock.Advance(25 * time.Second) // spans two periods but delivers one tick
+	l.Poll()
+	clock.Advance(5 * time.Second)
+	if l.Poll() != 0 || ticks != 3 {
+		t.Fatalf("ticks %d, live %d", ticks, l.Live())
+	}
+}
+
+func TestVirtualClockTickerStop(t *testing.T) {
+	clock := kont.NewVirtualClock(time.Unix(0, 0))
+	tk := kont.RunVirtualClock(clock, kont.ClockEvery(time.Second))
+	tk.Stop()
+	clock.Advance(time.Minute)
+	select {
+	case <-tk.C:
+		t.Fatal("tick after Stop")
+	default:
+	}
+	defer func() {
+		if r := recover(); r != "kont: NewTicker requires a positive interval" {
+			t.Fatalf("got panic %v", r)
+		}
+	}()
+	clock.NewTicker(0)
+}
+
+func TestClockEvery(t *testing.T) {
+	tk := kont.RunClockExpr(kont.ExprClockEvery(time.Millisecond))
+	defer tk.Stop()
+	<-tk.C
+}
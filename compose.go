@@ -7,11 +7,13 @@ package kont
 // Composed effect handlers for multi-effect computations.
 // These avoid nesting Run* calls by dispatching multiple effect families
 // from a single handler via handleDispatch/HandleExpr.
+// Every composed handler also answers Fresh from a supply numbering from zero.
 
 // stateReaderHandler handles both State and Reader effects.
 type stateReaderHandler[S, E, R any] struct {
 	state *S
 	env   *E
+	fresh FreshSupply
 }
 
 // Dispatch implements Handler for the composed State+Reader handler.
@@ -26,7 +28,12 @@ func (h *stateReaderHandler[S, E, R]) Dispatch(op Operation) (Resumed, bool) {
 	}); ok {
 		return rop.DispatchReader(h.env)
 	}
-	unhandledEffect("StateReaderHandler")
+	if fop, ok := op.(interface {
+		DispatchFresh(s *FreshSupply) (Resumed, bool)
+	}); ok {
+		return fop.DispatchFresh(&h.fresh)
+	}
+	unhandledEffect("StateReaderHandler", op)
 	return nil, false
 }
 
@@ -53,17 +60,28 @@ func RunStateReader[S, E, A any](initial S, env E, m Cont[Resumed, A]) (A, S) {
 type stateErrorHandler[S, E, A any] struct {
 	state *S
 	ctx   *ErrorContext[E]
+	fresh FreshSupply
 }
 
 // Dispatch implements Handler for the composed State+Error handler.
 // Dispatch order: State → Error.
-// Catch runs body with error-only handler internally (like Listen/Censor).
+// Catch runs body with error-only handler internally (like Listen/Censor);
+// CatchAll forwards non-error effects in its body back to this handler.
 func (h *stateErrorHandler[S, E, A]) Dispatch(op Operation) (Resumed, bool) {
 	if sop, ok := op.(interface {
 		DispatchState(state *S) (Resumed, bool)
 	}); ok {
 		return sop.DispatchState(h.state)
 	}
+	if dop, ok := op.(interface {
+		DispatchErrorDeep(ctx *ErrorContext[E], outer func(Operation) (Resumed, bool)) (Resumed, bool)
+	}); ok {
+		v, _ := dop.DispatchErrorDeep(h.ctx, h.Dispatch)
+		if h.ctx.HasErr {
+			return Left[E, A](h.ctx.Err), false
+		}
+		return v, true
+	}
 	if eop, ok := op.(interface {
 		DispatchError(ctx *ErrorContext[E]) (Resumed, bool)
 	}); ok {
@@ -73,7 +91,12 @@ func (h *stateErrorHandler[S, E, A]) Dispatch(op Operation) (Resumed, bool) {
 		}
 		return v, true
 	}
-	unhandledEffect("StateErrorHandler")
+	if fop, ok := op.(interface {
+		DispatchFresh(s *FreshSupply) (Resumed, bool)
+	}); ok {
+		return fop.DispatchFresh(&h.fresh)
+	}
+	unhandledEffect("StateErrorHandler", op)
 	return nil, false
 }
 
@@ -119,6 +142,7 @@ func RunStateErrorExpr[S, E, A any](initial S, m Expr[A]) (Either[E, A], S) {
 type stateWriterHandler[S, W, R any] struct {
 	state *S
 	ctx   *WriterContext[W]
+	fresh FreshSupply
 }
 
 // Dispatch implements Handler for the composed State+Writer handler.
@@ -134,7 +158,12 @@ func (h *stateWriterHandler[S, W, R]) Dispatch(op Operation) (Resumed, bool) {
 	}); ok {
 		return wop.DispatchWriter(h.ctx)
 	}
-	unhandledEffect("StateWriterHandler")
+	if fop, ok := op.(interface {
+		DispatchFresh(s *FreshSupply) (Resumed, bool)
+	}); ok {
+		return fop.DispatchFresh(&h.fresh)
+	}
+	unhandledEffect("StateWriterHandler", op)
 	return nil, false
 }
 
@@ -164,11 +193,13 @@ type readerStateErrorHandler[Env, S, Err, A any] struct {
 	env   *Env
 	state *S
 	ctx   *ErrorContext[Err]
+	fresh FreshSupply
 }
 
 // Dispatch implements Handler for the composed Reader+State+Error handler.
 // Dispatch order: Reader → State → Error.
-// Catch runs body with error-only handler internally (like Listen/Censor).
+// Catch runs body with error-only handler internally (like Listen/Censor);
+// CatchAll forwards non-error effects in its body back to this handler.
 func (h *readerStateErrorHandler[Env, S, Err, A]) Dispatch(op Operation) (Resumed, bool) {
 	if rop, ok := op.(interface {
 		DispatchReader(env *Env) (Resumed, bool)
@@ -180,6 +211,15 @@ func (h *readerStateErrorHandler[Env, S, Err, A]) Dispatch(op Operation) (Resume
 	}); ok {
 		return sop.DispatchState(h.state)
 	}
+	if dop, ok := op.(interface {
+		DispatchErrorDeep(ctx *ErrorContext[Err], outer func(Operation) (Resumed, bool)) (Resumed, bool)
+	}); ok {
+		v, _ := dop.DispatchErrorDeep(h.ctx, h.Dispatch)
+		if h.ctx.HasErr {
+			return Left[Err, A](h.ctx.Err), false
+		}
+		return v, true
+	}
 	if eop, ok := op.(interface {
 		DispatchError(ctx *ErrorContext[Err]) (Resumed, bool)
 	}); ok {
@@ -189,7 +229,12 @@ func (h *readerStateErrorHandler[Env, S, Err, A]) Dispatch(op Operation) (Resume
 		}
 		return v, true
 	}
-	unhandledEffect("ReaderStateErrorHandler")
+	if fop, ok := op.(interface {
+		DispatchFresh(s *FreshSupply) (Resumed, bool)
+	}); ok {
+		return fop.DispatchFresh(&h.fresh)
+	}
+	unhandledEffect("ReaderStateErrorHandler", op)
 	return nil, false
 }
 
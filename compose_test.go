@@ -129,7 +129,7 @@ func TestRunStateReaderUnhandledEffectPanics(t *testing.T) {
 		if r == nil {
 			t.Fatal("expected panic")
 		}
-		if r != "kont: unhandled effect in StateReaderHandler" {
+		if unhandledIn(r) != "StateReaderHandler" {
 			t.Fatalf("unexpected panic: %v", r)
 		}
 	}()
@@ -143,7 +143,7 @@ func TestRunStateWriterUnhandledEffectPanics(t *testing.T) {
 		if r == nil {
 			t.Fatal("expected panic")
 		}
-		if r != "kont: unhandled effect in StateWriterHandler" {
+		if unhandledIn(r) != "StateWriterHandler" {
 			t.Fatalf("unexpected panic: %v", r)
 		}
 	}()
@@ -157,7 +157,7 @@ func TestRunStateErrorUnhandledEffectPanics(t *testing.T) {
 		if r == nil {
 			t.Fatal("expected panic")
 		}
-		if r != "kont: unhandled effect in StateErrorHandler" {
+		if unhandledIn(r) != "StateErrorHandler" {
 			t.Fatalf("unexpected panic: %v", r)
 		}
 	}()
@@ -171,7 +171,7 @@ func TestRunReaderStateErrorUnhandledEffectPanics(t *testing.T) {
 		if r == nil {
 			t.Fatal("expected panic")
 		}
-		if r != "kont: unhandled effect in ReaderStateErrorHandler" {
+		if unhandledIn(r) != "ReaderStateErrorHandler" {
 			t.Fatalf("unexpected panic: %v", r)
 		}
 	}()
@@ -590,3 +590,86 @@ func BenchmarkRunStateWriterExpr(b *testing.B) {
 		_, _, _ = kont.RunStateWriterExpr[int, string, int](0, comp)
 	}
 }
+
+func TestCatchAllStateInsideBody(t *testing.T) {
+	comp := kont.CatchAllError[string, int](
+		kont.GetState(func(s int) kont.Eff[int] {
+			return kont.PutState(s+1, kont.ThrowError[string, int]("boom"))
+		}),
+		func(e string) kont.Eff[int] {
+			return kont.GetState(func(s int) kont.Eff[int] { return kont.Pure(s * 100) })
+		},
+	)
+	result, state := kont.RunStateError[int, string, int](1, comp)
+	if v, ok := result.GetRight(); !ok || v != 200 {
+		t.Fatalf("got %+v, want Right(200)", result)
+	}
+	if state != 2 {
+		t.Fatalf("got state %d, want 2 (body changes kept)", state)
+	}
+}
+
+func TestCatchAllRethrow(t *testing.T) {
+	comp := kont.CatchAllError[string, int](
+		kont.ThrowError[string, int]("first"),
+		func(e string) kont.Eff[int] {
+			return kont.PutState(7, kont.ThrowError[string, int](e+"+second"))
+		},
+	)
+	result, state := kont.RunStateError[int, string, int](0, comp)
+	if e, ok := result.GetLeft(); !ok || e != "first+second" || state != 7 {
+		t.Fatalf("got (%+v, %d), want (Left(first+second), 7)", result, state)
+	}
+}
+
+func TestCatchAllReaderStateError(t *testing.T) {
+	comp := kont.CatchAllError[string, string](
+		kont.AskReader(func(env string) kont.Eff[string] {
+			return kont.PutState(len(env), kont.Pure(env))
+		}),
+		func(string) kont.Eff[string] { return kont.Pure("unreachable") },
+	)
+	result, state := kont.RunReaderStateError[string, int, string, string]("cfg", 0, comp)
+	if v, ok := result.GetRight(); !ok || v != "cfg" || state != 3 {
+		t.Fatalf("got (%+v, %d), want (Right(cfg), 3)", result, state)
+	}
+}
+
+func TestCatchAllNested(t *testing.T) {
+	inner := kont.CatchAllError[string, int](
+		kont.PutState(1, kont.ThrowError[string, int]("inner")),
+		func(string) kont.Eff[int] { return kont.ThrowError[string, int]("escalated") },
+	)
+	comp := kont.CatchAllError[string, int](inner, func(e string) kont.Eff[int] {
+		return kont.GetState(func(s int) kont.Eff[int] { return kont.Pure(s + len(e)) })
+	})
+	result, _ := kont.RunStateError[int, string, int](0, comp)
+	if v, ok := result.GetRight(); !ok || v != 1+len("escalated") {
+		t.Fatalf("got %+v", result)
+	}
+}
+
+func TestCatchAllExpr(t *testing.T) {
+	comp := kont.ExprPerform(kont.CatchAll[string, int]{
+		Body: kont.PutState(5, kont.ThrowError[string, int]("x")),
+		Handler: func(string) kont.Eff[int] {
+			return kont.GetState(func(s int) kont.Eff[int] { return kont.Pure(s) })
+		},
+	})
+	result, _ := kont.RunStateErrorExpr[int, string, int](0, comp)
+	if v, ok := result.GetRight(); !ok || v != 5 {
+		t.Fatalf("got %+v, want Right(5)", result)
+	}
+}
+
+func TestCatchAllOutbox(t *testing.T) {
+	comp := kont.CatchAllError[string, int](
+		kont.EmitOutbox("created", kont.ThrowError[string, int]("fail")),
+		func(string) kont.Eff[int] { return kont.Pure(0) },
+	)
+	var committed []string
+	result, _ := kont.RunStateOutbox[int, string, string](0, comp, func(_ int, evs []string) { committed = evs })
+	if !result.IsRight() || len(committed) != 1 || committed[0] != "created" {
+		t.Fatalf("got (%+v, %v)", result, committed)
+	}
+}
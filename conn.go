@@ -0,0 +1,388 @@
+// ©Hayabusa Cloud Co., Ltd. 2026. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package kont
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"slices"
+	"time"
+)
+
+// Connection effect operations.
+// Programs open, use, and close message connections through effects, so
+// dialing, framing, and reconnecting live in the handler. A failure the
+// handler cannot absorb short-circuits the computation with Left(*ConnError).
+
+// ConnID identifies a connection opened by ConnOpen.
+type ConnID int
+
+// ConnOpen is the effect operation for opening a connection to Addr.
+type ConnOpen struct{ Addr string }
+
+func (ConnOpen) OpResult() ConnID { panic("phantom") }
+
+// ConnSend is the effect operation for sending one message.
+type ConnSend struct {
+	Conn ConnID
+	Data []byte
+}
+
+func (ConnSend) OpResult() struct{} { panic("phantom") }
+
+// ConnRecv is the effect operation for receiving one message.
+type ConnRecv struct{ Conn ConnID }
+
+func (ConnRecv) OpResult() []byte { panic("phantom") }
+
+// ConnClose is the effect operation for closing a connection.
+type ConnClose struct{ Conn ConnID }
+
+func (ConnClose) OpResult() struct{} { panic("phantom") }
+
+// ErrConnClosed is returned for operations on a connection that was closed
+// or never opened.
+var ErrConnClosed = errors.New("kont: connection closed")
+
+// ErrFrameTooLarge is returned for a received message whose length header
+// exceeds [ConnOptions.MaxFrame].
+var ErrFrameTooLarge = errors.New("kont: frame too large")
+
+// ConnError reports a connection operation that failed.
+// It matches [ErrHandlerFailure] under errors.Is, and Unwrap returns the
+// underlying failure.
+type ConnError struct {
+	Op   Operation
+	Addr string
+	Err  error
+}
+
+func (e *ConnError) Error() string {
+	return fmt.Sprintf("kont: %T %s: %v", e.Op, e.Addr, e.Err)
+}
+
+// Is reports whether target is ErrHandlerFailure.
+func (e *ConnError) Is(target error) bool { return target == ErrHandlerFailure }
+
+// Unwrap returns the underlying failure.
+func (e *ConnError) Unwrap() error { return e.Err }
+
+// OpenConn performs ConnOpen.
+func OpenConn(addr string) Cont[Resumed, ConnID] {
+	return Perform(ConnOpen{Addr: addr})
+}
+
+// SendConn performs ConnSend.
+func SendConn(c ConnID, data []byte) Cont[Resumed, struct{}] {
+	return Perform(ConnSend{Conn: c, Data: data})
+}
+
+// RecvConn performs ConnRecv.
+func RecvConn(c ConnID) Cont[Resumed, []byte] {
+	return Perform(ConnRecv{Conn: c})
+}
+
+// CloseConn performs ConnClose.
+func CloseConn(c ConnID) Cont[Resumed, struct{}] {
+	return Perform(ConnClose{Conn: c})
+}
+
+// ExprOpenConn is the Expr counterpart of [OpenConn].
+func ExprOpenConn(addr string) Expr[ConnID] {
+	return ExprPerform(ConnOpen{Addr: addr})
+}
+
+// ExprSendConn is the Expr counterpart of [SendConn].
+func ExprSendConn(c ConnID, data []byte) Expr[struct{}] {
+	return ExprPerform(ConnSend{Conn: c, Data: data})
+}
+
+// ExprRecvConn is the Expr counterpart of [RecvConn].
+func ExprRecvConn(c ConnID) Expr[[]byte] {
+	return ExprPerform(ConnRecv{Conn: c})
+}
+
+// ExprCloseConn is the Expr counterpart of [CloseConn].
+func ExprCloseConn(c ConnID) Expr[struct{}] {
+	return ExprPerform(ConnClose{Conn: c})
+}
+
+// ConnOptions configures the handler returned by [ConnHandler].
+type ConnOptions struct {
+	// Dial opens a transport connection. Nil dials TCP with net.Dial.
+	Dial func(addr string) (net.Conn, error)
+	// Reconnect bounds dial and I/O attempts per operation. After a
+	// transport failure the connection is redialed and the operation
+	// retried, waiting Reconnect.Backoff between attempts.
+	Reconnect RetryPolicy
+	// Sleep waits out a backoff delay. Nil means time.Sleep; tests can
+	// pass a function that advances a [VirtualClock] instead. Backoff
+	// happens inside Dispatch, so it cannot perform the Clock effect.
+	Sleep func(time.Duration)
+	// MaxFrame bounds the length of a received message; a longer length
+	// header fails the receive with [ErrFrameTooLarge] before anything is
+	// allocated. Zero means 16 MiB.
+	MaxFrame int
+}
+
+// defaultMaxFrame is the MaxFrame used when ConnOptions.MaxFrame is zero.
+const defaultMaxFrame = 16 << 20
+
+// netConn is one connection served by netConnHandler. c is nil after a
+// transport failure until the next redial.
+type netConn struct {
+	addr string
+	c    net.Conn
+	r    *bufio.Reader
+}
+
+// netConnHandler implements Handler for the connection effects over
+// net.Conn, framing each message with a 4-byte big-endian length.
+type netConnHandler[A any] struct {
+	opts  ConnOptions
+	conns map[ConnID]*netConn
+	next  ConnID
+}
+
+// Dispatch implements Handler for the connection effects.
+func (h *netConnHandler[A]) Dispatch(op Operation) (Resumed, bool) {
+	switch o := op.(type) {
+	case ConnOpen:
+		nc := &netConn{addr: o.Addr}
+		if err := h.attempt(func() error { return h.redial(nc) }); err != nil {
+			return h.fail(op, o.Addr, err)
+		}
+		h.next++
+		h.conns[h.next] = nc
+		return h.next, true
+	case ConnSend:
+		nc, ok := h.conns[o.Conn]
+		if !ok {
+			return h.fail(op, "", ErrConnClosed)
+		}
+		if err := h.attempt(func() error { return h.io(nc, func() error { return writeFrame(nc.c, o.Data) }) }); err != nil {
+			return h.fail(op, nc.addr, err)
+		}
+		return struct{}{}, true
+	case ConnRecv:
+		nc, ok := h.conns[o.Conn]
+		if !ok {
+			return h.fail(op, "", ErrConnClosed)
+		}
+		var msg []byte
+		err := h.attempt(func() error {
+			return h.io(nc, func() (err error) {
+				msg, err = readFrame(nc.r, h.opts.MaxFrame)
+				return err
+			})
+		})
+		if err != nil {
+			return h.fail(op, nc.addr, err)
+		}
+		return msg, true
+	case ConnClose:
+		nc, ok := h.conns[o.Conn]
+		if !ok {
+			return h.fail(op, "", ErrConnClosed)
+		}
+		delete(h.conns, o.Conn)
+		if nc.c != nil {
+			nc.c.Close()
+		}
+		return struct{}{}, true
+	}
+	unhandledEffect("ConnHandler", op)
+	return nil, false
+}
+
+func (h *netConnHandler[A]) fail(op Operation, addr string, err error) (Resumed, bool) {
+	return Left[error, A](&ConnError{Op: op, Addr: addr, Err: err}), false
+}
+
+// attempt runs f up to Reconnect.MaxAttempts times, backing off between
+// failures, and returns the last error.
+func (h *netConnHandler[A]) attempt(f func() error) error {
+	for retry := 1; ; retry++ {
+		err := f()
+		if err == nil || retry >= h.opts.Reconnect.MaxAttempts {
+			return err
+		}
+		if h.opts.Reconnect.Backoff != nil {
+			h.opts.Sleep(h.opts.Reconnect.Backoff(retry))
+		}
+	}
+}
+
+// io redials nc if it has failed, then runs f, dropping the transport if f
+// fails so the next attempt reconnects.
+func (h *netConnHandler[A]) io(nc *netConn, f func() error) error {
+	if nc.c == nil {
+		if err := h.redial(nc); err != nil {
+			return err
+		}
+	}
+	if err := f(); err != nil {
+		nc.c.Close()
+		nc.c, nc.r = nil, nil
+		return err
+	}
+	return nil
+}
+
+func (h *netConnHandler[A]) redial(nc *netConn) error {
+	c, err := h.opts.Dial(nc.addr)
+	if err != nil {
+		return err
+	}
+	nc.c, nc.r = c, bufio.NewReader(c)
+	return nil
+}
+
+// Close closes every connection the computation left open.
+func (h *netConnHandler[A]) Close() {
+	for id, nc := range h.conns {
+		if nc.c != nil {
+			nc.c.Close()
+		}
+		delete(h.conns, id)
+	}
+}
+
+func writeFrame(w io.Writer, data []byte) error {
+	buf := make([]byte, 4+len(data))
+	binary.BigEndian.PutUint32(buf, uint32(len(data)))
+	copy(buf[4:], data)
+	_, err := w.Write(buf)
+	return err
+}
+
+func readFrame(r io.Reader, maxFrame int) ([]byte, error) {
+	var hdr [4]byte
+	if _, err := io.ReadFull(r, hdr[:]); err != nil {
+		return nil, err
+	}
+	n := binary.BigEndian.Uint32(hdr[:])
+	if uint64(n) > uint64(maxFrame) {
+		return nil, fmt.Errorf("%w: %d bytes, limit %d", ErrFrameTooLarge, n, maxFrame)
+	}
+	msg := make([]byte, n)
+	if _, err := io.ReadFull(r, msg); err != nil {
+		return nil, err
+	}
+	return msg, nil
+}
+
+// ConnHandler creates a handler serving the connection effects over
+// net.Conn transports. Each message is framed with a 4-byte big-endian
+// length. A send or receive that fails is retried on a fresh connection,
+// so after a reconnect a message may be delivered twice or a reply lost;
+// the peer's protocol must tolerate that.
+// Returns a concrete handler.
+func ConnHandler[A any](opts ConnOptions) *netConnHandler[A] {
+	if opts.Dial == nil {
+		opts.Dial = func(addr string) (net.Conn, error) { return net.Dial("tcp", addr) }
+	}
+	if opts.Sleep == nil {
+		opts.Sleep = time.Sleep
+	}
+	if opts.MaxFrame == 0 {
+		opts.MaxFrame = defaultMaxFrame
+	}
+	return &netConnHandler[A]{opts: opts, conns: make(map[ConnID]*netConn)}
+}
+
+// loopbackConnHandler implements Handler for the connection effects in
+// memory. Each connection has a queue of messages waiting to be received.
+type loopbackConnHandler[A any] struct {
+	serve func(addr string, msg []byte) [][]byte
+	conns map[ConnID]*loopbackConn
+	next  ConnID
+}
+
+type loopbackConn struct {
+	addr  string
+	queue [][]byte
+}
+
+// Dispatch implements Handler for the connection effects.
+func (h *loopbackConnHandler[A]) Dispatch(op Operation) (Resumed, bool) {
+	switch o := op.(type) {
+	case ConnOpen:
+		h.next++
+		h.conns[h.next] = &loopbackConn{addr: o.Addr}
+		return h.next, true
+	case ConnSend:
+		lc, ok := h.conns[o.Conn]
+		if !ok {
+			return Left[error, A](&ConnError{Op: op, Err: ErrConnClosed}), false
+		}
+		lc.queue = append(lc.queue, h.serve(lc.addr, slices.Clone(o.Data))...)
+		return struct{}{}, true
+	case ConnRecv:
+		lc, ok := h.conns[o.Conn]
+		if !ok {
+			return Left[error, A](&ConnError{Op: op, Err: ErrConnClosed}), false
+		}
+		if len(lc.queue) == 0 {
+			return Left[error, A](&ConnError{Op: op, Addr: lc.addr, Err: io.EOF}), false
+		}
+		msg := lc.queue[0]
+		lc.queue[0] = nil
+		lc.queue = lc.queue[1:]
+		return msg, true
+	case ConnClose:
+		if _, ok := h.conns[o.Conn]; !ok {
+			return Left[error, A](&ConnError{Op: op, Err: ErrConnClosed}), false
+		}
+		delete(h.conns, o.Conn)
+		return struct{}{}, true
+	}
+	unhandledEffect("LoopbackConnHandler", op)
+	return nil, false
+}
+
+// LoopbackConnHandler creates an in-memory handler for the connection
+// effects, for tests. Each message sent on a connection is passed to
+// serve with the connection's address, and the replies it returns are
+// queued for ConnRecv. A nil serve echoes every message. Receiving from an
+// empty queue fails with io.EOF.
+// Returns a concrete handler.
+func LoopbackConnHandler[A any](serve func(addr string, msg []byte) [][]byte) *loopbackConnHandler[A] {
+	if serve == nil {
+		serve = func(_ string, msg []byte) [][]byte { return [][]byte{msg} }
+	}
+	return &loopbackConnHandler[A]{serve: serve, conns: make(map[ConnID]*loopbackConn)}
+}
+
+// RunConn runs a computation with connections served by [ConnHandler],
+// closing any it leaves open, and returns its value or the *ConnError that
+// stopped it.
+func RunConn[A any](opts ConnOptions, m Cont[Resumed, A]) (A, error) {
+	h := ConnHandler[A](opts)
+	defer h.Close()
+	return eitherErr(Handle(Map(m, Right[error, A]), h))
+}
+
+// RunConnExpr is the Expr counterpart of [RunConn].
+func RunConnExpr[A any](opts ConnOptions, m Expr[A]) (A, error) {
+	h := ConnHandler[A](opts)
+	defer h.Close()
+	return eitherErr(HandleExpr(ExprMap(m, Right[error, A]), h))
+}
+
+// RunLoopbackConn runs a computation with connections served in memory by
+// [LoopbackConnHandler].
+func RunLoopbackConn[A any](serve func(addr string, msg []byte) [][]byte, m Cont[Resumed, A]) (A, error) {
+	return eitherErr(Handle(Map(m, Right[error, A]), LoopbackConnHandler[A](serve)))
+}
+
+// RunLoopbackConnExpr is the Expr counterpart of [RunLoopbackConn].
+func RunLoopbackConnExpr[A any](serve func(addr string, msg []byte) [][]byte, m Expr[A]) (A, error) {
+	return eitherErr(HandleExpr(ExprMap(m, Right[error, A]), LoopbackConnHandler[A](serve)))
+}
@@ -0,0 +1,163 @@
+// ©Hayabusa Cloud Co., Ltd. 2026. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package kont_test
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"io"
+	"net"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"code.hybscloud.com/kont"
+)
+
+// echoServer serves length-prefixed frames on c, replying with each message
+// upper-cased, until the peer closes.
+func echoServer(c net.Conn) {
+	defer c.Close()
+	r := bufio.NewReader(c)
+	for {
+		var hdr [4]byte
+		if _, err := io.ReadFull(r, hdr[:]); err != nil {
+			return
+		}
+		msg := make([]byte, binary.BigEndian.Uint32(hdr[:]))
+		if _, err := io.ReadFull(r, msg); err != nil {
+			return
+		}
+		reply := []byte(strings.ToUpper(string(msg)))
+		out := binary.BigEndian.AppendUint32(nil, uint32(len(reply)))
+		if _, err := c.Write(append(out, reply...)); err != nil {
+			return
+		}
+	}
+}
+
+func pingPong(addr string, msgs ...string) kont.Eff[[]string] {
+	return kont.Bind(kont.OpenConn(addr), func(c kont.ConnID) kont.Eff[[]string] {
+		var loop func(i int, acc []string) kont.Eff[[]string]
+		loop = func(i int, acc []string) kont.Eff[[]string] {
+			if i == len(msgs) {
+				return kont.Then(kont.CloseConn(c), kont.Pure(acc))
+			}
+			return kont.Then(kont.SendConn(c, []byte(msgs[i])), kont.Bind(kont.RecvConn(c), func(b []byte) kont.Eff[[]string] {
+				return loop(i+1, append(acc, string(b)))
+			}))
+		}
+		return loop(0, nil)
+	})
+}
+
+func TestRunConnEcho(t *testing.T) {
+	opts := kont.ConnOptions{Dial: func(string) (net.Conn, error) {
+		client, server := net.Pipe()
+		go echoServer(server)
+		return client, nil
+	}}
+	got, err := kont.RunConn(opts, pingPong("svc", "a", "b"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"A", "B"}; !slices.Equal(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+}
+
+func TestRunConnReconnect(t *testing.T) {
+	dials := 0
+	var waits []time.Duration
+	opts := kont.ConnOptions{
+		Dial: func(string) (net.Conn, error) {
+			dials++
+			client, server := net.Pipe()
+			if dials == 1 {
+				server.Close()
+			} else {
+				go echoServer(server)
+			}
+			return client, nil
+		},
+		Reconnect: kont.RetryPolicy{MaxAttempts: 3, Backoff: func(n int) time.Duration { return time.Duration(n) * time.Second }},
+		Sleep:     func(d time.Duration) { waits = append(waits, d) },
+	}
+	got, err := kont.RunConnExpr(opts, kont.ExprBind(kont.ExprOpenConn("svc"), func(c kont.ConnID) kont.Expr[[]byte] {
+		return kont.ExprThen(kont.ExprSendConn(c, []byte("hi")), kont.ExprRecvConn(c))
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != "HI" || dials != 2 || !slices.Equal(waits, []time.Duration{time.Second}) {
+		t.Fatalf("got %q after %d dials, waits %v", got, dials, waits)
+	}
+}
+
+func TestRunConnDialFailure(t *testing.T) {
+	refused := errors.New("refused")
+	dials := 0
+	opts := kont.ConnOptions{
+		Dial:      func(string) (net.Conn, error) { dials++; return nil, refused },
+		Reconnect: kont.RetryPolicy{MaxAttempts: 3},
+	}
+	_, err := kont.RunConn(opts, pingPong("svc", "a"))
+	var ce *kont.ConnError
+	if !errors.As(err, &ce) || !errors.Is(err, refused) || ce.Addr != "svc" {
+		t.Fatalf("got %v", err)
+	}
+	if _, ok := ce.Op.(kont.ConnOpen); !ok || dials != 3 {
+		t.Fatalf("op %T after %d dials", ce.Op, dials)
+	}
+}
+
+func TestRunConnMaxFrame(t *testing.T) {
+	opts := kont.ConnOptions{
+		Dial: func(string) (net.Conn, error) {
+			client, server := net.Pipe()
+			go func() {
+				defer server.Close()
+				server.Write(binary.BigEndian.AppendUint32(nil, 1<<31))
+			}()
+			return client, nil
+		},
+		MaxFrame: 1 << 10,
+	}
+	_, err := kont.RunConn(opts, kont.Bind(kont.OpenConn("svc"), kont.RecvConn))
+	if !errors.Is(err, kont.ErrFrameTooLarge) {
+		t.Fatalf("got %v, want ErrFrameTooLarge", err)
+	}
+}
+
+func TestRunLoopbackConn(t *testing.T) {
+	got, err := kont.RunLoopbackConn(nil, pingPong("echo", "x", "y"))
+	if err != nil || !slices.Equal(got, []string{"x", "y"}) {
+		t.Fatalf("got %v, %v", got, err)
+	}
+
+	serve := func(addr string, msg []byte) [][]byte {
+		return [][]byte{[]byte(addr + ":" + string(msg))}
+	}
+	got, err = kont.RunLoopbackConn(serve, pingPong("svc", "x"))
+	if err != nil || !slices.Equal(got, []string{"svc:x"}) {
+		t.Fatalf("got %v, %v", got, err)
+	}
+}
+
+func TestRunLoopbackConnErrors(t *testing.T) {
+	_, err := kont.RunLoopbackConn(nil, kont.Bind(kont.OpenConn("svc"), kont.RecvConn))
+	if !errors.Is(err, io.EOF) {
+		t.Fatalf("empty recv: got %v", err)
+	}
+	closed := kont.Bind(kont.OpenConn("svc"), func(c kont.ConnID) kont.Eff[struct{}] {
+		return kont.Then(kont.CloseConn(c), kont.SendConn(c, nil))
+	})
+	_, err = kont.RunLoopbackConnExpr(nil, kont.Reify(closed))
+	if !errors.Is(err, kont.ErrConnClosed) {
+		t.Fatalf("send after close: got %v", err)
+	}
+}
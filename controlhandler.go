@@ -0,0 +1,67 @@
+// ©Hayabusa Cloud Co., Ltd. 2026. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package kont
+
+// Control handlers.
+// A Handler only chooses a resume value. A ControlHandler receives the
+// pending Suspension itself, so it can resume it later, discard it, resume
+// another suspension it saved, or clone it to resume several times —
+// enough to implement exceptions, coroutines and schedulers directly.
+
+// ControlHandler is the F-bounded interface for handlers that own the
+// continuation. Control is called with each pending suspension and returns
+// either the next suspension to control or nil with the final result.
+// Every suspension it receives must be resumed or discarded exactly once,
+// now or later.
+type ControlHandler[H ControlHandler[H, R], R any] interface {
+	Control(s *Suspension[R]) (R, *Suspension[R])
+}
+
+// HandleControl runs m, passing each suspension to h until h returns a
+// final result.
+func HandleControl[H ControlHandler[H, R], R any](m Cont[Resumed, R], h H) R {
+	r, s := Step(m)
+	return controlLoop(r, s, h)
+}
+
+// HandleControlExpr is the Expr counterpart of [HandleControl]. Its
+// suspensions can always be cloned.
+func HandleControlExpr[H ControlHandler[H, R], R any](m Expr[R], h H) R {
+	r, s := StepExpr(m)
+	return controlLoop(r, s, h)
+}
+
+func controlLoop[H ControlHandler[H, R], R any](r R, s *Suspension[R], h H) R {
+	for s != nil {
+		r, s = h.Control(s)
+	}
+	return r
+}
+
+// controlFunc adapts a function to ControlHandler.
+type controlFunc[R any] struct {
+	f func(s *Suspension[R]) (R, *Suspension[R])
+}
+
+// Control implements ControlHandler.
+func (h *controlFunc[R]) Control(s *Suspension[R]) (R, *Suspension[R]) {
+	return h.f(s)
+}
+
+// ControlFunc creates a ControlHandler from a function.
+// Returns a concrete handler.
+//
+// Example, an exception handler that abandons the rest of the computation:
+//
+//	h := kont.ControlFunc(func(s *kont.Suspension[int]) (int, *kont.Suspension[int]) {
+//	    if t, ok := s.Op().(kont.Throw[string]); ok {
+//	        s.Discard()
+//	        return len(t.Err), nil
+//	    }
+//	    return s.Resume(0)
+//	})
+func ControlFunc[R any](f func(s *Suspension[R]) (R, *Suspension[R])) *controlFunc[R] {
+	return &controlFunc[R]{f: f}
+}
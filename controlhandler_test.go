@@ -0,0 +1,72 @@
+// ©Hayabusa Cloud Co., Ltd. 2026. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package kont_test
+
+import (
+	"slices"
+	"testing"
+
+	"code.hybscloud.com/kont"
+)
+
+func TestHandleControlException(t *testing.T) {
+	h := kont.ControlFunc(func(s *kont.Suspension[int]) (int, *kont.Suspension[int]) {
+		if th, ok := s.Op().(kont.Throw[string]); ok {
+			s.Discard()
+			return len(th.Err), nil
+		}
+		return s.Resume(10)
+	})
+	comp := kont.Bind(kont.Perform(kont.Ask[int]{}), func(n int) kont.Eff[int] {
+		if n > 5 {
+			return kont.ThrowError[string, int]("too big")
+		}
+		return kont.Pure(n)
+	})
+	if got := kont.HandleControl(comp, h); got != 7 {
+		t.Fatalf("got %d", got)
+	}
+}
+
+// yieldRecorder records yielded values and resumes each yield.
+type yieldRecorder struct {
+	trace []int
+}
+
+func (h *yieldRecorder) Control(s *kont.Suspension[[]int]) ([]int, *kont.Suspension[[]int]) {
+	h.trace = append(h.trace, s.Op().(kont.Yield[int]).Value)
+	return s.Resume(struct{}{})
+}
+
+func TestHandleControlExprCustomHandler(t *testing.T) {
+	h := &yieldRecorder{}
+	comp := kont.ExprThen(kont.ExprYieldValue(1),
+		kont.ExprThen(kont.ExprYieldValue(2), kont.ExprReturn([]int{0})))
+	got := kont.HandleControlExpr(comp, h)
+	if !slices.Equal(got, []int{0}) || !slices.Equal(h.trace, []int{1, 2}) {
+		t.Fatalf("got %v trace %v", got, h.trace)
+	}
+}
+
+func TestHandleControlExprMultiShot(t *testing.T) {
+	// Collect the result of resuming the same choice with every option.
+	var results []int
+	h := kont.ControlFunc(func(s *kont.Suspension[int]) (int, *kont.Suspension[int]) {
+		for _, v := range []int{1, 2, 3} {
+			r, next := s.Clone().Resume(v)
+			if next != nil {
+				t.Fatal("unexpected suspension")
+			}
+			results = append(results, r)
+		}
+		s.Discard()
+		return 0, nil
+	})
+	comp := kont.ExprMap(kont.ExprPerform(kont.Ask[int]{}), func(n int) int { return n * 10 })
+	kont.HandleControlExpr(comp, h)
+	if !slices.Equal(results, []int{10, 20, 30}) {
+		t.Fatalf("results %v", results)
+	}
+}
@@ -1454,7 +1454,7 @@ func TestRunErrorExprUnhandledPanic(t *testing.T) {
 		if r == nil {
 			t.Fatal("expected panic")
 		}
-		if r != "kont: unhandled effect in ErrorHandler" {
+		if unhandledIn(r) != "ErrorHandler" {
 			t.Fatalf("unexpected panic: %v", r)
 		}
 	}()
@@ -1468,7 +1468,7 @@ func TestRunStateErrorExprUnhandledPanic(t *testing.T) {
 		if r == nil {
 			t.Fatal("expected panic")
 		}
-		if r != "kont: unhandled effect in StateErrorHandler" {
+		if unhandledIn(r) != "StateErrorHandler" {
 			t.Fatalf("unexpected panic: %v", r)
 		}
 	}()
@@ -1482,7 +1482,7 @@ func TestRunReaderStateErrorExprUnhandledPanic(t *testing.T) {
 		if r == nil {
 			t.Fatal("expected panic")
 		}
-		if r != "kont: unhandled effect in ReaderStateErrorHandler" {
+		if unhandledIn(r) != "ReaderStateErrorHandler" {
 			t.Fatalf("unexpected panic: %v", r)
 		}
 	}()
@@ -0,0 +1,206 @@
+// ©Hayabusa Cloud Co., Ltd. 2026. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package kont
+
+import (
+	"context"
+	"time"
+)
+
+// Context effect.
+// The context-aware runners answer AskCtx and CheckCancelled from a
+// context.Context and poll it between dispatches, so a long-running
+// computation can be cancelled from outside. Cancellation is reported as a
+// [*CancelledError] in Go's (value, error) shape.
+
+// AskCtx is the effect operation for reading the current context.
+type AskCtx struct{}
+
+func (AskCtx) OpResult() context.Context { panic("phantom") }
+
+// CheckCancelled is the effect operation for a cancellation check point.
+// The runner aborts the computation if the current context is done.
+type CheckCancelled struct {
+	// ctx is the context of the innermost WithTimeout scope, or nil for
+	// the runner's context.
+	ctx context.Context
+}
+
+func (CheckCancelled) OpResult() struct{} { panic("phantom") }
+
+// GetCtx performs AskCtx.
+func GetCtx() Cont[Resumed, context.Context] {
+	return Perform(AskCtx{})
+}
+
+// CheckCtx performs CheckCancelled.
+func CheckCtx() Cont[Resumed, struct{}] {
+	return Perform(CheckCancelled{})
+}
+
+// ExprGetCtx is the Expr counterpart of [GetCtx].
+func ExprGetCtx() Expr[context.Context] {
+	return ExprPerform(AskCtx{})
+}
+
+// ExprCheckCtx is the Expr counterpart of [CheckCtx].
+func ExprCheckCtx() Expr[struct{}] {
+	return ExprPerform(CheckCancelled{})
+}
+
+// WithTimeout runs body under a context derived from the current one with
+// the given timeout. AskCtx inside body sees the derived context and
+// CheckCancelled checks it; the context is cancelled when body completes.
+// Children spawned in body inherit its deadline but not its cancellation.
+// Effects performed after body completes see the enclosing context.
+func WithTimeout[A any](d time.Duration, body Cont[Resumed, A]) Cont[Resumed, A] {
+	return Bind(GetCtx(), func(parent context.Context) Cont[Resumed, A] {
+		return func(k func(A) Resumed) Resumed {
+			ctx, cancel := context.WithTimeout(parent, d)
+			return ctxScopeResult(body(scopeDoneCont[A]), ctx, cancel, k)
+		}
+	})
+}
+
+// ExprWithTimeout is the Expr counterpart of [WithTimeout].
+func ExprWithTimeout[A any](d time.Duration, m Expr[A]) Expr[A] {
+	return Reify(WithTimeout(d, Reflect(m)))
+}
+
+// ctxScopeSuspension wraps a suspension raised inside WithTimeout, binding
+// CheckCancelled to the scope's context.
+type ctxScopeSuspension[A any] struct {
+	inner  effectSuspension
+	op     Operation
+	ctx    context.Context
+	cancel context.CancelFunc
+	k      func(A) Resumed
+}
+
+func (s *ctxScopeSuspension[A]) Op() Operation { return s.op }
+func (s *ctxScopeSuspension[A]) Resume(v Resumed) Resumed {
+	return ctxScopeResult(s.inner.Resume(v), s.ctx, s.cancel, s.k)
+}
+func (s *ctxScopeSuspension[A]) resumeWithError(throw Operation) Resumed {
+	return ctxScopeResult(s.inner.resumeWithError(throw), s.ctx, s.cancel, s.k)
+}
+func (s *ctxScopeSuspension[A]) release() {
+	s.cancel()
+	s.inner.release()
+}
+func (s *ctxScopeSuspension[A]) site() provenance { return s.inner.site() }
+
+func ctxScopeResult[A any](r Resumed, ctx context.Context, cancel context.CancelFunc, k func(A) Resumed) Resumed {
+	for {
+		switch rr := r.(type) {
+		case scopeDone[A]:
+			cancel()
+			return k(rr.value)
+		case effectSuspension:
+			op := rr.Op()
+			switch o := op.(type) {
+			case AskCtx:
+				r = rr.Resume(ctx)
+				continue
+			case CheckCancelled:
+				if o.ctx == nil {
+					op = CheckCancelled{ctx: ctx}
+				}
+			case Spawn:
+				if o.ctx == nil {
+					o.ctx = ctx
+					op = o
+				}
+			}
+			return &ctxScopeSuspension[A]{inner: rr, op: op, ctx: ctx, cancel: cancel, k: k}
+		}
+		cancel()
+		return r
+	}
+}
+
+// cancelledBy returns the error reported for a computation aborted because
+// ctx is done.
+func cancelledBy(ctx context.Context) error {
+	return &CancelledError{Cause: context.Cause(ctx)}
+}
+
+// StepCtx is [Step] with the Context effect handled from ctx. It returns
+// the next suspension on any other operation, or a [*CancelledError] if ctx
+// is done or a CheckCancelled fails; the pending suspension is then
+// discarded.
+func StepCtx[A any](ctx context.Context, m Cont[Resumed, A]) (A, *Suspension[A], error) {
+	v, s := Step(m)
+	return stepCtx(ctx, v, s)
+}
+
+// StepCtxExpr is the Expr counterpart of [StepCtx].
+func StepCtxExpr[A any](ctx context.Context, m Expr[A]) (A, *Suspension[A], error) {
+	v, s := StepExpr(m)
+	return stepCtx(ctx, v, s)
+}
+
+// ResumeCtx resumes s with v and continues like [StepCtx].
+func ResumeCtx[A any](ctx context.Context, s *Suspension[A], v Resumed) (A, *Suspension[A], error) {
+	a, next := s.Resume(v)
+	return stepCtx(ctx, a, next)
+}
+
+func stepCtx[A any](ctx context.Context, v A, s *Suspension[A]) (A, *Suspension[A], error) {
+	done := ctx.Done()
+	for s != nil {
+		select {
+		case <-done:
+			s.Discard()
+			var zero A
+			return zero, nil, cancelledBy(ctx)
+		default:
+		}
+		switch op := s.Op().(type) {
+		case AskCtx:
+			v, s = s.Resume(ctx)
+		case CheckCancelled:
+			c := op.ctx
+			if c == nil {
+				c = ctx
+			}
+			if c.Err() != nil {
+				s.Discard()
+				var zero A
+				return zero, nil, cancelledBy(c)
+			}
+			v, s = s.Resume(struct{}{})
+		default:
+			return v, s, nil
+		}
+	}
+	return v, nil, nil
+}
+
+// HandleCtx runs m with handler h like [Handle], answering the Context
+// effect from ctx. ctx is polled before every dispatch; once it is done the
+// computation is abandoned and a [*CancelledError] is returned.
+func HandleCtx[H Handler[H, R], R any](ctx context.Context, m Cont[Resumed, R], h H) (R, error) {
+	v, s, err := StepCtx(ctx, m)
+	return handleCtx(ctx, h, v, s, err)
+}
+
+// HandleCtxExpr is the Expr counterpart of [HandleCtx].
+func HandleCtxExpr[H Handler[H, R], R any](ctx context.Context, m Expr[R], h H) (R, error) {
+	v, s, err := StepCtxExpr(ctx, m)
+	return handleCtx(ctx, h, v, s, err)
+}
+
+func handleCtx[H Handler[H, R], R any](ctx context.Context, h H, v R, s *Suspension[R], err error) (R, error) {
+	for s != nil {
+		r, shouldResume := h.Dispatch(s.Op())
+		if !shouldResume {
+			s.Discard()
+			return valueOrZero[R](r), nil
+		}
+		v, s, err = ResumeCtx(ctx, s, r)
+	}
+	return v, err
+}
@@ -0,0 +1,114 @@
+// ©Hayabusa Cloud Co., Ltd. 2026. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package kont_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"code.hybscloud.com/kont"
+)
+
+type ctxKey struct{}
+
+func TestHandleCtxAnswersContext(t *testing.T) {
+	ctx := context.WithValue(context.Background(), ctxKey{}, "v")
+	comp := kont.Bind(kont.GetCtx(), func(c context.Context) kont.Eff[string] {
+		return kont.Then(kont.CheckCtx(), kont.Bind(kont.Perform(kont.Get[int]{}), func(n int) kont.Eff[string] {
+			return kont.Pure(c.Value(ctxKey{}).(string) + string(rune('0'+n)))
+		}))
+	})
+	h, _ := kont.StateHandler[int, string](7)
+	got, err := kont.HandleCtx(ctx, comp, h)
+	if err != nil || got != "v7" {
+		t.Fatalf("got %q, %v", got, err)
+	}
+}
+
+func TestHandleCtxCancelledFromOutside(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	var loop func() kont.Eff[int]
+	loop = func() kont.Eff[int] {
+		return kont.Bind(kont.Perform(kont.Get[int]{}), func(n int) kont.Eff[int] {
+			if n == 3 {
+				cancel()
+			}
+			return kont.Then(kont.Perform(kont.Put[int]{Value: n + 1}), loop())
+		})
+	}
+	h, get := kont.StateHandler[int, int](0)
+	_, err := kont.HandleCtx(ctx, kont.Bind(kont.Pure(0), func(int) kont.Eff[int] { return loop() }), h)
+	var ce *kont.CancelledError
+	if !errors.Is(err, kont.ErrCancelled) || !errors.As(err, &ce) || !errors.Is(ce.Cause, context.Canceled) {
+		t.Fatalf("got %v", err)
+	}
+	// The Put performed after cancel is never dispatched.
+	if get() != 3 {
+		t.Fatalf("state %d, want 3", get())
+	}
+}
+
+func TestWithTimeoutScope(t *testing.T) {
+	parent := context.Background()
+	var inner context.Context
+	comp := kont.Then(
+		kont.WithTimeout(time.Hour, kont.Map(kont.GetCtx(), func(c context.Context) struct{} { inner = c; return struct{}{} })),
+		kont.GetCtx(),
+	)
+	got, err := kont.HandleCtx(parent, comp, kont.ReaderHandler[int, context.Context](0))
+	if err != nil || got != parent {
+		t.Fatalf("after scope got %v, %v", got, err)
+	}
+	if _, ok := inner.Deadline(); !ok || inner.Err() == nil {
+		t.Fatalf("inner context should carry a deadline and be cancelled on exit")
+	}
+
+	expired := kont.WithTimeout(0, kont.Then(kont.CheckCtx(), kont.Pure(1)))
+	_, err = kont.HandleCtx(parent, expired, kont.ReaderHandler[int, int](0))
+	if !errors.Is(err, kont.ErrCancelled) || !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expired scope: got %v", err)
+	}
+}
+
+func TestStepCtx(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	comp := kont.Bind(kont.Perform(kont.Ask[int]{}), func(n int) kont.Eff[int] {
+		return kont.Then(kont.CheckCtx(), kont.Pure(n*2))
+	})
+	_, s, err := kont.StepCtx(ctx, comp)
+	if err != nil || s == nil {
+		t.Fatalf("got %v, %v", s, err)
+	}
+	if got, s, err := kont.ResumeCtx(ctx, s, 4); err != nil || s != nil || got != 8 {
+		t.Fatalf("got %d, %v, %v", got, s, err)
+	}
+
+	_, s, _ = kont.StepCtx(ctx, comp)
+	cancel()
+	if _, s, err := kont.ResumeCtx(ctx, s, 4); !errors.Is(err, context.Canceled) || s != nil {
+		t.Fatalf("after cancel got %v, %v", s, err)
+	}
+}
+
+func TestHandleCtxExpr(t *testing.T) {
+	comp := kont.ExprWithTimeout(time.Hour, kont.ExprThen(kont.ExprCheckCtx(),
+		kont.ExprMap(kont.ExprGetCtx(), func(c context.Context) bool {
+			_, ok := c.Deadline()
+			return ok
+		})))
+	got, err := kont.HandleCtxExpr(context.Background(), comp, kont.ReaderHandler[int, bool](0))
+	if err != nil || !got {
+		t.Fatalf("got %v, %v", got, err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, _, err = kont.StepCtxExpr(ctx, kont.ExprCheckCtx())
+	if !errors.Is(err, kont.ErrCancelled) {
+		t.Fatalf("got %v", err)
+	}
+}
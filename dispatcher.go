@@ -0,0 +1,100 @@
+// ©Hayabusa Cloud Co., Ltd. 2026. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package kont
+
+import "fmt"
+
+// OpKey identifies an operation type to a [Dispatcher]. Create one per
+// operation type with [NewOpKey], typically in a package-level variable,
+// and return it from the type's DispatchKey method.
+type OpKey struct{ k *opKey }
+
+type opKey struct{ name string }
+
+// NewOpKey creates a distinct OpKey. name is used only in messages.
+func NewOpKey(name string) OpKey { return OpKey{k: &opKey{name: name}} }
+
+// String returns the name given to NewOpKey.
+func (k OpKey) String() string {
+	if k.k == nil {
+		return "<nil OpKey>"
+	}
+	return k.k.name
+}
+
+// KeyedOp is an operation that can be registered with a [Dispatcher].
+// DispatchKey must return the same OpKey for every value of the type,
+// including its zero value.
+type KeyedOp interface {
+	Operation
+	DispatchKey() OpKey
+}
+
+// Dispatcher builds a handler from per-operation functions, replacing the
+// open-ended type switch of [HandleFunc] with a registry keyed by operation
+// type. Register cases with [Case] (Go methods cannot take type
+// parameters), then call Build. Operations are looked up by their
+// DispatchKey, so dispatch involves no reflection and no type switch.
+type Dispatcher[R any] struct {
+	cases map[*opKey]func(Operation) (Resumed, bool)
+	ops   []OpKey
+}
+
+// NewDispatcher creates an empty Dispatcher.
+func NewDispatcher[R any]() *Dispatcher[R] {
+	return &Dispatcher[R]{cases: make(map[*opKey]func(Operation) (Resumed, bool))}
+}
+
+// Case registers f for the operation type O and returns d for chaining.
+// It panics if O is an interface type, if its DispatchKey is the zero
+// OpKey, or if its key is already registered.
+func Case[R any, O KeyedOp](d *Dispatcher[R], f func(O) (Resumed, bool)) *Dispatcher[R] {
+	var zero O
+	if any(zero) == nil {
+		panic("kont: Dispatcher case for an interface type")
+	}
+	key := zero.DispatchKey()
+	if key.k == nil {
+		panic(fmt.Sprintf("kont: Dispatcher case for %T has no OpKey", zero))
+	}
+	if _, dup := d.cases[key.k]; dup {
+		panic(fmt.Sprintf("kont: duplicate Dispatcher case for %v", key))
+	}
+	d.cases[key.k] = func(op Operation) (Resumed, bool) { return f(op.(O)) }
+	d.ops = append(d.ops, key)
+	return d
+}
+
+// Ops returns the registered operation keys in registration order.
+func (d *Dispatcher[R]) Ops() []OpKey {
+	return append([]OpKey(nil), d.ops...)
+}
+
+// Build returns a handler serving the cases registered so far.
+// Later registrations on d do not affect it.
+func (d *Dispatcher[R]) Build() *dispatcherHandler[R] {
+	cases := make(map[*opKey]func(Operation) (Resumed, bool), len(d.cases))
+	for k, f := range d.cases {
+		cases[k] = f
+	}
+	return &dispatcherHandler[R]{cases: cases}
+}
+
+// dispatcherHandler implements Handler over a Dispatcher's cases.
+type dispatcherHandler[R any] struct {
+	cases map[*opKey]func(Operation) (Resumed, bool)
+}
+
+// Dispatch implements Handler by looking up the case for op's DispatchKey.
+// Operations that are not [KeyedOp] or have no case are unhandled.
+func (h *dispatcherHandler[R]) Dispatch(op Operation) (Resumed, bool) {
+	if kop, ok := op.(KeyedOp); ok {
+		if f, ok := h.cases[kop.DispatchKey().k]; ok {
+			return f(op)
+		}
+	}
+	unhandledEffect("Dispatcher", op)
+	return nil, false
+}
@@ -0,0 +1,169 @@
+// ©Hayabusa Cloud Co., Ltd. 2026. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package kont_test
+
+import (
+	"slices"
+	"testing"
+
+	"code.hybscloud.com/kont"
+)
+
+// Keyed operations of a small account service.
+type (
+	readBalance struct{}
+	credit      struct{ Amount int }
+	debit       struct{ Amount int }
+)
+
+var (
+	readBalanceKey = kont.NewOpKey("readBalance")
+	creditKey      = kont.NewOpKey("credit")
+	debitKey       = kont.NewOpKey("debit")
+)
+
+func (readBalance) OpResult() int           { panic("phantom") }
+func (readBalance) DispatchKey() kont.OpKey { return readBalanceKey }
+func (credit) OpResult() struct{}           { panic("phantom") }
+func (credit) DispatchKey() kont.OpKey      { return creditKey }
+func (debit) OpResult() struct{}            { panic("phantom") }
+func (debit) DispatchKey() kont.OpKey       { return debitKey }
+
+// Operations with invalid keys: unkeyed returns the zero OpKey, and
+// sharedKey reuses the key of readBalance.
+type (
+	unkeyed   struct{}
+	sharedKey struct{}
+)
+
+func (unkeyed) DispatchKey() (k kont.OpKey) { return k }
+func (sharedKey) DispatchKey() kont.OpKey   { return readBalanceKey }
+
+// accountDispatcher registers readBalance, credit and debit over balance.
+func accountDispatcher(balance *int) *kont.Dispatcher[int] {
+	d := kont.NewDispatcher[int]()
+	kont.Case(d, func(readBalance) (kont.Resumed, bool) { return *balance, true })
+	kont.Case(d, func(op credit) (kont.Resumed, bool) {
+		*balance += op.Amount
+		return struct{}{}, true
+	})
+	kont.Case(d, func(op debit) (kont.Resumed, bool) {
+		if op.Amount > *balance {
+			return -1, false
+		}
+		*balance -= op.Amount
+		return struct{}{}, true
+	})
+	return d
+}
+
+func TestDispatcherCases(t *testing.T) {
+	balance := 10
+	h := accountDispatcher(&balance).Build()
+	comp := kont.Then(kont.Perform(credit{Amount: 5}), kont.Then(kont.Perform(debit{Amount: 3}), kont.Perform(readBalance{})))
+	if got := kont.Handle(comp, h); got != 12 {
+		t.Fatalf("got %d, want 12", got)
+	}
+}
+
+func TestDispatcherOps(t *testing.T) {
+	balance := 0
+	want := []kont.OpKey{readBalanceKey, creditKey, debitKey}
+	if got := accountDispatcher(&balance).Ops(); !slices.Equal(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+}
+
+func TestDispatcherShortCircuit(t *testing.T) {
+	balance := 1
+	comp := kont.Then(kont.Perform(debit{Amount: 2}), kont.Perform(readBalance{}))
+	if got := kont.Handle(comp, accountDispatcher(&balance).Build()); got != -1 {
+		t.Fatalf("got %d, want -1", got)
+	}
+}
+
+func TestDispatcherBuildSnapshot(t *testing.T) {
+	d := kont.NewDispatcher[int]()
+	h := d.Build()
+	kont.Case(d, func(readBalance) (kont.Resumed, bool) { return 1, true })
+	defer func() {
+		if r := recover(); unhandledIn(r) != "Dispatcher" {
+			t.Fatalf("got panic %v", r)
+		}
+	}()
+	kont.Handle(kont.Perform(readBalance{}), h)
+}
+
+func TestDispatcherUnkeyedOpUnhandled(t *testing.T) {
+	balance := 0
+	h := accountDispatcher(&balance).Build()
+	defer func() {
+		if r := recover(); unhandledIn(r) != "Dispatcher" {
+			t.Fatalf("got panic %v", r)
+		}
+	}()
+	kont.Handle(kont.Perform(kont.Get[int]{}), h)
+}
+
+func TestDispatcherCasePanics(t *testing.T) {
+	for name, tc := range map[string]struct {
+		register func(*kont.Dispatcher[int])
+		want     string
+	}{
+		"duplicate": {
+			func(d *kont.Dispatcher[int]) {
+				kont.Case(d, func(sharedKey) (kont.Resumed, bool) { return 2, true })
+			},
+			"kont: duplicate Dispatcher case for readBalance",
+		},
+		"zero key": {
+			func(d *kont.Dispatcher[int]) {
+				kont.Case(d, func(unkeyed) (kont.Resumed, bool) { return nil, true })
+			},
+			"kont: Dispatcher case for kont_test.unkeyed has no OpKey",
+		},
+		"interface": {
+			func(d *kont.Dispatcher[int]) {
+				kont.Case(d, func(kont.KeyedOp) (kont.Resumed, bool) { return nil, true })
+			},
+			"kont: Dispatcher case for an interface type",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			balance := 0
+			d := accountDispatcher(&balance)
+			defer func() {
+				if r := recover(); r != tc.want {
+					t.Fatalf("got panic %v, want %q", r, tc.want)
+				}
+			}()
+			tc.register(d)
+		})
+	}
+}
+
+func TestDispatcherLookupDoesNotAllocate(t *testing.T) {
+	balance := 0
+	h := accountDispatcher(&balance).Build()
+	var op kont.Operation = readBalance{}
+	allocs := testing.AllocsPerRun(100, func() {
+		if _, ok := h.Dispatch(op); !ok {
+			t.Fatal("not resumed")
+		}
+	})
+	if allocs != 0 {
+		t.Fatalf("Dispatch allocated %.1f times", allocs)
+	}
+}
+
+func BenchmarkDispatcherLookup(b *testing.B) {
+	balance := 0
+	h := accountDispatcher(&balance).Build()
+	ops := []kont.Operation{readBalance{}, credit{Amount: 1}, debit{}}
+	b.ReportAllocs()
+	for i := 0; b.Loop(); i++ {
+		h.Dispatch(ops[i%len(ops)])
+	}
+}
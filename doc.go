@@ -41,6 +41,13 @@
 //
 //   - [Map]: Apply a function to the result, equivalent to Bind(m, func(a) Return(f(a)))
 //   - [Then]: Sequence discarding first result, equivalent to Bind(m, func(_) n)
+//   - [Sequence], [SequenceExpr]: Run computations in order and collect their results, in a loop rather than nested Binds
+//   - [Traverse], [TraverseExpr]: Map a slice to computations and run them like Sequence
+//   - [FoldM], [FoldMExpr]: Fold an effectful step over a slice, in a loop rather than by recursion
+//   - [WhileM], [WhileMExpr]: Run a body while a condition computation completes with true
+//   - [UntilM], [UntilMExpr]: Run a body, then a condition, until the condition completes with true
+//   - [ReplicateM], [ReplicateMExpr]: Run a computation n times and collect the results
+//   - [Forever], [ForeverExpr]: Repeat a computation until a handler short-circuits one of its operations
 //
 // Convenience:
 //
@@ -85,15 +92,80 @@
 //
 //   - [Step]: Drive a [Cont] computation until it completes or suspends
 //   - [StepExpr]: Drive an [Expr] computation until it completes or suspends
+//   - [StepN], [StepNExpr], [ResumeN]: Step, dispatching up to n operations with a handler before yielding
+//   - [RunUntil], [RunUntilExpr], [ResumeUntil]: Step, dispatching with a handler until an operation matches a predicate
+//   - [StepWith], [StepWithExpr], [ResumeWith]: Step with a partial handler, yielding only operations it declines as unhandled
+//   - [RunParallel], [RunParallelExpr]: Step independent computations concurrently under one thread-safe handler, results in input order
+//   - [ParTraverse], [ParTraverseExpr]: Map a slice to computations and run them like RunParallel
+//   - [Race], [RaceExpr]: Step two computations, completing with the first to complete and discarding the other
+//   - [Zip], [ZipExpr]: Step two computations, completing with both results as a [Pair]
+//   - [Both], [ResumeLeft], [ResumeRight]: Operation pending while both sides are suspended, and its resumptions
 //   - [Suspension]: Pending operation with one-shot resumption handle
 //   - [Suspension.Op]: Returns the effect operation that caused the suspension
 //   - [Suspension.Resume]: Advance to the next suspension or completion (panics on reuse)
 //   - [Suspension.TryResume]: Non-panicking variant of Resume
+//   - [Suspension.ResumeWithError], [ResumeThrow]: Resume by performing Throw at the suspension point, for failed external operations
+//   - [Suspension.ResumeWithin]: Resume, abandoning the computation with [ResumeTimeoutError] if it runs past a deadline
 //   - [Suspension.Discard]: Drop without invoking
+//   - [Suspension.Clone]: Copy a pending suspension so it can be resumed with several values
+//   - [Suspension.OnComplete]: Register a callback for the final value, carried across resumptions
+//   - [Suspension.Age], [Suspension.TrackAge]: Time elapsed since the suspension was created, measured once tracked or metered
+//   - [Suspension.Meter]: Report the lifetime of this and later suspensions to [Metrics]
+//   - [Suspension.Site]: File:line where the operation was performed (kontdebug builds)
 //
 // Returns (value, nil) on completion, or (zero, [*Suspension]) when pending.
 // Affine semantics: each [Suspension] may be resumed at most once.
 //
+// [Generator] turns a computation performing Yield into a pull-based sequence:
+//
+//   - [Yield], [YieldValue], [ExprYieldValue]: Emit a value to the consumer
+//   - [NewGenerator], [NewGeneratorExpr]: Create a Generator; the computation starts on the first Next
+//   - [Generator.Next], [Generator.Stop]: Pull the next value, or abandon the computation
+//   - [ToSeq], [ToSeqExpr]: Range over yielded values with range-over-func
+//   - [FromSeq]: Lift an iter.Seq into a computation that yields each value
+//
+// [RunAsync] is a deterministic single-threaded event loop for tasks:
+//
+//   - [Fork], [ForkAsync]: Start a task; resumes with its [Future]
+//   - [Await], [AwaitAsync]: Park the current task until a Future completes
+//   - [Future.Done], [Future.Get]: Inspect a task's result
+//   - [RunAsyncWith], [AsyncScheduler], [AsyncTask]: Run with a pluggable order of runnable tasks; [FIFOScheduler] is the default
+//   - [RunAsyncWithOptions], [AsyncOptions]: Run with dispatch, scheduler, panic policy and batcher
+//   - [AsyncBatcher]: Park tasks on operations it recognizes and dispatch them as one batch once no task is runnable
+//   - [PanicPolicy], [PanicCrash], [PanicStop], [PanicRestart]: Crash, stop, or restart a task or pooled computation that panics
+//   - [Queue], [NewQueue]: Bounded FIFO queue between tasks; [Queue.Len] and [Queue.Cap] report its fill
+//   - [Offer], [OfferQueue], [ExprOfferQueue]: Add a value, parking the producer while the queue is full
+//   - [Poll], [PollQueue], [ExprPollQueue]: Take the oldest value, parking the consumer while the queue is empty
+//
+// [RunScope] runs children on goroutines with errgroup semantics, returning only
+// once every child has completed or been cancelled:
+//
+//   - [Spawn], [SpawnScope], [ExprSpawnScope]: Start a child of the scope
+//   - [Join], [JoinScope], [ExprJoinScope]: Wait for the children; resumes with the first child error
+//   - [CancelAll], [CancelAllScope], [ExprCancelAllScope]: Cancel running and later children at their next operation
+//   - [SpawnScopeWithin], [ExprSpawnScopeWithin]: Start a child with a deadline no later than the inherited one
+//   - [RunScopeExpr]: Run an Expr computation as the scope
+//   - [RunScopeCtx], [RunScopeCtxExpr]: Run a scope under a context; each computation's AskCtx sees its own inherited deadline
+//   - [Group], [NewGroup]: Collect child results in spawn order, optionally cancelling the scope on the first failure
+//   - [Group.Go], [Group.ExprGo], [Group.Wait], [Group.ExprWait]: Spawn a member, or join and read every result so far
+//
+// [Pool] multiplexes many computations over a few worker goroutines, parking
+// them off the workers while an external poller completes their operations:
+//
+//   - [NewPool], [PoolOptions]: Create a pool with worker count, live bound, Park callback, dispatch, clock, and panic policy
+//   - [Pool.Submit], [Pool.SubmitExpr]: Add a computation, blocking while the live bound is reached
+//   - [Pool.TrySubmit], [Pool.TrySubmitExpr]: Add a computation without blocking; false at the bound
+//   - [Pool.Live], [Pool.Parked]: Count live and parked computations
+//   - [Pool.Stats], [PoolStats]: Snapshot live, queued and parked computations, parked operations by type, and the oldest park
+//   - [Pool.Shutdown], [ErrPoolClosed]: Stop accepting, cancel the pool's context, wait for live computations up to a deadline, then discard the rest
+//
+// [TickLoop] is a fixed-timestep driver built on the stepping boundary:
+//
+//   - [Tick], [AwaitTick], [ExprAwaitTick]: Wait for the next tick; resumes with the timestep
+//   - [PollInput]: Read the input batch coalesced since the previous tick
+//   - [NewTickLoop]: Create a loop; [TickLoop.Spawn] and [TickLoop.SpawnExpr] add computations
+//   - [TickLoop.Tick], [TickLoop.Advance], [TickLoop.Run]: Step once, per accumulated time, or in real time
+//
 // # Algebraic Effects
 //
 // Effects are defined as types implementing the F-bounded [Op] constraint,
@@ -109,6 +181,12 @@
 //   - [Perform]: Trigger an effect operation
 //   - [Handle]: Run a computation with an F-bounded effect handler
 //   - [HandleFunc]: Create a handler from a dispatch function
+//   - [Dispatcher], [NewDispatcher], [Case]: Build a handler from per-operation-type functions
+//   - [KeyedOp], [OpKey], [NewOpKey]: Operation types registered with a Dispatcher, looked up by key without reflection
+//   - [HandleForward], [HandleForwardExpr]: Handle with one handler, re-performing the operations it declines to the enclosing handler
+//   - [ForwardOp]: Decline an operation from Dispatch so it is forwarded
+//   - [ControlHandler], [HandleControl], [HandleControlExpr]: Handler receiving each pending [Suspension] to resume, save, clone or discard
+//   - [ControlFunc]: Create a ControlHandler from a function
 //
 // # Standard Effects
 //
@@ -118,16 +196,35 @@
 //
 // State effect for mutable state threading:
 //
-//   - [Get], [Put], [Modify]: Effect operations
+//   - [Get], [Put], [Modify], [Gets]: Effect operations
 //   - [GetState], [PutState], [ModifyState]: Fused convenience constructors (Cont)
+//   - [GetsState], [ExprGetsState]: Project the state at dispatch time (Cont, Expr)
+//   - [WithState], [ExprWithState]: Run a body with a local State cell, returning its result and final sub-state
+//   - [ZoomState], [ExprZoomState]: Run a State[Inner] body inside a State[Outer] handler through a get/set lens
 //   - [StateHandler]: Creates a State handler (returns *stateHandler and state getter)
+//   - [ConcurrentStateHandler]: Mutex-guarded State handler shared by computations stepped on several goroutines
 //   - [RunState], [EvalState], [ExecState]: Run with State effect (Cont)
 //   - [RunStateExpr]: Run with State effect (Expr)
+//   - [RunStateDiff], [RunStateDiffExpr]: Run and also return a user-computed diff of initial and final state
+//   - [EventSourcedStateHandler]: State handler journaling the state produced by every state-changing operation, zoomed ones included, with snapshot + replay
+//   - [StateJournal], [JournalEntry], [MemoryJournal]: Journal interface, entry holding the resulting state, and in-memory journal
+//   - [RunEventSourced], [RunEventSourcedExpr]: Run with journaled State effect
+//
+// Labeled State effect with several cells under one handler:
+//
+//   - [GetAt], [PutAt], [ModifyAt]: Effect operations on the cell at a key
+//   - [GetStateAt], [PutStateAt], [ModifyStateAt]: Constructors (Cont)
+//   - [ExprGetStateAt], [ExprPutStateAt], [ExprModifyStateAt]: Constructors (Expr)
+//   - [StateCells]: Cells of a labeled State handler
+//   - [StateMapHandler]: Creates a labeled State handler seeded from ordered pairs (returns *stateMapHandler and cells getter); Keys lists seed keys first, then keys in write order
+//   - [RunStateMap], [RunStateMapExpr]: Run with labeled State effects
 //
 // Reader effect for read-only environment:
 //
-//   - [Ask]: Effect operation
+//   - [Ask], [Asks], [Local]: Effect operations
 //   - [AskReader], [MapReader]: Fused convenience constructors (Cont)
+//   - [AsksReader], [ExprAsksReader]: Project the environment at dispatch time (Cont, Expr)
+//   - [LocalReader], [ExprLocalReader]: Run a body with a modified environment (Cont, Expr)
 //   - [ReaderHandler]: Creates a Reader handler (returns *readerHandler)
 //   - [RunReader]: Run with Reader effect (Cont)
 //   - [RunReaderExpr]: Run with Reader effect (Expr)
@@ -141,18 +238,152 @@
 //   - [WriterHandler]: Creates a Writer handler (returns *writerHandler and output getter)
 //   - [RunWriter], [ExecWriter]: Run with Writer effect (Cont)
 //   - [RunWriterExpr]: Run with Writer effect (Expr)
+//   - [WriterMonoidHandler]: Creates a Writer handler folding output with a monoid (returns *writerMonoidHandler and value getter)
+//   - [RunWriterMonoid], [RunWriterMonoidExpr]: Run with monoid-accumulating Writer effect
+//   - [StreamWriterHandler]: Creates a Writer handler streaming each output value to a sink func
+//   - [RunWriterStream], [RunWriterStreamExpr]: Run with streamed Writer output
 //   - [Pair]: Tuple type for Listen results
 //
+// Structured logging effect forwarded to log/slog as each record is
+// dispatched; unlike Tell, records are never captured by Listen:
+//
+//   - [Log]: Effect operation carrying a level, message and attributes
+//   - [LogMessage], [ExprLogMessage]: Constructors
+//   - [WithLogAttrs], [ExprWithLogAttrs]: Add attributes to every Log in a scope (e.g. a request id from Reader)
+//   - [SlogHandler]: Creates a Log handler over a *slog.Logger (returns *slogHandler)
+//   - [RunSlog], [RunSlogExpr]: Run with the Log effect
+//
+// Bounded-memory Writer output:
+//
+//   - [SpillWriter]: Keeps the last N entries in memory, spills older ones to a temp file
+//   - [NewSpillWriter]: Creates a SpillWriter with a memory limit and spill directory
+//   - [SpillWriterHandler]: Creates a Writer handler appending to a SpillWriter
+//   - [RunWriterSpill], [RunWriterSpillExpr]: Run with spilling Writer output
+//   - [RecordSink], [SinkOptions]: Structured record sink with periodic flush and rotation hooks
+//   - [NewJSONLinesSink], [NewCSVSink]: Create JSON-lines and CSV sinks over an io.Writer
+//   - [SinkWriterHandler]: Creates a Writer handler encoding output to a RecordSink
+//   - [RunWriterSink], [RunWriterSinkExpr]: Run with Writer output encoded to a sink
+//   - [TellBlocking]: Backpressured output op; [TellBlocking].TryDeliver lets Step drivers park until the sink drains
+//   - [TellBlockingWriter], [ExprTellBlocking]: Convenience constructors (Cont, Expr)
+//   - [BlockingTellHandler]: Creates a handler delivering to a bounded channel, blocking while saturated
+//   - [RunTellBlocking], [RunTellBlockingExpr]: Run with backpressured output
+//
 // Error effect for exception-like control flow:
 //
 //   - [Throw], [Catch]: Effect operations
 //   - [ErrorContext]: Shared context for error dispatch
 //   - [Throw].DispatchError: sets error in context, returns (struct{}{}, true)
 //   - [Catch].DispatchError: runs body with RunError internally
-//   - [ThrowError], [CatchError]: Convenience constructors (Cont)
+//   - [CatchAll]: Deep Catch; non-error effects in body and handler reach the enclosing composed handler
+//   - [CatchAll].DispatchErrorDeep: handles errors locally, forwards other ops to the enclosing handler
+//   - [ThrowError], [CatchError], [CatchAllError]: Convenience constructors (Cont)
+//   - [ThrowErr], [ExprThrowErr], [CatchErr]: Error effect with a Go error payload
+//   - [CatchErrAs]: Catch only errors matching a type under errors.As, rethrowing the rest
+//   - [RunErr], [RunErrExpr], [EitherErr]: Return (value, error) with the thrown error chain intact
+//   - [MapError], [ExprMapError]: Rewrite Throw payloads raised by a computation into another error type
+//   - [Recover], [ExprRecover]: Convert panics raised by a computation into Throw
+//   - [RecoverHandler]: Short-circuit on panics raised inside a handler's Dispatch
 //   - [ExprThrowError]: Throw constructor for Expr via direct EffectFrame, not composable from ExprPerform
 //   - [RunError]: Run with Error effect (Cont), returns [Either]
 //   - [RunErrorExpr]: Run with Error effect (Expr), returns [Either]
+//   - [ParTraverseValidated], [ParTraverseValidatedExpr]: Run Error branches concurrently, collecting all failures
+//
+// Error taxonomy shared by the (value, error) APIs:
+//
+//   - [ErrorKind], [Kind]: Classify an error as cancelled, deadline exceeded, unhandled, budget exceeded, suspension reused, or handler failure
+//   - [PanicError]: Convert a recovered runtime or handler panic into a classifiable error
+//   - [ErrUnhandled], [UnhandledError]: Sentinel and typed unhandled-effect error; handlers panic with [*UnhandledError] carrying the operation
+//   - [ErrSuspensionReused]: Sentinel for resuming a consumed suspension
+//   - [ErrHandlerFailure], [HandlerFailureError]: Sentinel and typed handler panic
+//
+// Feature flag effect for conditional behavior:
+//
+//   - [FlagEnabled]: Effect operation
+//   - [CheckFlag]: Fused convenience constructor (Cont)
+//   - [FlagProvider]: Provider interface
+//   - [StaticFlags], [EnvFlags], [DynamicFlags]: Map, environment, and runtime-mutable providers
+//   - [StaticFlags.Names], [DynamicFlags.Snapshot]: Flags in sorted order for golden tests and replay
+//   - [FlagHandler]: Creates a flag handler (returns *flagHandler)
+//   - [RunFlags], [RunFlagsExpr]: Run with feature flags
+//
+// Access-control effect for row/field-level authorization:
+//
+//   - [Authorize]: Effect operation answered by a [Policy] with a [Verdict] ([VerdictAllow], [VerdictDeny], [VerdictFilter])
+//   - [CheckAccess], [ExprCheckAccess]: Authorize, throwing [*ForbiddenError] as Error[error] unless allowed or filtered
+//   - [ErrForbidden], [ForbiddenError]: Sentinel and typed denial error
+//   - [AuthorizationHandler]: Creates an Authorize handler (returns *authorizationHandler)
+//   - [RunAuthorized], [RunAuthorizedExpr]: Run with Authorize + Error[error], returns [Either]
+//
+// Cache effect with expiry and stampede protection:
+//
+//   - [CacheGet], [CachePut], [CacheAbandon]: Effect operations
+//   - [Cached], [ExprCached]: Read-through caching of a computation; a compute that throws abandons its fill
+//   - [LRUCache.Abandon]: Wake misses waiting on an in-flight fill without storing a value
+//   - [LRUCache], [NewLRUCache], [CacheOptions]: Concurrent in-memory LRU backing store
+//   - [LRUCache.Keys]: Keys in recency order, determined by the sequence of Get and Put calls
+//   - [CacheHandler]: Creates a Cache handler (returns *cacheHandler)
+//   - [RunCache], [RunCacheExpr]: Run with Cache effect
+//   - [VirtualClock]: Manually advanced clock for deterministic expiry tests
+//
+// Clock effect for time-dependent logic:
+//
+//   - [Now], [Sleep], [After]: Effect operations; After resumes with a timer channel
+//   - [Every], [Ticker]: Periodic timer; resumes with a Ticker whose channel a ChanLoop task can receive from
+//   - [ClockNow], [ClockSleep], [ClockAfter], [ClockEvery]: Constructors (Cont)
+//   - [ExprClockNow], [ExprClockSleep], [ExprClockAfter], [ExprClockEvery]: Constructors (Expr)
+//   - [ClockHandler]: Real-time handler backed by the time package
+//   - [VirtualClockHandler]: Handler on a [VirtualClock]; Sleep and Advance move virtual time and fire timers and tickers
+//   - [VirtualClock.NewTicker]: Ticker firing as virtual time advances
+//   - [RunClock], [RunClockExpr], [RunVirtualClock], [RunVirtualClockExpr]: Run with the Clock effect
+//
+// Random effect with seedable handlers:
+//
+//   - [RandInt], [RandFloat], [Shuffle]: Effect operations
+//   - [RandomInt], [RandomFloat], [ShuffleItems]: Constructors (Cont)
+//   - [ExprRandomInt], [ExprRandomFloat], [ExprShuffleItems]: Constructors (Expr)
+//   - [RandHandler], [SeededRandHandler]: Create a handler over math/rand/v2 (returns *randHandler and a draw-count accessor)
+//   - [RunRand], [RunRandExpr], [RunSeededRand], [RunSeededRandExpr]: Run with fresh entropy or a fixed seed
+//
+// Fresh effect for gensym-style identifiers:
+//
+//   - [Fresh], [FreshNamed]: Effect operations
+//   - [FreshID], [FreshName]: Constructors (Cont)
+//   - [ExprFreshID], [ExprFreshName]: Constructors (Expr)
+//   - [FreshSupply]: Monotonic counter with a pluggable suffix generator (e.g. UUIDs)
+//   - [FreshHandler]: Create a handler drawing from a supply (returns *freshHandler)
+//   - [RunFresh], [RunFreshExpr]: Run with the Fresh effect, returning the next unused identifier
+//   - The composed runners ([RunStateReader], [RunStateError], ...) also answer Fresh
+//
+// Non-determinism effect with list semantics:
+//
+//   - [Choose], [Fail]: Effect operations
+//   - [Amb], [FailBranch]: Constructors (Cont); Amb captures a multi-shot continuation
+//   - [ExprAmb], [ExprFailBranch]: Constructors (Expr)
+//   - [RunNonDet], [RunNonDetExpr]: Resume once per option and collect every completed branch
+//
+// Session-typed protocols between two peers:
+//
+//   - [ProtoSend], [ProtoRecv], [ProtoEnd]: Protocol steps; [ProtoSession] is the token for the next step
+//   - [SendMsg], [RecvMsg]: Perform the next step, returning the token for the rest
+//   - [SessionSend], [SessionRecv]: Effect operations
+//   - [RunSession]: Run client and server as peers, checking message types as they flow
+//
+// Channel effect for computations that block on Go channels:
+//
+//   - [ChanRecv], [ChanSend]: Effect operations; ChanRecv resumes with None once the channel is closed
+//   - [RecvChan], [SendChan]: Constructors (Cont)
+//   - [ExprRecvChan], [ExprSendChan]: Constructors (Expr)
+//   - [ChanLoop]: Parks computations on their channels and resumes them as channels become ready
+//
+// Connection effect for message connections, failing with [ConnError]:
+//
+//   - [ConnOpen], [ConnSend], [ConnRecv], [ConnClose]: Effect operations; [ConnID] names a connection
+//   - [OpenConn], [SendConn], [RecvConn], [CloseConn]: Constructors (Cont)
+//   - [ExprOpenConn], [ExprSendConn], [ExprRecvConn], [ExprCloseConn]: Constructors (Expr)
+//   - [ConnHandler]: Length-framed net.Conn handler that redials per [ConnOptions]
+//   - [ErrConnClosed], [ErrFrameTooLarge]: Sentinel failures; [ConnOptions.MaxFrame] bounds received messages
+//   - [LoopbackConnHandler]: In-memory handler for tests
+//   - [RunConn], [RunConnExpr], [RunLoopbackConn], [RunLoopbackConnExpr]: Run returning (value, error)
 //
 // # Composed Effects
 //
@@ -171,6 +402,12 @@
 //   - [ExecStateError]: Returns only the final state
 //   - [RunStateErrorExpr]: Run with State + Error (Expr)
 //
+// Transactional State + Error (state rolled back to its starting snapshot on error):
+//
+//   - [Rollback]: Effect operation restoring the starting snapshot mid-computation
+//   - [RollbackState], [ExprRollbackState]: Constructors
+//   - [RunStateTx], [RunStateTxExpr]: Run with State + Error + Rollback, taking a snapshot copy func
+//
 // State + Writer:
 //
 //   - [RunStateWriter]: Run with State + Writer (Cont), returns (A, S, []W)
@@ -181,6 +418,66 @@
 //   - [RunReaderStateError]: Run with Reader + State + Error (Cont), returns ([Either], S)
 //   - [RunReaderStateErrorExpr]: Run with Reader + State + Error (Expr)
 //
+// Any combination of State, Reader, Writer and Error in one flat handler:
+//
+//   - [HandlerStack], [NewStack]: Handler trying its layers in the order they were added
+//   - [StackState], [StackReader], [StackWriter], [StackError]: Add a layer; each returns a typed slot read after the run
+//   - [StateSlot], [WriterSlot], [ErrorSlot]: Final state, accumulated output, and the error that ended the run
+//   - [HandlerStack.Run], [HandlerStack.RunExpr]: Run with every layer
+//
+// State + Error + Outbox (events committed with the final state, dropped on error):
+//
+//   - [EmitEvent]: Effect operation
+//   - [EmitOutbox]: Fused convenience constructor (Cont)
+//   - [OutboxHandler]: Creates an Outbox handler (returns *outboxHandler and pending getter)
+//   - [RunStateOutbox]: Run with State + Error + Outbox (Cont), returns ([Either], S)
+//   - [RunStateOutboxExpr]: Run with State + Error + Outbox (Expr)
+//
+// # Handler Wrappers
+//
+// Wrappers take any F-bounded handler and return a concrete handler that
+// delegates to it, so they work with [Handle], [HandleExpr], and [Step] drivers:
+//
+//   - [HashingHandler]: Fold each dispatch's operation type and binary-encoded resume value into a determinism digest
+//   - [RunHashed], [RunHashedExpr]: Run and return the result with its digest
+//   - [RetryOps]: Retry dispatches that fail with an error panic, per [RetryPolicy]
+//   - [TimeoutOps]: Control handler bounding each dispatch with a timer; an overrun is abandoned and resumed as Throw of [OpTimeoutError]
+//   - [HedgeOps]: Issue a delayed second dispatch for idempotent ops; first result wins
+//   - [SwappableHandler]: Atomically replace the handler between dispatches
+//
+// Middleware chains fix the order of dispatch decorators:
+//
+//   - [HandlerMiddleware]: Named [DispatchFunc] decorator assigned to a [MiddlewareStage]
+//   - [Chain]: Assemble middleware, outermost first; panics if stages are out of order
+//   - [HandlerChain.Names]: Inspect the assembled order
+//   - [ApplyChain]: Wrap a handler with a chain
+//   - [WrapHandler]: Call before and after hooks around every dispatch of a handler
+//
+// Fallback chains compose independent handlers without a hand-written switch:
+//
+//   - [ChainHandlers]: Try each [DispatchFunc] in order, falling through on [*UnhandledError] panics
+//
+// Tracing records the effect stream of a run for debugging and test diffs:
+//
+//   - [TraceEvent]: One dispatch: operation, resume value, and whether it short-circuited
+//   - [Trace], [TraceExpr]: Run with a handler and return every dispatch in order
+//   - [TracingHandler]: Wrap a handler to append its dispatches to a slice
+//
+// Metrics bind dispatch measurements to a backend such as Prometheus or expvar:
+//
+//   - [Metrics]: Receives per-operation dispatch latency, per-run dispatch counts, and suspension lifetimes
+//   - [HandleMetered], [HandleExprMetered]: Run reporting to [Metrics]; other runners pay nothing
+//
+// The otelkont module opens an OpenTelemetry span per dispatch, named after
+// the operation type, through its Wrap handler or Observe-stage Middleware.
+//
+// Record and replay answer a rerun from a recorded effect stream:
+//
+//   - [Script]: Recorded [TraceEvent] sequence
+//   - [Record], [RecordExpr]: Run with a handler and return the result with its script
+//   - [Replay], [ReplayExpr]: Rerun answering every operation from a script, matched by type
+//   - [ErrReplayDiverged], [ReplayDivergedError]: Sentinel and typed error for a rerun that departs from its script
+//
 // # Either Type
 //
 // [Either] represents success (Right) or failure (Left):
@@ -193,12 +490,81 @@
 //   - [FlatMapEither]: Monadic bind
 //   - [MapLeftEither]: Transform Left value
 //
+// [Validated] represents success (Valid) or one or more errors (Invalid).
+// Combining Validated values accumulates errors instead of short-circuiting:
+//
+//   - [Valid], [Invalid]: Constructors
+//   - [Validated.IsValid], [Validated.Value], [Validated.Errors]: Accessors
+//   - [Validated.Either]: Convert to [Either] with all errors on the Left
+//   - [MapValidated]: Functor map over Valid
+//   - [ZipValidated]: Pair two values, concatenating errors
+//   - [RunValidation], [RunValidationExpr]: Run with Error effect, returns [Validated]
+//   - [TraverseValidated], [TraverseValidatedExpr]: Run independent Error computations, collecting all failures
+//
+// [Option] represents a present (Some) or absent (None) value:
+//
+//   - [Some], [None]: Constructors
+//   - [Option.IsSome], [Option.IsNone], [Option.Get], [Option.GetOrElse]: Accessors
+//   - [MatchOption]: Pattern matching
+//   - [MapOption], [FlatMapOption]: Functor map and monadic bind
+//   - [FailNone]: Maybe effect operation; aborts with None
+//   - [FailOption], [FromOption], [ExprFailOption], [ExprFromOption]: Constructors
+//   - [RunOption], [RunOptionExpr]: Run with the Maybe effect, returns [Option]
+//
+// Conversions from other functional libraries, without depending on them:
+//
+//   - [OptionOf]: Option from a (value, ok) pair, such as a foreign Option's Get
+//   - [EitherOf]: Either[error, A] from a (value, error) pair
+//   - [EitherSource], [FromEitherSource]: Either from a type with comma-ok Left and Right accessors (e.g. samber/mo)
+//   - Outbound, pass foreign constructors to [MatchEither] and [MatchOption]
+//
 // # Resource Safety
 //
 // Exception-safe resource management:
 //
 //   - [Bracket]: Acquire-release-use with guaranteed cleanup; interprets only Error[E] inside use
+//   - [BracketTimeout]: Bracket with a release bounded by a Clock-effect deadline; reports [ErrReleaseTimeout] separately
 //   - [OnError]: Run cleanup only on error
+//   - [BracketErr]: Bracket over Go errors that rethrows after release, so errors unwind through nested regions
+//
+// Cooperative cancellation is raised at check points as a thrown Go error and
+// unwinds through [BracketErr] regions, running each release:
+//
+//   - [CheckCancel], [ExprCheckCancel]: Throw [*CancelledError] if the context is done when the check point is reached
+//   - [ErrCancelled]: Sentinel matched by errors.Is for cancellation
+//
+// The Context effect reads a context.Context from the runner, which aborts
+// with a [*CancelledError] once it is done:
+//
+//   - [AskCtx], [CheckCancelled]: Effect operations
+//   - [GetCtx], [CheckCtx], [WithTimeout]: Constructors (Cont); WithTimeout scopes a derived context
+//   - [ExprGetCtx], [ExprCheckCtx], [ExprWithTimeout]: Constructors (Expr)
+//   - [HandleCtx], [HandleCtxExpr]: Handle, polling the context before every dispatch
+//   - [StepCtx], [StepCtxExpr], [ResumeCtx]: Step, answering the Context effect
+//
+// # Idempotency
+//
+// At-least-once execution with recorded results:
+//
+//   - [IdempotencyStore]: Store interface for completed results
+//   - [IdempotentLookup], [IdempotentCommit]: Effect operations
+//   - [Idempotent]: Skip body when its key has a recorded result (Cont)
+//   - [ExprIdempotent]: Skip body when its key has a recorded result (Expr)
+//   - [IdempotencyHandler]: Creates an Idempotency handler (returns *idempotencyHandler)
+//   - [RunIdempotent], [RunIdempotentExpr]: Run with Idempotency effect
+//   - [NewMemoryIdempotencyStore]: In-memory store safe for concurrent use; Keys lists keys in first-stored order
+//
+// # Two-Phase Commit
+//
+// A coordinator over the stepping boundary; participants stay suspended on
+// their vote until every participant has voted:
+//
+//   - [Prepare]: Effect operation carrying a participant's vote
+//   - [Commit], [Abort]: Phase-two operations dispatched for each prepared participant
+//   - [Decision], [TwoPhaseCommit], [TwoPhaseAbort]: Coordinator outcome resumed into participants
+//   - [PrepareCommit]: Fused convenience constructor (Cont)
+//   - [RunTwoPhaseCommit]: Drive participants through both phases under a handler
+//   - [RunTwoPhaseCommitWithin]: Abort when phase one outlasts a timeout, including a participant hung in a dispatch
 //
 // # Affine Continuations
 //
@@ -254,7 +620,16 @@
 //   - [ExprSuspend]: Create suspended computation
 //   - [ChainFrames]: Compose frame chains
 //   - [RunPure]: Iteratively evaluate pure computation (panics on effects)
+//   - [RunPureOr]: Evaluate an almost-pure computation, passing effects to a fallback function
 //   - [HandleExpr]: Evaluate with F-bounded effect handler
+//   - [HandleExprShared]: Evaluate without mutating or releasing caller frames (repeatable, concurrent)
+//   - [HandleExprHooked]: Evaluate with [EvalHooks] called before and after each frame, by [FrameKind]
+//
+// Bounded construction for untrusted program builders:
+//
+//   - [FrameBudget], [WithMaxFrames]: Frame-node budget (nil is unlimited)
+//   - [TryExprBind], [TryExprMap], [TryExprThen], [TryChainFrames]: Constructors charged against a budget
+//   - [FrameLimitError]: Returned when a budget is exhausted; matches [ErrFrameLimit]
 //
 // # Frame Pools
 //
@@ -262,13 +637,37 @@
 // Expr construction. The evaluator releases pooled frames after consumption.
 // Pooled frames assume affine (at-most-once) evaluation; the evaluator zeroes
 // all fields on release. Do not use pooled frames in Expr values that may be
-// evaluated more than once.
+// evaluated more than once, except through [HandleExprShared].
 //
 //   - [AcquireEffectFrame]: Acquire pooled [EffectFrame]
 //   - [AcquireBindFrame]: Acquire pooled [BindFrame]
 //   - [AcquireThenFrame]: Acquire pooled [ThenFrame]
 //   - [AcquireUnwindFrame]: Acquire pooled [UnwindFrame]
 //
+// # Testing
+//
+// Building with the kontdebug tag records the file:line where each effect
+// operation is performed. Unhandled-effect panics, which always name the
+// operation type, then also carry its site in [UnhandledError.Site], and
+// [Suspension.Site] reports it while stepping. The tag also tracks every
+// suspension from [Step], [StepExpr], and [Suspension.Clone] with its
+// creation stack; [CheckLeaks] lists those neither resumed nor discarded
+// as [LeakedSuspension] values, for finding lost continuations in event
+// loops. Without the tag the records are zero-size and cost nothing.
+//
+// Package konttest provides AssertZeroAllocs, AssertAllocs, and benchmark
+// wrappers that fail when a handler loop exceeds its allocation budget.
+// Its Coverage and Cover record which operation types a handler dispatched,
+// and how, so a test can fail when a declared operation was never performed.
+// Its MockHandler answers expected operations in order with canned
+// responses, as in m.Expect(kont.Get[int]{}).Return(5), and fails the test
+// on unexpected or unperformed operations.
+//
+// The kontlint module provides a go/analysis analyzer that flags discarded
+// suspensions, suspensions resumed twice in a loop, [Run] or [RunPure] on
+// computations that perform effects, and closures handed to kont that
+// capture variables a loop reassigns.
+//
 // # Example
 //
 //	type Ask[A any] struct{ kont.Phantom[A] }
@@ -285,8 +684,11 @@
 //		case Ask[int]:
 //			return 21, true // resume with 21
 //		default:
-//			panic("kont: unhandled effect")
+//			panic(&kont.UnhandledError{Handler: "HandleFunc", Op: op})
 //		}
 //	}))
 //	// result == 42
+//
+// Package examples/interp is a complete interpreter for a small expression
+// language built on Reader, State, Error, and NonDet.
 package kont
@@ -4,12 +4,12 @@
 
 package kont
 
-// unhandledEffect panics with a descriptive message for unmatched operations.
+// unhandledEffect panics with an [UnhandledError] for unmatched operations.
 // Extracted as a noinline function so that Dispatch methods remain inlineable.
 //
 //go:noinline
-func unhandledEffect(handler string) {
-	panic("kont: unhandled effect in " + handler)
+func unhandledEffect(handler string, op Operation) {
+	panic(&UnhandledError{Handler: handler, Op: op})
 }
 
 // Operation is the interface for effect operations in handler dispatch.
@@ -80,7 +80,7 @@ func (h *handlerFunc[R]) Dispatch(op Operation) (Resumed, bool) {
 //	        fmt.Println(e.Value)
 //	        return struct{}{}, true
 //	    default:
-//	        panic("kont: unhandled effect")
+//	        panic(&UnhandledError{Handler: "HandleFunc", Op: op})
 //	    }
 //	})
 func HandleFunc[R any](f func(op Operation) (Resumed, bool)) *handlerFunc[R] {
@@ -93,7 +93,11 @@ func HandleFunc[R any](f func(op Operation) (Resumed, bool)) *handlerFunc[R] {
 type effectSuspension interface {
 	Op() Operation
 	Resume(Resumed) Resumed
+	// resumeWithError resumes by performing throw at the innermost
+	// suspension point in place of the pending operation.
+	resumeWithError(throw Operation) Resumed
 	release()
+	site() provenance
 }
 
 // effectMarkerResume resumes an effect operation from a genericMarker.
@@ -109,11 +113,13 @@ func effectMarkerResume[A any](m *genericMarker, v Resumed) Resumed {
 // a resume value, or short-circuits with a final result.
 func Perform[O Op[O, A], A any](op O) Cont[Resumed, A] {
 	resume := effectMarkerResume[A]
+	at := captureProvenance()
 	return func(k func(A) Resumed) Resumed {
 		m := acquireMarker()
 		m.op = op
 		m.k = k
 		m.resume = resume
+		m.at = at
 		return m
 	}
 }
@@ -163,6 +169,7 @@ func ExprPerform[O Op[O, A], A any](op O) Expr[A] {
 			Operation: op,
 			Resume:    identityResume,
 			Next:      ReturnFrame{},
+			at:        captureProvenance(),
 		},
 	}
 }
@@ -177,7 +184,7 @@ func ExprPerform[O Op[O, A], A any](op O) Expr[A] {
 //	    case Ask[int]:
 //	        return 42, true
 //	    default:
-//	        panic("kont: unhandled effect")
+//	        panic(&UnhandledError{Handler: "HandleFunc", Op: op})
 //	    }
 //	}))
 func Handle[H Handler[H, R], R any](m Cont[Resumed, R], h H) R {
@@ -192,7 +199,7 @@ func Handle[H Handler[H, R], R any](m Cont[Resumed, R], h H) R {
 func handleDispatch[H Handler[H, R], R any](result Resumed, h H) R {
 	for {
 		if s, ok := result.(effectSuspension); ok {
-			v, shouldResume := h.Dispatch(s.Op())
+			v, shouldResume := dispatchAt[H, R](h, s.Op(), s.site())
 			if !shouldResume {
 				s.release()
 				return valueOrZero[R](v)
@@ -0,0 +1,165 @@
+// ©Hayabusa Cloud Co., Ltd. 2026. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package kont
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// Error taxonomy.
+// Kind sorts the errors this package reports into a few kinds, so an
+// integration maps them to its own status codes in one place. Runtime
+// faults raised as panics become classifiable errors through PanicError.
+
+// ErrorKind classifies an error reported by this package.
+type ErrorKind uint8
+
+const (
+	// KindUnknown is any error not produced by this package.
+	KindUnknown ErrorKind = iota
+	// KindCancelled is a computation cancelled through its context.
+	KindCancelled
+	// KindDeadlineExceeded is a context deadline, an operation timeout, or a
+	// resume timeout.
+	KindDeadlineExceeded
+	// KindUnhandled is an operation no handler dispatched.
+	KindUnhandled
+	// KindBudgetExceeded is an exhausted construction budget.
+	KindBudgetExceeded
+	// KindSuspensionReused is a suspension or continuation resumed after use.
+	KindSuspensionReused
+	// KindHandlerFailure is a handler that failed to serve an operation.
+	KindHandlerFailure
+)
+
+var errorKindNames = [...]string{
+	KindUnknown:          "Unknown",
+	KindCancelled:        "Cancelled",
+	KindDeadlineExceeded: "DeadlineExceeded",
+	KindUnhandled:        "Unhandled",
+	KindBudgetExceeded:   "BudgetExceeded",
+	KindSuspensionReused: "SuspensionReused",
+	KindHandlerFailure:   "HandlerFailure",
+}
+
+func (k ErrorKind) String() string {
+	if int(k) < len(errorKindNames) {
+		return errorKindNames[k]
+	}
+	return fmt.Sprintf("ErrorKind(%d)", k)
+}
+
+// ErrUnhandled is the sentinel matched by errors.Is for an unhandled effect
+// reported by [PanicError].
+var ErrUnhandled = errors.New("kont: unhandled effect")
+
+// ErrSuspensionReused is the sentinel matched by errors.Is for a suspension
+// or affine continuation resumed after use, as reported by [PanicError].
+var ErrSuspensionReused = errors.New("kont: suspension reused")
+
+// ErrHandlerFailure is the sentinel matched by errors.Is for errors
+// reporting that a handler failed to serve an operation.
+var ErrHandlerFailure = errors.New("kont: handler failure")
+
+// UnhandledError reports an operation no handler dispatched. Handlers in
+// this package panic with it, so a recovered value can be inspected with
+// errors.As. It matches [ErrUnhandled] under errors.Is.
+type UnhandledError struct {
+	// Handler names the handler or runner that rejected the operation.
+	Handler string
+	// Op is the rejected operation.
+	Op Operation
+	// Site is the file:line where Op was performed. It is set only in
+	// builds with the kontdebug tag.
+	Site string
+}
+
+func (e *UnhandledError) Error() string {
+	msg := "kont: unhandled effect in " + e.Handler
+	switch {
+	case e.Op != nil && e.Site != "":
+		return fmt.Sprintf("%s (%T performed at %s)", msg, e.Op, e.Site)
+	case e.Op != nil:
+		return fmt.Sprintf("%s (%T)", msg, e.Op)
+	}
+	return msg
+}
+
+// Unwrap returns ErrUnhandled.
+func (e *UnhandledError) Unwrap() error { return ErrUnhandled }
+
+// HandlerFailureError reports a panic raised while a computation or its
+// handler ran. It matches [ErrHandlerFailure] under errors.Is, and Unwrap
+// returns the panic value when it is an error.
+type HandlerFailureError struct {
+	Value any
+}
+
+func (e *HandlerFailureError) Error() string {
+	return fmt.Sprintf("kont: handler failure: %v", e.Value)
+}
+
+// Is reports whether target is ErrHandlerFailure.
+func (e *HandlerFailureError) Is(target error) bool { return target == ErrHandlerFailure }
+
+// Unwrap returns the panic value if it is an error, or nil.
+func (e *HandlerFailureError) Unwrap() error {
+	err, _ := e.Value.(error)
+	return err
+}
+
+// Kind classifies err. The most specific kind in err's chain wins: a
+// handler failure caused by a context deadline is KindDeadlineExceeded.
+// A nil error is KindUnknown.
+func Kind(err error) ErrorKind {
+	switch {
+	case err == nil:
+		return KindUnknown
+	case errors.Is(err, ErrOpTimeout), errors.Is(err, ErrResumeTimeout), errors.Is(err, context.DeadlineExceeded):
+		return KindDeadlineExceeded
+	case errors.Is(err, ErrCancelled), errors.Is(err, context.Canceled):
+		return KindCancelled
+	case errors.Is(err, ErrFrameLimit):
+		return KindBudgetExceeded
+	case errors.Is(err, ErrUnhandled):
+		return KindUnhandled
+	case errors.Is(err, ErrSuspensionReused):
+		return KindSuspensionReused
+	case errors.Is(err, ErrHandlerFailure):
+		return KindHandlerFailure
+	}
+	return KindUnknown
+}
+
+// PanicError converts a value recovered from a panic raised by this
+// package or a handler into an error Kind can classify: an
+// [*UnhandledError] is returned as is, reuse of a consumed suspension
+// becomes [ErrSuspensionReused], and anything else, including a string
+// panic from a custom handler, becomes [*HandlerFailureError].
+// PanicError(nil) returns nil.
+func PanicError(r any) error {
+	if r == nil {
+		return nil
+	}
+	if err, ok := r.(*UnhandledError); ok {
+		return err
+	}
+	if s, ok := r.(string); ok {
+		switch s {
+		case "kont: suspension resumed twice", "kont: affine continuation resumed twice", "kont: clone of consumed suspension":
+			return ErrSuspensionReused
+		}
+	}
+	return &HandlerFailureError{Value: r}
+}
+
+// isUnhandledPanic reports whether r, recovered from a dispatch, is an
+// [*UnhandledError] signalling an unhandled operation.
+func isUnhandledPanic(r any) bool {
+	_, ok := r.(*UnhandledError)
+	return ok
+}
@@ -0,0 +1,136 @@
+// ©Hayabusa Cloud Co., Ltd. 2026. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package kont_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+
+	"code.hybscloud.com/kont"
+)
+
+func TestKind(t *testing.T) {
+	tests := []struct {
+		err  error
+		want kont.ErrorKind
+	}{
+		{nil, kont.KindUnknown},
+		{io.EOF, kont.KindUnknown},
+		{&kont.CancelledError{Cause: context.Canceled}, kont.KindCancelled},
+		{&kont.CancelledError{Cause: context.DeadlineExceeded}, kont.KindDeadlineExceeded},
+		{&kont.OpTimeoutError{}, kont.KindDeadlineExceeded},
+		{&kont.ResumeTimeoutError{}, kont.KindDeadlineExceeded},
+		{&kont.FrameLimitError{Limit: 1}, kont.KindBudgetExceeded},
+		{&kont.UnhandledError{Handler: "X"}, kont.KindUnhandled},
+		{kont.ErrSuspensionReused, kont.KindSuspensionReused},
+		{&kont.ConnError{Op: kont.ConnOpen{}, Err: io.EOF}, kont.KindHandlerFailure},
+		{&kont.ConnError{Op: kont.ConnOpen{}, Err: context.DeadlineExceeded}, kont.KindDeadlineExceeded},
+		{fmt.Errorf("wrapped: %w", &kont.HandlerFailureError{Value: "boom"}), kont.KindHandlerFailure},
+	}
+	for _, tt := range tests {
+		if got := kont.Kind(tt.err); got != tt.want {
+			t.Errorf("Kind(%v) = %v, want %v", tt.err, got, tt.want)
+		}
+	}
+	if s := kont.ErrorKind(99).String(); s != "ErrorKind(99)" {
+		t.Fatalf("got %q", s)
+	}
+}
+
+func TestKindOfRunnerErrors(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := kont.HandleCtx(ctx, kont.CheckCtx(), kont.ReaderHandler[int, struct{}](0))
+	if k := kont.Kind(err); k != kont.KindCancelled {
+		t.Fatalf("HandleCtx: %v", k)
+	}
+	_, err = kont.RunLoopbackConn(nil, kont.RecvConn(1))
+	if k := kont.Kind(err); k != kont.KindHandlerFailure {
+		t.Fatalf("RunLoopbackConn: %v", k)
+	}
+}
+
+// unhandledIn returns the handler named by an unhandled-effect panic value,
+// or "" if r is not one.
+func unhandledIn(r any) string {
+	if ue, ok := r.(*kont.UnhandledError); ok {
+		return ue.Handler
+	}
+	return ""
+}
+
+func recoverError(f func()) (err error) {
+	defer func() { err = kont.PanicError(recover()) }()
+	f()
+	return nil
+}
+
+func TestPanicError(t *testing.T) {
+	err := recoverError(func() { kont.RunState[int, int](0, kont.Perform(kont.Ask[int]{})) })
+	var ue *kont.UnhandledError
+	if !errors.As(err, &ue) || ue.Handler != "StateHandler" || kont.Kind(err) != kont.KindUnhandled {
+		t.Fatalf("unhandled: got %v", err)
+	}
+
+	_, s := kont.Step(kont.Perform(kont.Ask[int]{}))
+	s.Resume(1)
+	err = recoverError(func() { s.Resume(1) })
+	if kont.Kind(err) != kont.KindSuspensionReused {
+		t.Fatalf("reuse: got %v", err)
+	}
+
+	err = recoverError(func() { panic("kont: unhandled effect in Custom") })
+	if kont.Kind(err) != kont.KindHandlerFailure {
+		t.Fatalf("string panic: got %v, %v", err, kont.Kind(err))
+	}
+
+	cause := errors.New("disk full")
+	err = recoverError(func() { panic(cause) })
+	if !errors.Is(err, cause) || kont.Kind(err) != kont.KindHandlerFailure {
+		t.Fatalf("failure: got %v", err)
+	}
+	if kont.PanicError(nil) != nil {
+		t.Fatal("PanicError(nil) should be nil")
+	}
+}
+
+func TestUnhandledErrorCarriesOp(t *testing.T) {
+	defer func() {
+		r := recover()
+		ue, ok := r.(*kont.UnhandledError)
+		if !ok {
+			t.Fatalf("got panic %T %v", r, r)
+		}
+		if _, ok := ue.Op.(kont.Ask[int]); !ok || ue.Handler != "StateHandler" {
+			t.Fatalf("got handler %q op %T", ue.Handler, ue.Op)
+		}
+		if !errors.Is(ue, kont.ErrUnhandled) {
+			t.Fatal("UnhandledError should match ErrUnhandled")
+		}
+		if !strings.HasPrefix(ue.Error(), "kont: unhandled effect in StateHandler (kont.Ask[int]") {
+			t.Fatalf("got message %q", ue.Error())
+		}
+	}()
+	kont.RunState[int, int](0, kont.Perform(kont.Ask[int]{}))
+}
+
+func TestUnhandledErrorMessage(t *testing.T) {
+	for _, tc := range []struct {
+		err  *kont.UnhandledError
+		want string
+	}{
+		{&kont.UnhandledError{Handler: "X"}, "kont: unhandled effect in X"},
+		{&kont.UnhandledError{Handler: "X", Op: kont.Get[int]{}}, "kont: unhandled effect in X (kont.Get[int])"},
+		{&kont.UnhandledError{Handler: "X", Op: kont.Get[int]{}, Site: "a.go:1"}, "kont: unhandled effect in X (kont.Get[int] performed at a.go:1)"},
+	} {
+		if got := tc.err.Error(); got != tc.want {
+			t.Errorf("got %q, want %q", got, tc.want)
+		}
+	}
+}
@@ -13,6 +13,9 @@ type Throw[E any] struct{ Err E }
 
 func (Throw[E]) OpResult() Resumed { panic("phantom") }
 
+// throw marks Throw for scopes that react to any error type.
+func (Throw[E]) throw() {}
+
 // DispatchError handles Throw in Error handler dispatch.
 // Sets the error in the context and returns (struct{}{}, true) — uniform
 // with State/Reader/Writer. The handler inspects ctx.HasErr to short-circuit.
@@ -55,15 +58,99 @@ func (o Catch[E, A]) DispatchError(ctx *ErrorContext[E]) (Resumed, bool) {
 	return v, true
 }
 
+// CatchAll is the deep variant of Catch.
+// Perform(CatchAll[E, A]{Body: m, Handler: h}) runs m, catching errors with h,
+// while every non-error operation in m and h is forwarded to the enclosing
+// handler. Composed handlers with an Error effect (RunStateError,
+// RunReaderStateError, RunStateOutbox) dispatch it deeply; under RunError it
+// behaves like Catch.
+//
+// State changes made by the body before it throws are kept, not rolled back.
+type CatchAll[E, A any] struct {
+	Body    Cont[Resumed, A]
+	Handler func(E) Cont[Resumed, A]
+}
+
+func (CatchAll[E, A]) OpResult() A { panic("phantom") }
+
+// DispatchError handles CatchAll without an enclosing handler, like Catch.
+func (o CatchAll[E, A]) DispatchError(ctx *ErrorContext[E]) (Resumed, bool) {
+	return Catch[E, A]{Body: o.Body, Handler: o.Handler}.DispatchError(ctx)
+}
+
+// DispatchErrorDeep handles CatchAll in composed handler dispatch.
+// Error operations in the body and handler are handled locally; every other
+// operation is passed to outer, which must resume.
+func (o CatchAll[E, A]) DispatchErrorDeep(ctx *ErrorContext[E], outer func(Operation) (Resumed, bool)) (Resumed, bool) {
+	result := runErrorDeep[E, A](o.Body, outer)
+	if e, ok := result.GetLeft(); ok {
+		result = runErrorDeep[E, A](o.Handler(e), outer)
+		if e, ok := result.GetLeft(); ok {
+			ctx.Err = e
+			ctx.HasErr = true
+			return struct{}{}, true
+		}
+	}
+	v, _ := result.GetRight()
+	return v, true
+}
+
+// deepErrorHandler handles Error effects locally and forwards all others.
+type deepErrorHandler[E, A any] struct {
+	ctx   *ErrorContext[E]
+	outer func(Operation) (Resumed, bool)
+}
+
+// Dispatch implements Handler for deep error handling.
+func (h *deepErrorHandler[E, A]) Dispatch(op Operation) (Resumed, bool) {
+	if dop, ok := op.(interface {
+		DispatchErrorDeep(ctx *ErrorContext[E], outer func(Operation) (Resumed, bool)) (Resumed, bool)
+	}); ok {
+		v, _ := dop.DispatchErrorDeep(h.ctx, h.outer)
+		if h.ctx.HasErr {
+			return Left[E, A](h.ctx.Err), false
+		}
+		return v, true
+	}
+	if eop, ok := op.(interface {
+		DispatchError(ctx *ErrorContext[E]) (Resumed, bool)
+	}); ok {
+		v, _ := eop.DispatchError(h.ctx)
+		if h.ctx.HasErr {
+			return Left[E, A](h.ctx.Err), false
+		}
+		return v, true
+	}
+	v, shouldResume := h.outer(op)
+	if !shouldResume {
+		panic("kont: enclosing handler short-circuited inside CatchAll")
+	}
+	return v, true
+}
+
+// runErrorDeep runs m like RunError, forwarding non-error operations to outer.
+func runErrorDeep[E, A any](m Cont[Resumed, A], outer func(Operation) (Resumed, bool)) Either[E, A] {
+	var ctx ErrorContext[E]
+	h := &deepErrorHandler[E, A]{ctx: &ctx, outer: outer}
+	result := m(rightCont[E, A])
+	if result == nil {
+		var zero A
+		return Right[E, A](zero)
+	}
+	return handleDispatch[*deepErrorHandler[E, A], Either[E, A]](result, h)
+}
+
 // ThrowError performs the Throw effect to raise an error.
 // This aborts the current computation — the continuation k is never called.
 func ThrowError[E, A any](err E) Cont[Resumed, A] {
 	resume := effectMarkerResume[A]
+	at := captureProvenance()
 	return func(k func(A) Resumed) Resumed {
 		m := acquireMarker()
 		m.op = Throw[E]{Err: err}
 		m.k = k
 		m.resume = resume
+		m.at = at
 		return m
 	}
 }
@@ -73,6 +160,160 @@ func CatchError[E, A any](body Cont[Resumed, A], handler func(E) Cont[Resumed, A
 	return Perform(Catch[E, A]{Body: body, Handler: handler})
 }
 
+// CatchAllError wraps a computation with a deep error handler: non-error
+// effects in body and handler reach the enclosing composed handler.
+func CatchAllError[E, A any](body Cont[Resumed, A], handler func(E) Cont[Resumed, A]) Cont[Resumed, A] {
+	return Perform(CatchAll[E, A]{Body: body, Handler: handler})
+}
+
+// MapError rewrites Throw[E1] errors raised by m into Throw[E2]{Err: f(e)},
+// so the enclosing handler sees E2. Every other operation passes through
+// unchanged, and effects performed after m completes are not rewritten.
+// Catch[E1] operations inside m are not translated.
+func MapError[E1, E2, A any](m Cont[Resumed, A], f func(E1) E2) Cont[Resumed, A] {
+	return func(k func(A) Resumed) Resumed {
+		return mapErrorResult(m(scopeDoneCont[A]), f, k)
+	}
+}
+
+// ExprMapError is the Expr counterpart of [MapError].
+func ExprMapError[E1, E2, A any](m Expr[A], f func(E1) E2) Expr[A] {
+	return Reify(MapError(Reflect(m), f))
+}
+
+// scopeDone marks completion of the body of MapError or Recover, so that the
+// outer continuation runs outside the rewriting scope.
+type scopeDone[A any] struct{ value A }
+
+func scopeDoneCont[A any](a A) Resumed { return scopeDone[A]{value: a} }
+
+// mapErrorSuspension wraps a suspension raised inside MapError, presenting
+// the rewritten operation and re-wrapping whatever the resumption yields.
+type mapErrorSuspension[E1, E2, A any] struct {
+	inner effectSuspension
+	op    Operation
+	f     func(E1) E2
+	k     func(A) Resumed
+}
+
+func (s *mapErrorSuspension[E1, E2, A]) Op() Operation { return s.op }
+func (s *mapErrorSuspension[E1, E2, A]) Resume(v Resumed) Resumed {
+	return mapErrorResult(s.inner.Resume(v), s.f, s.k)
+}
+func (s *mapErrorSuspension[E1, E2, A]) resumeWithError(throw Operation) Resumed {
+	return mapErrorResult(s.inner.resumeWithError(throw), s.f, s.k)
+}
+func (s *mapErrorSuspension[E1, E2, A]) release()         { s.inner.release() }
+func (s *mapErrorSuspension[E1, E2, A]) site() provenance { return s.inner.site() }
+
+func mapErrorResult[E1, E2, A any](r Resumed, f func(E1) E2, k func(A) Resumed) Resumed {
+	switch r := r.(type) {
+	case scopeDone[A]:
+		return k(r.value)
+	case effectSuspension:
+		op := r.Op()
+		if t, ok := op.(Throw[E1]); ok {
+			op = Throw[E2]{Err: f(t.Err)}
+		}
+		return &mapErrorSuspension[E1, E2, A]{inner: r, op: op, f: f, k: k}
+	}
+	return r
+}
+
+// Recover converts a panic raised while m runs into a Throw of onPanic(p),
+// so RunError and the composed runners report a Left instead of crashing.
+// This covers m's own code, including Bind closures and continuation steps
+// run on resumption; panics inside a handler's Dispatch are raised outside
+// m and are not recovered (see [RecoverHandler]). Effects performed after m
+// completes are not covered.
+func Recover[E, A any](m Cont[Resumed, A], onPanic func(any) E) Cont[Resumed, A] {
+	return func(k func(A) Resumed) Resumed {
+		r, p, panicked := recoverCall(func() Resumed { return m(scopeDoneCont[A]) })
+		return recoverResult(r, p, panicked, onPanic, k)
+	}
+}
+
+// ExprRecover is the Expr counterpart of [Recover].
+func ExprRecover[E, A any](m Expr[A], onPanic func(any) E) Expr[A] {
+	return Reify(Recover(Reflect(m), onPanic))
+}
+
+// recoverSuspension wraps a suspension raised inside Recover so the
+// resumption runs under recovery.
+type recoverSuspension[E, A any] struct {
+	inner   effectSuspension
+	onPanic func(any) E
+	k       func(A) Resumed
+}
+
+func (s *recoverSuspension[E, A]) Op() Operation { return s.inner.Op() }
+func (s *recoverSuspension[E, A]) Resume(v Resumed) Resumed {
+	r, p, panicked := recoverCall(func() Resumed { return s.inner.Resume(v) })
+	return recoverResult(r, p, panicked, s.onPanic, s.k)
+}
+func (s *recoverSuspension[E, A]) resumeWithError(throw Operation) Resumed {
+	r, p, panicked := recoverCall(func() Resumed { return s.inner.resumeWithError(throw) })
+	return recoverResult(r, p, panicked, s.onPanic, s.k)
+}
+func (s *recoverSuspension[E, A]) release()         { s.inner.release() }
+func (s *recoverSuspension[E, A]) site() provenance { return s.inner.site() }
+
+func recoverCall(f func() Resumed) (r Resumed, p any, panicked bool) {
+	defer func() {
+		if panicked {
+			p = recover()
+		}
+	}()
+	panicked = true
+	r = f()
+	panicked = false
+	return r, nil, false
+}
+
+func recoverResult[E, A any](r Resumed, p any, panicked bool, onPanic func(any) E, k func(A) Resumed) Resumed {
+	if panicked {
+		return ThrowError[E, A](onPanic(p))(k)
+	}
+	switch r := r.(type) {
+	case scopeDone[A]:
+		return k(r.value)
+	case effectSuspension:
+		return &recoverSuspension[E, A]{inner: r, onPanic: onPanic, k: k}
+	}
+	return r
+}
+
+// recoverHandler wraps a handler and converts panics in its Dispatch into
+// a short-circuit.
+type recoverHandler[H Handler[H, R], R any] struct {
+	inner   H
+	onPanic func(Operation, any) R
+}
+
+// Dispatch implements Handler by dispatching to the inner handler under
+// recovery. Unhandled-effect panics propagate, so that fallback chains
+// still see them.
+func (h *recoverHandler[H, R]) Dispatch(op Operation) (v Resumed, shouldResume bool) {
+	defer func() {
+		if p := recover(); p != nil {
+			if isUnhandledPanic(p) {
+				panic(p)
+			}
+			v, shouldResume = h.onPanic(op, p), false
+		}
+	}()
+	return h.inner.Dispatch(op)
+}
+
+// RecoverHandler recovers panics raised inside inner's Dispatch, the
+// dispatch boundary [Recover] does not cover: the computation is
+// short-circuited with onPanic(op, p), such as a Left for the Either
+// results of the Error runners.
+// Returns a concrete handler.
+func RecoverHandler[R any, H Handler[H, R]](inner H, onPanic func(op Operation, p any) R) *recoverHandler[H, R] {
+	return &recoverHandler[H, R]{inner: inner, onPanic: onPanic}
+}
+
 // ExprThrowError creates an Expr that throws an error.
 // Constructs EffectFrame directly because Throw[E].OpResult() returns
 // Resumed, not A — ExprPerform would produce Expr[Resumed].
@@ -84,6 +325,7 @@ func ExprThrowError[E, A any](err E) Expr[A] {
 			Operation: Throw[E]{Err: err},
 			Resume:    identityResume,
 			Next:      ReturnFrame{},
+			at:        captureProvenance(),
 		},
 	}
 }
@@ -106,7 +348,7 @@ func (h *errorHandler[E, A]) Dispatch(op Operation) (Resumed, bool) {
 		}
 		return v, true
 	}
-	unhandledEffect("ErrorHandler")
+	unhandledEffect("ErrorHandler", op)
 	return nil, false
 }
 
@@ -5,6 +5,7 @@
 package kont_test
 
 import (
+	"fmt"
 	"testing"
 
 	"code.hybscloud.com/kont"
@@ -233,3 +234,123 @@ func TestMapLeftEither(t *testing.T) {
 		t.Fatalf("got %q, want %q", err, "wrapped: error")
 	}
 }
+
+func TestCatchAllUnderRunErrorIsShallow(t *testing.T) {
+	comp := kont.CatchAllError[string, int](
+		kont.ThrowError[string, int]("boom"),
+		func(e string) kont.Eff[int] { return kont.Pure(len(e)) },
+	)
+	result := kont.RunError[string, int](comp)
+	if v, ok := result.GetRight(); !ok || v != 4 {
+		t.Fatalf("got %+v, want Right(4)", result)
+	}
+}
+
+type codeError struct{ Code int }
+
+func TestMapErrorRewritesThrow(t *testing.T) {
+	inner := kont.ThrowError[string, int]("not found")
+	comp := kont.MapError(inner, func(msg string) codeError { return codeError{Code: len(msg)} })
+	got := kont.RunError[codeError, int](comp)
+	if e, ok := got.GetLeft(); !ok || e.Code != 9 {
+		t.Fatalf("got %+v", got)
+	}
+}
+
+func TestMapErrorForwardsOtherEffects(t *testing.T) {
+	body := kont.GetState(func(s int) kont.Eff[int] {
+		return kont.PutState(s+1, kont.Pure(s*10))
+	})
+	comp := kont.Bind(kont.MapError(body, func(string) codeError { return codeError{} }), func(x int) kont.Eff[int] {
+		return kont.GetState(func(s int) kont.Eff[int] { return kont.Pure(x + s) })
+	})
+	got, state := kont.RunStateError[int, codeError, int](2, comp)
+	if v, ok := got.GetRight(); !ok || v != 23 || state != 3 {
+		t.Fatalf("got %+v, state %d", got, state)
+	}
+}
+
+func TestMapErrorScope(t *testing.T) {
+	comp := kont.Then(kont.MapError(kont.Pure(1), func(e string) string { return "mapped: " + e }),
+		kont.ThrowError[string, int]("outside"))
+	if e, _ := kont.RunError[string, int](comp).GetLeft(); e != "outside" {
+		t.Fatalf("got %q, want error raised after MapError untouched", e)
+	}
+}
+
+func TestExprMapError(t *testing.T) {
+	comp := kont.ExprMapError(kont.ExprThrowError[string, int]("boom"), func(e string) int { return len(e) })
+	if e, ok := kont.RunErrorExpr[int, int](comp).GetLeft(); !ok || e != 4 {
+		t.Fatalf("got %v", e)
+	}
+}
+
+func panicMessage(p any) string { return fmt.Sprint(p) }
+
+func TestRecoverBindPanic(t *testing.T) {
+	comp := kont.Recover(kont.Bind(kont.Pure(1), func(int) kont.Eff[int] { panic("bad input") }), panicMessage)
+	if e, ok := kont.RunError[string, int](comp).GetLeft(); !ok || e != "bad input" {
+		t.Fatalf("got %q", e)
+	}
+}
+
+func TestRecoverPanicAfterResume(t *testing.T) {
+	body := kont.GetState(func(s int) kont.Eff[int] {
+		if s == 0 {
+			panic("zero state")
+		}
+		return kont.Pure(s)
+	})
+	got, _ := kont.RunStateError[int, string, int](0, kont.Recover(body, panicMessage))
+	if e, ok := got.GetLeft(); !ok || e != "zero state" {
+		t.Fatalf("got %+v", got)
+	}
+	got, _ = kont.RunStateError[int, string, int](4, kont.Recover(body, panicMessage))
+	if v, ok := got.GetRight(); !ok || v != 4 {
+		t.Fatalf("got %+v", got)
+	}
+}
+
+func TestRecoverScope(t *testing.T) {
+	defer func() {
+		if r := recover(); r != "outside" {
+			t.Fatalf("got panic %v, want panic after Recover to propagate", r)
+		}
+	}()
+	comp := kont.Bind(kont.Recover(kont.Pure(1), panicMessage), func(int) kont.Eff[int] { panic("outside") })
+	kont.RunError[string, int](comp)
+}
+
+func TestExprRecover(t *testing.T) {
+	comp := kont.ExprRecover(kont.ExprMap(kont.ExprPerform(kont.Get[int]{}), func(s int) int { return 10 / s }), panicMessage)
+	got, _ := kont.RunStateErrorExpr[int, string, int](0, comp)
+	if e, ok := got.GetLeft(); !ok || e != "runtime error: integer divide by zero" {
+		t.Fatalf("got %+v", got)
+	}
+}
+
+func TestRecoverHandlerDispatchPanic(t *testing.T) {
+	h := kont.RecoverHandler[kont.Either[string, int]](
+		kont.HandleFunc[kont.Either[string, int]](func(op kont.Operation) (kont.Resumed, bool) {
+			panic("handler broke")
+		}),
+		func(op kont.Operation, p any) kont.Either[string, int] {
+			return kont.Left[string, int](fmt.Sprintf("%T: %v", op, p))
+		},
+	)
+	got := kont.Handle(kont.Map(kont.Perform(kont.Get[int]{}), kont.Right[string, int]), h)
+	if e, ok := got.GetLeft(); !ok || e != "kont.Get[int]: handler broke" {
+		t.Fatalf("got %+v", got)
+	}
+}
+
+func TestRecoverHandlerUnhandledPropagates(t *testing.T) {
+	defer func() {
+		if r := recover(); unhandledIn(r) != "StateHandler" {
+			t.Fatalf("got panic %v", r)
+		}
+	}()
+	st, _ := kont.StateHandler[int, int](0)
+	h := kont.RecoverHandler[int](st, func(kont.Operation, any) int { return -1 })
+	kont.Handle(kont.Perform(kont.Ask[int]{}), h)
+}
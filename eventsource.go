@@ -0,0 +1,163 @@
+// ©Hayabusa Cloud Co., Ltd. 2026. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package kont
+
+import "sync"
+
+// Event-sourced State handling.
+// Every operation that may change the state, Put and Modify as well as
+// State operations lifted by ZoomState or defined outside this package, is
+// journaled as an event recording the state it produced. Events hold plain
+// values rather than operations, whose Modify functions cannot be
+// persisted, so a journal can be stored durably. The state is rebuilt from
+// the latest snapshot and the events recorded after it.
+
+// JournalEntry is one journaled state event: the state produced by the
+// Seq-th change.
+type JournalEntry[S any] struct {
+	Seq   uint64
+	State S
+}
+
+// StateJournal stores the events and snapshots of an event-sourced state.
+type StateJournal[S any] interface {
+	// Snapshot returns the latest snapshot and the sequence number it covers.
+	// A journal without snapshots returns its initial state and 0.
+	Snapshot() (state S, seq uint64)
+	// Replay returns the entries with Seq greater than after, in order.
+	Replay(after uint64) []JournalEntry[S]
+	// Append records entry.
+	Append(entry JournalEntry[S])
+	// Compact records snapshot as covering every entry up to seq.
+	// Entries at or below seq may be discarded.
+	Compact(snapshot S, seq uint64)
+}
+
+// eventSourcedStateHandler implements Handler for journaled state handling.
+type eventSourcedStateHandler[S, R any] struct {
+	state        *S
+	journal      StateJournal[S]
+	seq          uint64
+	snapSeq      uint64
+	compactEvery uint64
+}
+
+// Dispatch implements Handler for journaled state handling.
+// Each operation is applied; unless it only reads the state, the resulting
+// state is then appended to the journal.
+func (h *eventSourcedStateHandler[S, R]) Dispatch(op Operation) (Resumed, bool) {
+	v, ok := dispatchState(op, h.state)
+	if r, isReader := op.(stateReader); isReader && r.readsOnly() {
+		return v, ok
+	}
+	h.seq++
+	h.journal.Append(JournalEntry[S]{Seq: h.seq, State: *h.state})
+	if h.compactEvery > 0 && h.seq-h.snapSeq >= h.compactEvery {
+		h.journal.Compact(*h.state, h.seq)
+		h.snapSeq = h.seq
+	}
+	return v, ok
+}
+
+// EventSourcedStateHandler creates a State handler whose initial state is
+// reconstructed from journal's snapshot plus replay, and which journals the
+// state produced by every operation other than Get and Gets, zoomed or not.
+// When compactEvery is positive, the handler calls journal.Compact with the
+// current state each time compactEvery events have accumulated since the
+// last snapshot.
+// Returns a concrete handler and a function to retrieve the current state.
+func EventSourcedStateHandler[S, R any](journal StateJournal[S], compactEvery uint64) (*eventSourcedStateHandler[S, R], func() S) {
+	state, seq := journal.Snapshot()
+	snapSeq := seq
+	for _, e := range journal.Replay(seq) {
+		state, seq = e.State, e.Seq
+	}
+	h := &eventSourcedStateHandler[S, R]{
+		state:        &state,
+		journal:      journal,
+		seq:          seq,
+		snapSeq:      snapSeq,
+		compactEvery: compactEvery,
+	}
+	return h, func() S { return state }
+}
+
+// RunEventSourced runs a stateful computation against a journal.
+// Returns the result and the final state; see [EventSourcedStateHandler].
+func RunEventSourced[S, A any](journal StateJournal[S], compactEvery uint64, m Cont[Resumed, A]) (A, S) {
+	h, state := EventSourcedStateHandler[S, A](journal, compactEvery)
+	result := Handle(m, h)
+	return result, state()
+}
+
+// RunEventSourcedExpr runs a stateful Expr computation against a journal.
+func RunEventSourcedExpr[S, A any](journal StateJournal[S], compactEvery uint64, m Expr[A]) (A, S) {
+	h, state := EventSourcedStateHandler[S, A](journal, compactEvery)
+	result := HandleExpr(m, h)
+	return result, state()
+}
+
+// MemoryJournal is an in-memory StateJournal safe for concurrent use.
+type MemoryJournal[S any] struct {
+	mu       sync.Mutex
+	snapshot S
+	snapSeq  uint64
+	entries  []JournalEntry[S]
+}
+
+// NewMemoryJournal creates an empty journal whose initial state is initial.
+func NewMemoryJournal[S any](initial S) *MemoryJournal[S] {
+	return &MemoryJournal[S]{snapshot: initial}
+}
+
+// Snapshot implements StateJournal.
+func (j *MemoryJournal[S]) Snapshot() (S, uint64) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return j.snapshot, j.snapSeq
+}
+
+// Replay implements StateJournal.
+func (j *MemoryJournal[S]) Replay(after uint64) []JournalEntry[S] {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	var out []JournalEntry[S]
+	for _, e := range j.entries {
+		if e.Seq > after {
+			out = append(out, e)
+		}
+	}
+	return out
+}
+
+// Append implements StateJournal.
+func (j *MemoryJournal[S]) Append(entry JournalEntry[S]) {
+	j.mu.Lock()
+	j.entries = append(j.entries, entry)
+	j.mu.Unlock()
+}
+
+// Compact implements StateJournal by dropping entries covered by snapshot.
+func (j *MemoryJournal[S]) Compact(snapshot S, seq uint64) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.snapshot = snapshot
+	j.snapSeq = seq
+	kept := j.entries[:0]
+	for _, e := range j.entries {
+		if e.Seq > seq {
+			kept = append(kept, e)
+		}
+	}
+	clear(j.entries[len(kept):])
+	j.entries = kept
+}
+
+// Len returns the number of entries not yet compacted.
+func (j *MemoryJournal[S]) Len() int {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return len(j.entries)
+}
@@ -0,0 +1,111 @@
+// ©Hayabusa Cloud Co., Ltd. 2026. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package kont_test
+
+import (
+	"testing"
+
+	"code.hybscloud.com/kont"
+)
+
+func addBalance(n int) kont.Eff[int] {
+	return kont.ModifyState(func(s int) int { return s + n }, func(s int) kont.Eff[int] {
+		return kont.Pure(s)
+	})
+}
+
+func TestEventSourcedJournalsWrites(t *testing.T) {
+	j := kont.NewMemoryJournal(0)
+	comp := kont.Then(addBalance(5), kont.PutState(42, kont.Perform(kont.Get[int]{})))
+	result, state := kont.RunEventSourced(j, 0, comp)
+	if result != 42 || state != 42 {
+		t.Fatalf("got %d, %d, want 42, 42", result, state)
+	}
+	entries := j.Replay(0)
+	if len(entries) != 2 || entries[0].Seq != 1 || entries[1].Seq != 2 {
+		t.Fatalf("got entries %+v", entries)
+	}
+	if entries[0].State != 5 || entries[1].State != 42 {
+		t.Fatalf("got states %d, %d, want 5, 42", entries[0].State, entries[1].State)
+	}
+}
+
+func TestEventSourcedReplay(t *testing.T) {
+	j := kont.NewMemoryJournal(100)
+	kont.RunEventSourced(j, 0, kont.Then(addBalance(10), addBalance(-3)))
+
+	// A fresh run rebuilds 107 from the journal and continues the sequence.
+	result, state := kont.RunEventSourced(j, 0, addBalance(1))
+	if result != 108 || state != 108 {
+		t.Fatalf("got %d, %d, want 108, 108", result, state)
+	}
+	entries := j.Replay(0)
+	if last := entries[len(entries)-1]; last.Seq != 3 {
+		t.Fatalf("got last seq %d, want 3", last.Seq)
+	}
+}
+
+func TestEventSourcedCompaction(t *testing.T) {
+	j := kont.NewMemoryJournal(0)
+	comp := kont.Then(addBalance(1), kont.Then(addBalance(1), kont.Then(addBalance(1), addBalance(1))))
+	kont.RunEventSourced(j, 3, comp)
+
+	snap, seq := j.Snapshot()
+	if snap != 3 || seq != 3 {
+		t.Fatalf("got snapshot %d at %d, want 3 at 3", snap, seq)
+	}
+	if j.Len() != 1 {
+		t.Fatalf("got %d uncompacted entries, want 1", j.Len())
+	}
+	_, state := kont.RunEventSourced(j, 3, kont.Perform(kont.Get[int]{}))
+	if state != 4 {
+		t.Fatalf("got rebuilt state %d, want 4", state)
+	}
+}
+
+func TestEventSourcedReadsNotJournaled(t *testing.T) {
+	j := kont.NewMemoryJournal("x")
+	h, state := kont.EventSourcedStateHandler[string, string](j, 0)
+	got := kont.Handle(kont.Perform(kont.Get[string]{}), h)
+	if got != "x" || state() != "x" || j.Len() != 0 {
+		t.Fatalf("got %q, state %q, %d entries", got, state(), j.Len())
+	}
+}
+
+func TestRunEventSourcedExpr(t *testing.T) {
+	j := kont.NewMemoryJournal(1)
+	comp := kont.ExprThen(kont.ExprPerform(kont.Modify[int]{F: func(s int) int { return s * 10 }}),
+		kont.ExprPerform(kont.Get[int]{}))
+	result, state := kont.RunEventSourcedExpr(j, 0, comp)
+	if result != 10 || state != 10 || j.Len() != 1 {
+		t.Fatalf("got %d, %d, %d entries", result, state, j.Len())
+	}
+}
+
+type ledger struct {
+	Balance int
+	Owner   string
+}
+
+func TestEventSourcedJournalsZoomedWrites(t *testing.T) {
+	j := kont.NewMemoryJournal(ledger{Owner: "ann"})
+	balance := func(body kont.Eff[int]) kont.Eff[int] {
+		return kont.ZoomState(
+			func(a ledger) int { return a.Balance },
+			func(a ledger, b int) ledger { a.Balance = b; return a },
+			body)
+	}
+	comp := balance(kont.Then(addBalance(7), kont.Perform(kont.Get[int]{})))
+	if got, state := kont.RunEventSourced(j, 0, comp); got != 7 || state.Balance != 7 {
+		t.Fatalf("got %d, state %+v", got, state)
+	}
+	entries := j.Replay(0)
+	if len(entries) != 1 || entries[0].State != (ledger{Balance: 7, Owner: "ann"}) {
+		t.Fatalf("got entries %+v, want one event with balance 7", entries)
+	}
+	if _, state := kont.RunEventSourced(j, 0, kont.Perform(kont.Get[ledger]{})); state.Balance != 7 {
+		t.Fatalf("replayed state %+v", state)
+	}
+}
@@ -0,0 +1,214 @@
+// ©Hayabusa Cloud Co., Ltd. 2026. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+// Package interp is a small expression-language interpreter built on kont.
+//
+// It doubles as an end-to-end exercise of the composed-runner surface and
+// as a template for effectful interpreters:
+//
+//   - Reader carries the evaluation [Config].
+//   - State counts evaluation steps against Config.MaxSteps.
+//   - Error reports unbound variables, division by zero, and step limits
+//     as Go errors; [Try] recovers with CatchAll, so the body keeps its
+//     access to Reader and State.
+//   - NonDet expands every [Choice] into the deterministic programs it
+//     denotes, each of which is then evaluated independently.
+//
+// [Eval] returns the bare effectful computation, so callers can run it
+// under their own handlers instead of [Run].
+package interp
+
+import (
+	"errors"
+	"fmt"
+
+	"code.hybscloud.com/kont"
+)
+
+// Expr is a node of the expression language.
+type Expr interface{ node() }
+
+type (
+	// Num is an integer literal.
+	Num int
+	// Var refers to a variable bound by an enclosing Let.
+	Var string
+	// Add is L + R.
+	Add struct{ L, R Expr }
+	// Mul is L * R.
+	Mul struct{ L, R Expr }
+	// Div is L / R, failing with ErrDivByZero when R is zero.
+	Div struct{ L, R Expr }
+	// Let binds Name to Value in Body.
+	Let struct {
+		Name        string
+		Value, Body Expr
+	}
+	// Try evaluates Body, or Fallback if Body fails.
+	Try struct{ Body, Fallback Expr }
+	// Choice denotes either L or R. Programs containing Choice are run with
+	// RunAll, once per alternative.
+	Choice struct{ L, R Expr }
+)
+
+func (Num) node()    {}
+func (Var) node()    {}
+func (Add) node()    {}
+func (Mul) node()    {}
+func (Div) node()    {}
+func (Let) node()    {}
+func (Try) node()    {}
+func (Choice) node() {}
+
+// Config is the Reader environment of an evaluation.
+type Config struct {
+	// MaxSteps bounds the number of nodes evaluated. Zero is unlimited.
+	MaxSteps int
+}
+
+var (
+	// ErrUnbound reports a Var with no enclosing Let.
+	ErrUnbound = errors.New("interp: unbound variable")
+	// ErrDivByZero reports a Div whose divisor is zero.
+	ErrDivByZero = errors.New("interp: division by zero")
+	// ErrStepLimit reports an evaluation exceeding Config.MaxSteps.
+	ErrStepLimit = errors.New("interp: step limit exceeded")
+	// ErrChoice reports a Choice reaching Eval; expand it with Expand or RunAll.
+	ErrChoice = errors.New("interp: unexpanded choice")
+)
+
+// scope is an immutable list of variable bindings.
+type scope struct {
+	name  string
+	value int
+	next  *scope
+}
+
+func (s *scope) lookup(name string) (int, bool) {
+	for ; s != nil; s = s.next {
+		if s.name == name {
+			return s.value, true
+		}
+	}
+	return 0, false
+}
+
+// Eval returns the computation evaluating e. It performs Ask[Config],
+// Get[int]/Put[int] for the step count, and Throw[error].
+func Eval(e Expr) kont.Eff[int] {
+	return eval(e, nil)
+}
+
+func eval(e Expr, sc *scope) kont.Eff[int] {
+	return kont.Then(tick(), evalNode(e, sc))
+}
+
+// tick charges one step, failing once Config.MaxSteps is reached.
+func tick() kont.Eff[struct{}] {
+	return kont.AskReader(func(cfg Config) kont.Eff[struct{}] {
+		return kont.GetState(func(steps int) kont.Eff[struct{}] {
+			if cfg.MaxSteps > 0 && steps >= cfg.MaxSteps {
+				return kont.ThrowErr[struct{}](ErrStepLimit)
+			}
+			return kont.PutState(steps+1, kont.Pure(struct{}{}))
+		})
+	})
+}
+
+func evalNode(e Expr, sc *scope) kont.Eff[int] {
+	switch e := e.(type) {
+	case Num:
+		return kont.Pure(int(e))
+	case Var:
+		if v, ok := sc.lookup(string(e)); ok {
+			return kont.Pure(v)
+		}
+		return kont.ThrowErr[int](fmt.Errorf("%w: %s", ErrUnbound, string(e)))
+	case Add:
+		return binary(e.L, e.R, sc, func(l, r int) kont.Eff[int] { return kont.Pure(l + r) })
+	case Mul:
+		return binary(e.L, e.R, sc, func(l, r int) kont.Eff[int] { return kont.Pure(l * r) })
+	case Div:
+		return binary(e.L, e.R, sc, func(l, r int) kont.Eff[int] {
+			if r == 0 {
+				return kont.ThrowErr[int](ErrDivByZero)
+			}
+			return kont.Pure(l / r)
+		})
+	case Let:
+		return kont.Bind(eval(e.Value, sc), func(v int) kont.Eff[int] {
+			return eval(e.Body, &scope{name: e.Name, value: v, next: sc})
+		})
+	case Try:
+		return kont.CatchAllError(eval(e.Body, sc), func(error) kont.Eff[int] {
+			return eval(e.Fallback, sc)
+		})
+	case Choice:
+		return kont.ThrowErr[int](ErrChoice)
+	}
+	panic(fmt.Sprintf("interp: unknown node %T", e))
+}
+
+func binary(l, r Expr, sc *scope, op func(int, int) kont.Eff[int]) kont.Eff[int] {
+	return kont.Bind(eval(l, sc), func(lv int) kont.Eff[int] {
+		return kont.Bind(eval(r, sc), func(rv int) kont.Eff[int] {
+			return op(lv, rv)
+		})
+	})
+}
+
+// Result is the outcome of one evaluation.
+type Result struct {
+	Value int
+	Steps int
+	Err   error
+}
+
+// Run evaluates a Choice-free e under cfg.
+func Run(e Expr, cfg Config) Result {
+	r, steps := kont.RunReaderStateError[Config, int, error](cfg, 0, Eval(e))
+	v, err := kont.EitherErr(r)
+	return Result{Value: v, Steps: steps, Err: err}
+}
+
+// Expand returns every Choice-free program e denotes, left alternatives
+// first.
+func Expand(e Expr) []Expr {
+	return kont.RunNonDet(expand(e))
+}
+
+func expand(e Expr) kont.Eff[Expr] {
+	switch e := e.(type) {
+	case Add:
+		return expand2(e.L, e.R, func(l, r Expr) Expr { return Add{l, r} })
+	case Mul:
+		return expand2(e.L, e.R, func(l, r Expr) Expr { return Mul{l, r} })
+	case Div:
+		return expand2(e.L, e.R, func(l, r Expr) Expr { return Div{l, r} })
+	case Let:
+		return expand2(e.Value, e.Body, func(v, b Expr) Expr { return Let{e.Name, v, b} })
+	case Try:
+		return expand2(e.Body, e.Fallback, func(b, f Expr) Expr { return Try{b, f} })
+	case Choice:
+		return kont.Bind(kont.Amb(e.L, e.R), expand)
+	}
+	return kont.Pure(e)
+}
+
+func expand2(l, r Expr, mk func(Expr, Expr) Expr) kont.Eff[Expr] {
+	return kont.Bind(expand(l), func(l Expr) kont.Eff[Expr] {
+		return kont.Map(expand(r), func(r Expr) Expr { return mk(l, r) })
+	})
+}
+
+// RunAll evaluates every alternative of e under cfg, in Expand order.
+// Each alternative starts from a fresh step count.
+func RunAll(e Expr, cfg Config) []Result {
+	progs := Expand(e)
+	results := make([]Result, len(progs))
+	for i, p := range progs {
+		results[i] = Run(p, cfg)
+	}
+	return results
+}
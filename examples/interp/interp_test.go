@@ -0,0 +1,98 @@
+// ©Hayabusa Cloud Co., Ltd. 2026. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package interp_test
+
+import (
+	"errors"
+	"testing"
+
+	"code.hybscloud.com/kont"
+	"code.hybscloud.com/kont/examples/interp"
+)
+
+func TestRun(t *testing.T) {
+	// let x = 6 in x * (x + 1)
+	prog := interp.Let{Name: "x", Value: interp.Num(6), Body: interp.Mul{
+		L: interp.Var("x"),
+		R: interp.Add{L: interp.Var("x"), R: interp.Num(1)},
+	}}
+	r := interp.Run(prog, interp.Config{})
+	if r.Err != nil || r.Value != 42 || r.Steps != 7 {
+		t.Fatalf("got %+v, want 42 in 7 steps", r)
+	}
+}
+
+func TestRunErrors(t *testing.T) {
+	if r := interp.Run(interp.Var("y"), interp.Config{}); !errors.Is(r.Err, interp.ErrUnbound) || r.Err.Error() != "interp: unbound variable: y" {
+		t.Fatalf("got %+v", r)
+	}
+	if r := interp.Run(interp.Div{L: interp.Num(1), R: interp.Num(0)}, interp.Config{}); !errors.Is(r.Err, interp.ErrDivByZero) {
+		t.Fatalf("got %+v", r)
+	}
+	prog := interp.Add{L: interp.Num(1), R: interp.Add{L: interp.Num(2), R: interp.Num(3)}}
+	if r := interp.Run(prog, interp.Config{MaxSteps: 3}); !errors.Is(r.Err, interp.ErrStepLimit) || r.Steps != 3 {
+		t.Fatalf("got %+v", r)
+	}
+	if r := interp.Run(interp.Choice{L: interp.Num(1), R: interp.Num(2)}, interp.Config{}); !errors.Is(r.Err, interp.ErrChoice) {
+		t.Fatalf("got %+v", r)
+	}
+}
+
+func TestTryKeepsStateAndReader(t *testing.T) {
+	// try (1 + 1/0) else 7: the body's steps are counted and the fallback
+	// still sees the Reader config.
+	prog := interp.Try{
+		Body:     interp.Add{L: interp.Num(1), R: interp.Div{L: interp.Num(1), R: interp.Num(0)}},
+		Fallback: interp.Num(7),
+	}
+	r := interp.Run(prog, interp.Config{})
+	if r.Err != nil || r.Value != 7 || r.Steps != 7 {
+		t.Fatalf("got %+v, want 7 in 7 steps", r)
+	}
+	if r := interp.Run(prog, interp.Config{MaxSteps: 6}); !errors.Is(r.Err, interp.ErrStepLimit) {
+		t.Fatalf("got %+v, want step limit raised inside the fallback", r)
+	}
+}
+
+func TestRunAll(t *testing.T) {
+	// (1 | 2) * (10 | 0), then divide 100 by it
+	prog := interp.Div{
+		L: interp.Num(100),
+		R: interp.Mul{
+			L: interp.Choice{L: interp.Num(1), R: interp.Num(2)},
+			R: interp.Choice{L: interp.Num(10), R: interp.Num(0)},
+		},
+	}
+	rs := interp.RunAll(prog, interp.Config{})
+	if len(rs) != 4 {
+		t.Fatalf("got %d results, want 4", len(rs))
+	}
+	want := []int{10, 0, 5, 0}
+	for i, r := range rs {
+		if r.Value != want[i] || (want[i] == 0) != errors.Is(r.Err, interp.ErrDivByZero) {
+			t.Fatalf("result %d: got %+v", i, r)
+		}
+	}
+}
+
+func TestEvalCustomHandler(t *testing.T) {
+	steps := 0
+	h := kont.HandleFunc[int](func(op kont.Operation) (kont.Resumed, bool) {
+		switch op := op.(type) {
+		case kont.Ask[interp.Config]:
+			return interp.Config{}, true
+		case kont.Get[int]:
+			return steps, true
+		case kont.Put[int]:
+			steps = op.Value
+			return struct{}{}, true
+		}
+		t.Fatalf("unexpected operation %T", op)
+		return nil, false
+	})
+	if got := kont.Handle(interp.Eval(interp.Add{L: interp.Num(2), R: interp.Num(3)}), h); got != 5 || steps != 3 {
+		t.Fatalf("got %d in %d steps", got, steps)
+	}
+}
@@ -0,0 +1,50 @@
+// ©Hayabusa Cloud Co., Ltd. 2026. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package kont
+
+// Fallback handler chains.
+// ChainHandlers composes independently written handlers, including
+// third-party ones, without a hand-written switch over every operation.
+
+// chainedHandler implements Handler by trying each dispatch in order.
+type chainedHandler[R any] struct {
+	dispatches []DispatchFunc
+}
+
+// Dispatch implements Handler. A dispatch that panics as unhandled falls
+// through to the next one; any other panic propagates.
+func (h *chainedHandler[R]) Dispatch(op Operation) (Resumed, bool) {
+	for _, d := range h.dispatches {
+		if v, resume, matched := tryDispatch(d, op); matched {
+			return v, resume
+		}
+	}
+	unhandledEffect("ChainHandlers", op)
+	return nil, false
+}
+
+func tryDispatch(d DispatchFunc, op Operation) (v Resumed, resume, matched bool) {
+	defer func() {
+		if matched {
+			return
+		}
+		if r := recover(); !isUnhandledPanic(r) {
+			panic(r)
+		}
+	}()
+	v, resume = d(op)
+	return v, resume, true
+}
+
+// ChainHandlers creates a handler trying dispatches in order, such as the
+// Dispatch methods of several handlers. A dispatch that does not recognize
+// an operation is expected to panic with an [*UnhandledError], as the
+// standard handlers do; the next dispatch is then tried. Panics only when
+// no dispatch matches. A dispatch should not change state before panicking,
+// since the chain cannot undo it.
+// Returns a concrete handler.
+func ChainHandlers[R any](dispatches ...DispatchFunc) *chainedHandler[R] {
+	return &chainedHandler[R]{dispatches: append([]DispatchFunc(nil), dispatches...)}
+}
@@ -0,0 +1,56 @@
+// ©Hayabusa Cloud Co., Ltd. 2026. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package kont_test
+
+import (
+	"testing"
+
+	"code.hybscloud.com/kont"
+)
+
+func TestChainHandlersFallsThrough(t *testing.T) {
+	st, state := kont.StateHandler[int, string](1)
+	rd := kont.ReaderHandler[string, string]("env")
+	fresh := kont.FreshHandler[string](&kont.FreshSupply{})
+	h := kont.ChainHandlers[string](st.Dispatch, rd.Dispatch, fresh.Dispatch)
+	comp := kont.AskReader(func(env string) kont.Eff[string] {
+		return kont.PutState(2, kont.FreshName(env))
+	})
+	if got := kont.Handle(comp, h); got != "env0" || state() != 2 {
+		t.Fatalf("got %q state %d", got, state())
+	}
+}
+
+func TestChainHandlersFirstMatchWins(t *testing.T) {
+	a, _ := kont.StateHandler[int, int](1)
+	b, _ := kont.StateHandler[int, int](2)
+	h := kont.ChainHandlers[int](a.Dispatch, b.Dispatch)
+	if got := kont.HandleExpr(kont.ExprPerform(kont.Get[int]{}), h); got != 1 {
+		t.Fatalf("got %d", got)
+	}
+}
+
+func TestChainHandlersNoMatchPanics(t *testing.T) {
+	st, _ := kont.StateHandler[int, int](0)
+	h := kont.ChainHandlers[int](st.Dispatch)
+	defer func() {
+		if r := recover(); unhandledIn(r) != "ChainHandlers" {
+			t.Fatalf("recovered %v", r)
+		}
+	}()
+	kont.Handle(kont.Perform(kont.Ask[string]{}), h)
+}
+
+func TestChainHandlersPropagatesOtherPanics(t *testing.T) {
+	boom := kont.DispatchFunc(func(kont.Operation) (kont.Resumed, bool) { panic("boom") })
+	st, _ := kont.StateHandler[int, int](0)
+	h := kont.ChainHandlers[int](boom, st.Dispatch)
+	defer func() {
+		if r := recover(); r != "boom" {
+			t.Fatalf("recovered %v", r)
+		}
+	}()
+	kont.Handle(kont.Perform(kont.Get[int]{}), h)
+}
@@ -0,0 +1,189 @@
+// ©Hayabusa Cloud Co., Ltd. 2026. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package kont
+
+import (
+	"maps"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Feature flag effect operations.
+// FlagEnabled declares conditional behavior as an effect answered by a
+// FlagProvider, so that tests can swap the provider given to FlagHandler
+// instead of rebuilding configuration structs.
+
+// FlagProvider reports whether a named feature flag is enabled.
+type FlagProvider interface {
+	Enabled(name string) bool
+}
+
+// FlagEnabled is the effect operation for querying a feature flag.
+// Perform(FlagEnabled{Name: n}) returns whether flag n is enabled.
+type FlagEnabled struct{ Name string }
+
+func (FlagEnabled) OpResult() bool { panic("phantom") }
+
+// DispatchFlags handles FlagEnabled in Flags handler dispatch.
+func (o FlagEnabled) DispatchFlags(p FlagProvider) (Resumed, bool) {
+	return p.Enabled(o.Name), true
+}
+
+// CheckFlag fuses FlagEnabled + Bind: queries flag name, passes the result to f.
+func CheckFlag[B any](name string, f func(bool) Cont[Resumed, B]) Cont[Resumed, B] {
+	resume := bindMarkerResume[bool, B]
+	at := captureProvenance()
+	return func(k func(B) Resumed) Resumed {
+		m := acquireMarker()
+		m.op = FlagEnabled{Name: name}
+		m.f = f
+		m.k = k
+		m.resume = resume
+		m.at = at
+		return m
+	}
+}
+
+// flagHandler implements Handler for feature flag handling.
+type flagHandler[R any] struct {
+	p FlagProvider
+}
+
+// Dispatch implements Handler for feature flag handling.
+func (h *flagHandler[R]) Dispatch(op Operation) (Resumed, bool) {
+	if fop, ok := op.(interface {
+		DispatchFlags(p FlagProvider) (Resumed, bool)
+	}); ok {
+		return fop.DispatchFlags(h.p)
+	}
+	unhandledEffect("FlagHandler", op)
+	return nil, false
+}
+
+// FlagHandler creates a handler for feature flag effects backed by p.
+// Returns a concrete handler.
+func FlagHandler[R any](p FlagProvider) *flagHandler[R] {
+	return &flagHandler[R]{p: p}
+}
+
+// RunFlags runs a computation with feature flags answered by p.
+func RunFlags[A any](p FlagProvider, m Cont[Resumed, A]) A {
+	return Handle(m, FlagHandler[A](p))
+}
+
+// RunFlagsExpr runs an Expr computation with feature flags answered by p.
+func RunFlagsExpr[A any](p FlagProvider, m Expr[A]) A {
+	return HandleExpr(m, FlagHandler[A](p))
+}
+
+// StaticFlags is a FlagProvider backed by a fixed map.
+// Flags absent from the map are disabled.
+type StaticFlags map[string]bool
+
+// Enabled implements FlagProvider.
+func (f StaticFlags) Enabled(name string) bool { return f[name] }
+
+// Names returns the flag names in sorted order.
+func (f StaticFlags) Names() []string { return slices.Sorted(maps.Keys(f)) }
+
+// EnvFlags is a FlagProvider backed by environment variables.
+// Flag "new-ui" with Prefix "APP_FLAG_" reads APP_FLAG_NEW_UI: the name is
+// upper-cased and '-' and '.' become '_'. Values are parsed with
+// strconv.ParseBool; unset or malformed variables are disabled.
+type EnvFlags struct{ Prefix string }
+
+// Enabled implements FlagProvider.
+func (f EnvFlags) Enabled(name string) bool {
+	key := f.Prefix + strings.Map(func(r rune) rune {
+		if r == '-' || r == '.' {
+			return '_'
+		}
+		return r
+	}, strings.ToUpper(name))
+	enabled, err := strconv.ParseBool(os.Getenv(key))
+	return err == nil && enabled
+}
+
+// DynamicFlags is a FlagProvider whose flags change at runtime.
+// It is safe for concurrent use; subscribers are notified on every change.
+type DynamicFlags struct {
+	notify sync.Mutex // serializes Set, so notifications follow the stored order
+	mu     sync.RWMutex
+	flags  map[string]bool
+	subs   map[uint64]func(name string, enabled bool)
+	nextID uint64
+}
+
+// NewDynamicFlags creates a DynamicFlags seeded with a copy of initial.
+func NewDynamicFlags(initial map[string]bool) *DynamicFlags {
+	flags := make(map[string]bool, len(initial))
+	for name, enabled := range initial {
+		flags[name] = enabled
+	}
+	return &DynamicFlags{flags: flags, subs: make(map[uint64]func(string, bool))}
+}
+
+// Enabled implements FlagProvider.
+func (f *DynamicFlags) Enabled(name string) bool {
+	f.mu.RLock()
+	enabled := f.flags[name]
+	f.mu.RUnlock()
+	return enabled
+}
+
+// Set changes flag name and notifies subscribers when its effective value,
+// as reported by Enabled, changed; an absent flag is false.
+// Subscribers run synchronously on the calling goroutine after the change is
+// visible, in the order they subscribed. Concurrent Sets are serialized, so
+// subscribers see changes in the order they were stored; a subscriber must
+// not call Set.
+func (f *DynamicFlags) Set(name string, enabled bool) {
+	f.notify.Lock()
+	defer f.notify.Unlock()
+	f.mu.Lock()
+	old := f.flags[name]
+	f.flags[name] = enabled
+	if old == enabled {
+		f.mu.Unlock()
+		return
+	}
+	subs := make([]func(string, bool), 0, len(f.subs))
+	for _, id := range slices.Sorted(maps.Keys(f.subs)) {
+		subs = append(subs, f.subs[id])
+	}
+	f.mu.Unlock()
+	for _, fn := range subs {
+		fn(name, enabled)
+	}
+}
+
+// Snapshot returns every flag and its value, sorted by name.
+func (f *DynamicFlags) Snapshot() []Pair[string, bool] {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	out := make([]Pair[string, bool], 0, len(f.flags))
+	for _, name := range slices.Sorted(maps.Keys(f.flags)) {
+		out = append(out, Pair[string, bool]{Fst: name, Snd: f.flags[name]})
+	}
+	return out
+}
+
+// Subscribe registers fn to be called on every flag change.
+// Returns a function that removes the subscription.
+func (f *DynamicFlags) Subscribe(fn func(name string, enabled bool)) (cancel func()) {
+	f.mu.Lock()
+	id := f.nextID
+	f.nextID++
+	f.subs[id] = fn
+	f.mu.Unlock()
+	return func() {
+		f.mu.Lock()
+		delete(f.subs, id)
+		f.mu.Unlock()
+	}
+}
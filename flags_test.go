@@ -0,0 +1,145 @@
+// ©Hayabusa Cloud Co., Ltd. 2026. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package kont_test
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"code.hybscloud.com/kont"
+)
+
+func greeting() kont.Eff[string] {
+	return kont.CheckFlag("new-greeting", func(on bool) kont.Eff[string] {
+		if on {
+			return kont.Pure("hello")
+		}
+		return kont.Pure("hi")
+	})
+}
+
+func TestStaticFlags(t *testing.T) {
+	on := kont.RunFlags(kont.StaticFlags{"new-greeting": true}, greeting())
+	if on != "hello" {
+		t.Fatalf("got %q, want %q", on, "hello")
+	}
+	off := kont.RunFlags(kont.StaticFlags{}, greeting())
+	if off != "hi" {
+		t.Fatalf("got %q, want %q", off, "hi")
+	}
+}
+
+func TestEnvFlags(t *testing.T) {
+	t.Setenv("APP_FLAG_NEW_GREETING", "true")
+	t.Setenv("APP_FLAG_BROKEN", "maybe")
+	flags := kont.EnvFlags{Prefix: "APP_FLAG_"}
+
+	if got := kont.RunFlags(flags, greeting()); got != "hello" {
+		t.Fatalf("got %q, want %q", got, "hello")
+	}
+	if flags.Enabled("broken") {
+		t.Fatal("malformed value must be disabled")
+	}
+	if flags.Enabled("missing") {
+		t.Fatal("unset variable must be disabled")
+	}
+	if !flags.Enabled("new.greeting") {
+		t.Fatal("'.' must map to '_'")
+	}
+}
+
+func TestDynamicFlags(t *testing.T) {
+	flags := kont.NewDynamicFlags(map[string]bool{"new-greeting": false})
+	var changes []string
+	cancel := flags.Subscribe(func(name string, enabled bool) {
+		if enabled {
+			changes = append(changes, name+"=on")
+		} else {
+			changes = append(changes, name+"=off")
+		}
+	})
+
+	if got := kont.RunFlags(flags, greeting()); got != "hi" {
+		t.Fatalf("got %q, want %q", got, "hi")
+	}
+	flags.Set("new-greeting", true)
+	flags.Set("new-greeting", true) // unchanged: no notification
+	flags.Set("absent", false)      // absent is already off: no notification
+	if got := kont.RunFlags(flags, greeting()); got != "hello" {
+		t.Fatalf("got %q, want %q", got, "hello")
+	}
+	cancel()
+	flags.Set("new-greeting", false)
+
+	if len(changes) != 1 || changes[0] != "new-greeting=on" {
+		t.Fatalf("got changes %v", changes)
+	}
+}
+
+func TestDynamicFlagsNotifyOrder(t *testing.T) {
+	flags := kont.NewDynamicFlags(nil)
+	var last atomic.Bool
+	flags.Subscribe(func(_ string, enabled bool) { last.Store(enabled) })
+	var wg sync.WaitGroup
+	for i := range 64 {
+		wg.Go(func() { flags.Set("f", i%2 == 0) })
+	}
+	wg.Wait()
+	// The last notification carries the value stored last.
+	if last.Load() != flags.Enabled("f") {
+		t.Fatalf("notified %v, stored %v", last.Load(), flags.Enabled("f"))
+	}
+}
+
+func TestFlagsExpr(t *testing.T) {
+	comp := kont.ExprMap(kont.ExprPerform(kont.FlagEnabled{Name: "beta"}), func(on bool) int {
+		if on {
+			return 2
+		}
+		return 1
+	})
+	if got := kont.RunFlagsExpr(kont.StaticFlags{"beta": true}, comp); got != 2 {
+		t.Fatalf("got %d, want 2", got)
+	}
+}
+
+func TestFlagHandlerUnhandledPanic(t *testing.T) {
+	defer func() {
+		if r := recover(); unhandledIn(r) != "FlagHandler" {
+			t.Fatalf("got panic %v", r)
+		}
+	}()
+	kont.RunFlags(kont.StaticFlags{}, kont.Perform(kont.Get[int]{}))
+}
+
+func TestOpResultPanicFlagEnabled(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Fatal("expected phantom panic")
+		}
+	}()
+	kont.FlagEnabled{}.OpResult()
+}
+
+func TestFlagsDeterministicOrder(t *testing.T) {
+	static := kont.StaticFlags{"c": true, "a": false, "b": true}
+	if got := fmt.Sprint(static.Names()); got != "[a b c]" {
+		t.Fatalf("Names %s", got)
+	}
+	f := kont.NewDynamicFlags(map[string]bool{"z": true, "m": false, "a": true})
+	if got := fmt.Sprint(f.Snapshot()); got != "[{a true} {m false} {z true}]" {
+		t.Fatalf("Snapshot %s", got)
+	}
+	var calls []int
+	for i := range 8 {
+		f.Subscribe(func(string, bool) { calls = append(calls, i) })
+	}
+	f.Set("m", true)
+	if got := fmt.Sprint(calls); got != "[0 1 2 3 4 5 6 7]" {
+		t.Fatalf("subscribers notified in order %s", got)
+	}
+}
@@ -0,0 +1,73 @@
+// ©Hayabusa Cloud Co., Ltd. 2026. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package kont
+
+// Effect forwarding.
+// HandleForward handles a computation with one handler and re-performs
+// every operation that handler declines, so handlers nest like layers of
+// an algebraic-effects system instead of one handler covering every op:
+//
+//	kont.Handle(kont.HandleForward(m, stateHandler), readerHandler)
+
+// forwardMarker is the resume value of a dispatch declining an operation.
+type forwardMarker struct{}
+
+// ForwardOp is returned from Dispatch to decline an operation, so that
+// HandleForward re-performs it to the enclosing handler. Handlers that panic
+// as unhandled, like the standard ones, are forwarded the same way.
+func ForwardOp() (Resumed, bool) { return forwardMarker{}, true }
+
+// HandleForward runs m with h, forwarding the operations h declines to the
+// handler of the resulting computation. When h short-circuits with a value,
+// that value becomes the result of m and evaluation continues after it.
+func HandleForward[R any, H Handler[H, R]](m Cont[Resumed, R], h H) Cont[Resumed, R] {
+	return func(k func(R) Resumed) Resumed {
+		return forwardResult(m(scopeDoneCont[R]), h, k)
+	}
+}
+
+// HandleForwardExpr is the Expr counterpart of [HandleForward].
+func HandleForwardExpr[R any, H Handler[H, R]](m Expr[R], h H) Expr[R] {
+	return Reify(HandleForward(Reflect(m), h))
+}
+
+// forwardSuspension wraps a suspension declined by the inner handler so its
+// resumption continues under that handler.
+type forwardSuspension[H Handler[H, R], R any] struct {
+	inner effectSuspension
+	h     H
+	k     func(R) Resumed
+}
+
+func (s *forwardSuspension[H, R]) Op() Operation { return s.inner.Op() }
+func (s *forwardSuspension[H, R]) Resume(v Resumed) Resumed {
+	return forwardResult(s.inner.Resume(v), s.h, s.k)
+}
+func (s *forwardSuspension[H, R]) resumeWithError(throw Operation) Resumed {
+	return forwardResult(s.inner.resumeWithError(throw), s.h, s.k)
+}
+func (s *forwardSuspension[H, R]) release()         { s.inner.release() }
+func (s *forwardSuspension[H, R]) site() provenance { return s.inner.site() }
+
+func forwardResult[H Handler[H, R], R any](r Resumed, h H, k func(R) Resumed) Resumed {
+	for {
+		switch rr := r.(type) {
+		case scopeDone[R]:
+			return k(rr.value)
+		case effectSuspension:
+			v, resume, matched := tryDispatch(h.Dispatch, rr.Op())
+			if _, declined := v.(forwardMarker); !matched || declined {
+				return &forwardSuspension[H, R]{inner: rr, h: h, k: k}
+			}
+			if !resume {
+				rr.release()
+				return k(valueOrZero[R](v))
+			}
+			r = rr.Resume(v)
+			continue
+		}
+		return r
+	}
+}
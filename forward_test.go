@@ -0,0 +1,66 @@
+// ©Hayabusa Cloud Co., Ltd. 2026. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package kont_test
+
+import (
+	"testing"
+
+	"code.hybscloud.com/kont"
+)
+
+func TestHandleForwardNestsStandardHandlers(t *testing.T) {
+	st, state := kont.StateHandler[int, int](10)
+	comp := kont.AskReader(func(n int) kont.Eff[int] {
+		return kont.ModifyState(func(s int) int { return s + n }, func(s int) kont.Eff[int] { return kont.Pure(s) })
+	})
+	got := kont.Handle(kont.HandleForward(comp, st), kont.ReaderHandler[int, int](5))
+	if got != 15 || state() != 15 {
+		t.Fatalf("got %d state %d", got, state())
+	}
+}
+
+func TestHandleForwardExplicitDecline(t *testing.T) {
+	zeros := kont.HandleFunc[int](func(op kont.Operation) (kont.Resumed, bool) {
+		if _, ok := op.(kont.Ask[int]); ok {
+			return kont.ForwardOp()
+		}
+		return 0, true
+	})
+	comp := kont.ExprPerform(kont.Ask[int]{})
+	got := kont.HandleExpr(kont.HandleForwardExpr(comp, zeros), kont.ReaderHandler[int, int](7))
+	if got != 7 {
+		t.Fatalf("got %d", got)
+	}
+}
+
+func TestHandleForwardShortCircuitContinuesOuter(t *testing.T) {
+	abort := kont.HandleFunc[int](func(op kont.Operation) (kont.Resumed, bool) {
+		if _, ok := op.(kont.Get[int]); ok {
+			return -1, false
+		}
+		return kont.ForwardOp()
+	})
+	inner := kont.Then(kont.Perform(kont.Get[int]{}), kont.Pure(100))
+	comp := kont.Bind(kont.HandleForward(inner, abort), func(v int) kont.Eff[int] {
+		return kont.Map(kont.Perform(kont.Ask[int]{}), func(n int) int { return v + n })
+	})
+	if got := kont.Handle(comp, kont.ReaderHandler[int, int](3)); got != 2 {
+		t.Fatalf("got %d", got)
+	}
+}
+
+func TestHandleForwardStepExposesForwardedOps(t *testing.T) {
+	st, _ := kont.StateHandler[string, string]("s")
+	comp := kont.GetState(func(s string) kont.Eff[string] {
+		return kont.Map(kont.Perform(kont.Ask[string]{}), func(e string) string { return s + e })
+	})
+	_, susp := kont.Step(kont.HandleForward(comp, st))
+	if _, ok := susp.Op().(kont.Ask[string]); !ok {
+		t.Fatalf("op %T", susp.Op())
+	}
+	if got, next := susp.Resume("!"); next != nil || got != "s!" {
+		t.Fatalf("got %q %v", got, next)
+	}
+}
@@ -66,6 +66,9 @@ type MapFrame[A, B any] struct {
 
 	// Next is the continuation frame after transformation.
 	Next Frame
+
+	// fuse is the typed mapFusion of a frame built by ExprMap, or nil.
+	fuse any
 }
 
 // Unwind performs a single step of reduction for the MapFrame.
@@ -127,7 +130,10 @@ type EffectFrame[A any] struct {
 	// Next is the continuation frame after resumption.
 	Next Frame
 
-	pooled bool
+	pooled          bool
+	forwards        bool // Resume passes values to a Cont suspension unchanged (Reify)
+	resumeWithError func(throw Operation) Erased
+	at              provenance
 }
 
 func (*EffectFrame[A]) frame() { return }
@@ -0,0 +1,112 @@
+// ©Hayabusa Cloud Co., Ltd. 2026. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package kont
+
+import "strconv"
+
+// Fresh effect operations.
+// Compilers and interpreters draw gensym-style identifiers through effects
+// instead of threading a counter through State.
+
+// FreshSupply is the source of fresh identifiers shared by a handler.
+// A supply may be reused across runs so identifiers stay unique between
+// passes.
+type FreshSupply struct {
+	// Next is the next identifier to hand out.
+	Next uint64
+	// Gen renders the suffix of a named identifier from its number.
+	// Nil renders the number in decimal; plug in a UUID generator to
+	// ignore the number and return a random suffix.
+	Gen func(n uint64) string
+}
+
+func (s *FreshSupply) take() uint64 {
+	n := s.Next
+	s.Next++
+	return n
+}
+
+// Fresh is the effect operation for a fresh numeric identifier.
+// Identifiers increase monotonically within a supply.
+type Fresh struct{}
+
+func (Fresh) OpResult() uint64 { panic("phantom") }
+
+// DispatchFresh takes the next identifier from s.
+func (Fresh) DispatchFresh(s *FreshSupply) (Resumed, bool) {
+	return s.take(), true
+}
+
+// FreshNamed is the effect operation for a fresh identifier rendered as
+// Prefix followed by the supply's generated suffix.
+type FreshNamed struct{ Prefix string }
+
+func (FreshNamed) OpResult() string { panic("phantom") }
+
+// DispatchFresh takes the next identifier from s and renders it.
+func (o FreshNamed) DispatchFresh(s *FreshSupply) (Resumed, bool) {
+	n := s.take()
+	if s.Gen != nil {
+		return o.Prefix + s.Gen(n), true
+	}
+	return o.Prefix + strconv.FormatUint(n, 10), true
+}
+
+// FreshID performs Fresh.
+func FreshID() Cont[Resumed, uint64] {
+	return Perform(Fresh{})
+}
+
+// FreshName performs FreshNamed.
+func FreshName(prefix string) Cont[Resumed, string] {
+	return Perform(FreshNamed{Prefix: prefix})
+}
+
+// ExprFreshID is the Expr counterpart of [FreshID].
+func ExprFreshID() Expr[uint64] {
+	return ExprPerform(Fresh{})
+}
+
+// ExprFreshName is the Expr counterpart of [FreshName].
+func ExprFreshName(prefix string) Expr[string] {
+	return ExprPerform(FreshNamed{Prefix: prefix})
+}
+
+// freshHandler implements Handler for the Fresh effect.
+type freshHandler[R any] struct {
+	supply *FreshSupply
+}
+
+// Dispatch implements Handler for the Fresh effect.
+func (h *freshHandler[R]) Dispatch(op Operation) (Resumed, bool) {
+	if fop, ok := op.(interface {
+		DispatchFresh(s *FreshSupply) (Resumed, bool)
+	}); ok {
+		return fop.DispatchFresh(h.supply)
+	}
+	unhandledEffect("FreshHandler", op)
+	return nil, false
+}
+
+// FreshHandler creates a Fresh handler drawing from supply.
+// Returns a concrete handler.
+func FreshHandler[R any](supply *FreshSupply) *freshHandler[R] {
+	return &freshHandler[R]{supply: supply}
+}
+
+// RunFresh runs a computation with the Fresh effect, numbering from zero.
+// Returns the result and the next unused identifier.
+func RunFresh[A any](m Cont[Resumed, A]) (A, uint64) {
+	var supply FreshSupply
+	result := Handle(m, FreshHandler[A](&supply))
+	return result, supply.Next
+}
+
+// RunFreshExpr is the Expr counterpart of [RunFresh].
+func RunFreshExpr[A any](m Expr[A]) (A, uint64) {
+	var supply FreshSupply
+	result := HandleExpr(m, FreshHandler[A](&supply))
+	return result, supply.Next
+}
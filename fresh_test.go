@@ -0,0 +1,88 @@
+// ©Hayabusa Cloud Co., Ltd. 2026. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package kont_test
+
+import (
+	"fmt"
+	"testing"
+
+	"code.hybscloud.com/kont"
+)
+
+// gensyms draws n names with prefix.
+func gensyms(prefix string, n int) kont.Eff[[]string] {
+	if n == 0 {
+		return kont.Pure([]string(nil))
+	}
+	return kont.Bind(kont.FreshName(prefix), func(s string) kont.Eff[[]string] {
+		return kont.Map(gensyms(prefix, n-1), func(rest []string) []string { return append([]string{s}, rest...) })
+	})
+}
+
+func TestRunFreshMonotonic(t *testing.T) {
+	comp := kont.Bind(kont.FreshID(), func(a uint64) kont.Eff[[2]uint64] {
+		return kont.Map(kont.FreshID(), func(b uint64) [2]uint64 { return [2]uint64{a, b} })
+	})
+	got, next := kont.RunFresh(comp)
+	if got != [2]uint64{0, 1} || next != 2 {
+		t.Fatalf("got %v next %d", got, next)
+	}
+}
+
+func TestRunFreshNames(t *testing.T) {
+	got, next := kont.RunFresh(gensyms("t", 3))
+	if fmt.Sprint(got) != "[t0 t1 t2]" || next != 3 {
+		t.Fatalf("got %v next %d", got, next)
+	}
+}
+
+func TestRunFreshExpr(t *testing.T) {
+	comp := kont.ExprBind(kont.ExprFreshID(), func(a uint64) kont.Expr[string] {
+		return kont.ExprFreshName("v")
+	})
+	got, next := kont.RunFreshExpr(comp)
+	if got != "v1" || next != 2 {
+		t.Fatalf("got %q next %d", got, next)
+	}
+}
+
+func TestFreshHandlerSharedSupply(t *testing.T) {
+	supply := &kont.FreshSupply{Next: 10}
+	a := kont.Handle(kont.FreshID(), kont.FreshHandler[uint64](supply))
+	b := kont.HandleExpr(kont.ExprFreshID(), kont.FreshHandler[uint64](supply))
+	if a != 10 || b != 11 || supply.Next != 12 {
+		t.Fatalf("a %d b %d next %d", a, b, supply.Next)
+	}
+}
+
+func TestFreshHandlerGenerator(t *testing.T) {
+	supply := &kont.FreshSupply{Gen: func(n uint64) string { return fmt.Sprintf("%08x", n+0xabc) }}
+	got := kont.Handle(kont.FreshName("id-"), kont.FreshHandler[string](supply))
+	if got != "id-00000abc" {
+		t.Fatalf("got %q", got)
+	}
+}
+
+func TestFreshInComposedRunners(t *testing.T) {
+	comp := kont.GetState(func(s int) kont.Eff[string] {
+		return kont.Bind(kont.FreshName("x"), func(a string) kont.Eff[string] {
+			return kont.Map(kont.FreshName("x"), func(b string) string { return fmt.Sprint(s, a, b) })
+		})
+	})
+	if got, _ := kont.RunStateReader[int, string](5, "env", comp); got != "5x0x1" {
+		t.Fatalf("StateReader: got %q", got)
+	}
+	if got, _ := kont.RunStateError[int, string](5, comp); !got.IsRight() {
+		t.Fatalf("StateError: got %v", got)
+	} else if v, _ := got.GetRight(); v != "5x0x1" {
+		t.Fatalf("StateError: got %q", v)
+	}
+	if got, _, _ := kont.RunStateWriter[int, string](5, comp); got != "5x0x1" {
+		t.Fatalf("StateWriter: got %q", got)
+	}
+	if got, _ := kont.RunReaderStateError[string, int, string](".", 5, comp); !got.IsRight() {
+		t.Fatalf("ReaderStateError: got %v", got)
+	}
+}
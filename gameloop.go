@@ -0,0 +1,172 @@
+// ©Hayabusa Cloud Co., Ltd. 2026. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package kont
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Fixed-timestep driver.
+// A TickLoop steps a set of computations once per tick: each runs until it
+// performs Tick, then waits for the next tick. Input pushed between ticks is
+// coalesced into one batch that every computation sees via PollInput.
+// Tick is a frame boundary owned by the loop, not a periodic timer stream:
+// nothing runs between ticks, and the loop, not a scheduler, decides when
+// the next one happens. For periodic work driven by the clock, see [Every].
+
+// Tick is the effect operation for waiting for the next tick.
+// Resumes with the fixed timestep of the loop.
+type Tick struct{}
+
+func (Tick) OpResult() time.Duration { panic("phantom") }
+
+// PollInput is the effect operation for reading the input coalesced since
+// the previous tick. Every computation stepped in the same tick receives the
+// same batch.
+type PollInput[E any] struct{}
+
+func (PollInput[E]) OpResult() []E { panic("phantom") }
+
+// AwaitTick performs Tick.
+func AwaitTick() Cont[Resumed, time.Duration] {
+	return Perform(Tick{})
+}
+
+// ExprAwaitTick is the Expr counterpart of [AwaitTick].
+func ExprAwaitTick() Expr[time.Duration] {
+	return ExprPerform(Tick{})
+}
+
+// TickLoop drives computations at a fixed timestep.
+// Push is safe for concurrent use; the other methods must be called from one
+// goroutine.
+type TickLoop[E any] struct {
+	step     time.Duration
+	dispatch func(Operation) (Resumed, bool)
+	pending  []func() (struct{}, *Suspension[struct{}])
+	live     []*Suspension[struct{}]
+	acc      time.Duration
+
+	mu     sync.Mutex
+	inputs []E
+}
+
+// NewTickLoop creates a loop with the given timestep. dispatch handles
+// operations other than Tick and PollInput; it may be nil if computations
+// perform no others. A computation whose operation dispatch short-circuits
+// is stopped. Panics if step is not positive.
+func NewTickLoop[E any](step time.Duration, dispatch func(Operation) (Resumed, bool)) *TickLoop[E] {
+	if step <= 0 {
+		panic("kont: NewTickLoop requires a positive step")
+	}
+	return &TickLoop[E]{step: step, dispatch: dispatch}
+}
+
+// Spawn adds m to the loop. It starts on the next tick.
+func (l *TickLoop[E]) Spawn(m Cont[Resumed, struct{}]) {
+	l.pending = append(l.pending, func() (struct{}, *Suspension[struct{}]) { return Step(m) })
+}
+
+// SpawnExpr adds an Expr computation to the loop. It starts on the next tick.
+func (l *TickLoop[E]) SpawnExpr(m Expr[struct{}]) {
+	l.pending = append(l.pending, func() (struct{}, *Suspension[struct{}]) { return StepExpr(m) })
+}
+
+// Push queues an input event for the next tick.
+func (l *TickLoop[E]) Push(e E) {
+	l.mu.Lock()
+	l.inputs = append(l.inputs, e)
+	l.mu.Unlock()
+}
+
+// Live returns the number of computations that have not completed.
+func (l *TickLoop[E]) Live() int {
+	return len(l.live) + len(l.pending)
+}
+
+// Tick runs one tick: newly spawned computations start, waiting ones resume
+// with the timestep, and each runs until its next Tick or completion.
+// Returns the number of computations still live.
+func (l *TickLoop[E]) Tick() int {
+	l.mu.Lock()
+	batch := l.inputs
+	l.inputs = nil
+	l.mu.Unlock()
+
+	live := l.live[:0]
+	for _, susp := range l.live {
+		_, susp = susp.Resume(l.step)
+		if susp = l.run(susp, batch); susp != nil {
+			live = append(live, susp)
+		}
+	}
+	for _, start := range l.pending {
+		_, susp := start()
+		if susp = l.run(susp, batch); susp != nil {
+			live = append(live, susp)
+		}
+	}
+	if len(live) < len(l.live) {
+		clear(l.live[len(live):])
+	}
+	l.live = live
+	clear(l.pending)
+	l.pending = l.pending[:0]
+	return len(l.live)
+}
+
+// run advances susp until it waits on Tick (returned) or completes (nil).
+func (l *TickLoop[E]) run(susp *Suspension[struct{}], batch []E) *Suspension[struct{}] {
+	for susp != nil {
+		switch op := susp.Op().(type) {
+		case Tick:
+			return susp
+		case PollInput[E]:
+			_, susp = susp.Resume(batch)
+		default:
+			if l.dispatch == nil {
+				unhandledEffect("TickLoop", op)
+			}
+			v, shouldResume := l.dispatch(op)
+			if !shouldResume {
+				susp.Discard()
+				return nil
+			}
+			_, susp = susp.Resume(v)
+		}
+	}
+	return nil
+}
+
+// Advance adds elapsed wall time and runs one tick per whole timestep
+// accumulated, carrying the remainder to the next call.
+// Returns the number of ticks run.
+func (l *TickLoop[E]) Advance(elapsed time.Duration) int {
+	l.acc += elapsed
+	n := 0
+	for l.acc >= l.step {
+		l.acc -= l.step
+		l.Tick()
+		n++
+	}
+	return n
+}
+
+// Run ticks in real time until every computation has completed or ctx is
+// done, returning ctx.Err() in the latter case.
+func (l *TickLoop[E]) Run(ctx context.Context) error {
+	ticker := time.NewTicker(l.step)
+	defer ticker.Stop()
+	for l.Tick() > 0 {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+	return nil
+}
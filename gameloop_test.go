@@ -0,0 +1,122 @@
+// ©Hayabusa Cloud Co., Ltd. 2026. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package kont_test
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"code.hybscloud.com/kont"
+)
+
+const frame = 16 * time.Millisecond
+
+// mover advances a position by its input each tick for n ticks.
+func mover(pos *int, log *[]string, name string, n int) kont.Eff[struct{}] {
+	if n == 0 {
+		return kont.Pure(struct{}{})
+	}
+	return kont.Bind(kont.Perform(kont.PollInput[int]{}), func(in []int) kont.Eff[struct{}] {
+		for _, d := range in {
+			*pos += d
+		}
+		*log = append(*log, name)
+		return kont.Then(kont.AwaitTick(), mover(pos, log, name, n-1))
+	})
+}
+
+func TestTickLoop(t *testing.T) {
+	loop := kont.NewTickLoop[int](frame, nil)
+	var a, b int
+	var log []string
+	loop.Spawn(mover(&a, &log, "a", 2))
+	loop.Spawn(mover(&b, &log, "b", 3))
+
+	loop.Push(1)
+	loop.Push(2)
+	if live := loop.Tick(); live != 2 || a != 3 || b != 3 {
+		t.Fatalf("tick 1: live %d, a %d, b %d", live, a, b)
+	}
+	if live := loop.Tick(); live != 2 || a != 3 {
+		t.Fatalf("tick 2: live %d, a %d", live, a)
+	}
+	loop.Push(10)
+	if live := loop.Tick(); live != 1 || b != 13 {
+		t.Fatalf("tick 3: live %d, b %d", live, b)
+	}
+	if live := loop.Tick(); live != 0 {
+		t.Fatalf("tick 4: live %d", live)
+	}
+	if want := []string{"a", "b", "a", "b", "b"}; !slices.Equal(log, want) {
+		t.Fatalf("got %v, want %v", log, want)
+	}
+}
+
+func TestTickLoopAdvance(t *testing.T) {
+	loop := kont.NewTickLoop[struct{}](frame, nil)
+	var total time.Duration
+	var run func(n int) kont.Eff[struct{}]
+	run = func(n int) kont.Eff[struct{}] {
+		if n == 0 {
+			return kont.Pure(struct{}{})
+		}
+		return kont.Bind(kont.AwaitTick(), func(dt time.Duration) kont.Eff[struct{}] {
+			total += dt
+			return run(n - 1)
+		})
+	}
+	loop.Spawn(run(10))
+	if n := loop.Advance(40 * time.Millisecond); n != 2 {
+		t.Fatalf("ran %d ticks, want 2", n)
+	}
+	if n := loop.Advance(10 * time.Millisecond); n != 1 || total != 2*frame {
+		t.Fatalf("ran %d ticks, total %v", n, total)
+	}
+}
+
+func TestTickLoopDispatchAndExpr(t *testing.T) {
+	loop := kont.NewTickLoop[int](frame, func(op kont.Operation) (kont.Resumed, bool) {
+		if _, ok := op.(kont.Ask[string]); ok {
+			return "cfg", true
+		}
+		return nil, false
+	})
+	var got string
+	loop.Spawn(kont.AskReader(func(s string) kont.Eff[struct{}] {
+		got = s
+		return kont.Pure(struct{}{})
+	}))
+	loop.Spawn(kont.Then(kont.Perform(kont.Get[int]{}), kont.Pure(struct{}{})))
+	loop.SpawnExpr(kont.ExprThen(kont.ExprAwaitTick(), kont.ExprReturn(struct{}{})))
+	if live := loop.Tick(); live != 1 || got != "cfg" {
+		t.Fatalf("live %d, got %q", live, got)
+	}
+	if err := loop.Run(context.Background()); err != nil || loop.Live() != 0 {
+		t.Fatalf("Run: %v, live %d", err, loop.Live())
+	}
+}
+
+func TestTickLoopRunCancelled(t *testing.T) {
+	loop := kont.NewTickLoop[int](time.Millisecond, nil)
+	var forever kont.Eff[struct{}]
+	forever = kont.Bind(kont.AwaitTick(), func(time.Duration) kont.Eff[struct{}] { return forever })
+	loop.Spawn(forever)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Millisecond)
+	defer cancel()
+	if err := loop.Run(ctx); err != context.DeadlineExceeded {
+		t.Fatalf("got %v", err)
+	}
+}
+
+func TestNewTickLoopRequiresPositiveStep(t *testing.T) {
+	defer func() {
+		if r := recover(); r != "kont: NewTickLoop requires a positive step" {
+			t.Fatalf("got panic %v", r)
+		}
+	}()
+	kont.NewTickLoop[int](0, nil)
+}
@@ -0,0 +1,163 @@
+// ©Hayabusa Cloud Co., Ltd. 2026. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package kont
+
+import "iter"
+
+// Generators.
+// A Generator turns a computation that performs Yield into a pull-based
+// sequence. Each Next steps the computation to its next Yield through the
+// stepping boundary, so no goroutine is involved.
+
+// Yield is the effect operation for emitting a value to a Generator.
+type Yield[A any] struct{ Value A }
+
+func (Yield[A]) OpResult() struct{} { panic("phantom") }
+
+// YieldValue performs Yield, suspending until the consumer pulls again.
+func YieldValue[A any](v A) Cont[Resumed, struct{}] {
+	return Perform(Yield[A]{Value: v})
+}
+
+// ExprYieldValue is the Expr counterpart of [YieldValue].
+func ExprYieldValue[A any](v A) Expr[struct{}] {
+	return ExprPerform(Yield[A]{Value: v})
+}
+
+// Generator pulls Yield values from a computation one at a time.
+// A Generator is not safe for concurrent use.
+type Generator[A any] struct {
+	start func() (struct{}, *Suspension[struct{}])
+	susp  *Suspension[struct{}]
+	done  bool
+}
+
+// NewGenerator creates a Generator over m. m does not run until the first Next.
+func NewGenerator[A any](m Cont[Resumed, struct{}]) *Generator[A] {
+	return &Generator[A]{start: func() (struct{}, *Suspension[struct{}]) { return Step(m) }}
+}
+
+// NewGeneratorExpr creates a Generator over an Expr computation.
+func NewGeneratorExpr[A any](m Expr[struct{}]) *Generator[A] {
+	return &Generator[A]{start: func() (struct{}, *Suspension[struct{}]) { return StepExpr(m) }}
+}
+
+// Next runs the computation to its next Yield and returns the yielded value
+// and true, or zero and false once the computation has completed.
+// Panics if the computation performs an operation other than Yield[A].
+func (g *Generator[A]) Next() (A, bool) {
+	var zero A
+	if g.done {
+		return zero, false
+	}
+	var susp *Suspension[struct{}]
+	if g.start != nil {
+		_, susp = g.start()
+		g.start = nil
+	} else {
+		_, susp = g.susp.Resume(struct{}{})
+	}
+	g.susp = nil
+	if susp == nil {
+		g.done = true
+		return zero, false
+	}
+	op := susp.Op()
+	y, ok := op.(Yield[A])
+	if !ok {
+		g.done = true
+		susp.Discard()
+		unhandledEffect("Generator", op)
+	}
+	g.susp = susp
+	return y.Value, true
+}
+
+// Stop abandons the computation at its current Yield. Subsequent Next
+// calls return false.
+func (g *Generator[A]) Stop() {
+	if g.susp != nil {
+		g.susp.Discard()
+		g.susp = nil
+	}
+	g.start = nil
+	g.done = true
+}
+
+// ToSeq returns an iterator over the values m yields. Each iteration runs m
+// from the start; breaking out of the loop abandons the computation.
+func ToSeq[A any](m Cont[Resumed, struct{}]) iter.Seq[A] {
+	return func(yield func(A) bool) {
+		drainGenerator(NewGenerator[A](m), yield)
+	}
+}
+
+// ToSeqExpr is the Expr counterpart of [ToSeq].
+// The Expr is evaluated destructively, so the iterator may be ranged over
+// only once.
+func ToSeqExpr[A any](m Expr[struct{}]) iter.Seq[A] {
+	return func(yield func(A) bool) {
+		drainGenerator(NewGeneratorExpr[A](m), yield)
+	}
+}
+
+func drainGenerator[A any](g *Generator[A], yield func(A) bool) {
+	for {
+		v, ok := g.Next()
+		if !ok {
+			return
+		}
+		if !yield(v) {
+			g.Stop()
+			return
+		}
+	}
+}
+
+// FromSeq returns a computation that yields every value of seq in order.
+// seq is pulled one value per Yield through iter.Pull; the pull is stopped
+// when seq is exhausted, or when the computation is abandoned at a Yield
+// (for example by Generator.Stop or Suspension.Discard).
+func FromSeq[A any](seq iter.Seq[A]) Cont[Resumed, struct{}] {
+	return func(k func(struct{}) Resumed) Resumed {
+		next, stop := iter.Pull(seq)
+		return pullYields(next, stop, k)
+	}
+}
+
+func pullYields[A any](next func() (A, bool), stop func(), k func(struct{}) Resumed) Resumed {
+	v, ok := next()
+	if !ok {
+		stop()
+		return k(struct{}{})
+	}
+	r := YieldValue(v)(func(struct{}) Resumed {
+		return pullYields(next, stop, k)
+	})
+	if s, ok := r.(effectSuspension); ok {
+		return &pullSuspension{inner: s, stop: stop}
+	}
+	return r
+}
+
+// pullSuspension is the Yield suspension of a FromSeq computation. Releasing
+// it stops the pull iterator.
+type pullSuspension struct {
+	inner effectSuspension
+	stop  func()
+}
+
+func (s *pullSuspension) Op() Operation            { return s.inner.Op() }
+func (s *pullSuspension) Resume(v Resumed) Resumed { return s.inner.Resume(v) }
+func (s *pullSuspension) site() provenance         { return s.inner.site() }
+
+func (s *pullSuspension) resumeWithError(throw Operation) Resumed {
+	return s.inner.resumeWithError(throw)
+}
+
+func (s *pullSuspension) release() {
+	s.stop()
+	s.inner.release()
+}
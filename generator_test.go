@@ -0,0 +1,116 @@
+// ©Hayabusa Cloud Co., Ltd. 2026. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package kont_test
+
+import (
+	"slices"
+	"testing"
+
+	"code.hybscloud.com/kont"
+)
+
+func fib(a, b int) kont.Eff[struct{}] {
+	return kont.Bind(kont.YieldValue(a), func(struct{}) kont.Eff[struct{}] { return fib(b, a+b) })
+}
+
+func drain[A any](g *kont.Generator[A], n int) []A {
+	var out []A
+	for range n {
+		v, ok := g.Next()
+		if !ok {
+			break
+		}
+		out = append(out, v)
+	}
+	return out
+}
+
+func TestGeneratorInfinite(t *testing.T) {
+	g := kont.NewGenerator[int](fib(0, 1))
+	if got, want := drain(g, 8), []int{0, 1, 1, 2, 3, 5, 8, 13}; !slices.Equal(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	g.Stop()
+	if _, ok := g.Next(); ok {
+		t.Fatal("Next after Stop must return false")
+	}
+}
+
+func TestGeneratorCompletes(t *testing.T) {
+	comp := kont.Then(kont.YieldValue("a"), kont.Then(kont.YieldValue("b"), kont.Pure(struct{}{})))
+	g := kont.NewGenerator[string](comp)
+	if got := drain(g, 5); !slices.Equal(got, []string{"a", "b"}) {
+		t.Fatalf("got %v", got)
+	}
+	if _, ok := g.Next(); ok {
+		t.Fatal("Next after completion must return false")
+	}
+}
+
+func TestGeneratorExpr(t *testing.T) {
+	comp := kont.ExprThen(kont.ExprYieldValue(1), kont.ExprYieldValue(2))
+	if got := drain(kont.NewGeneratorExpr[int](comp), 5); !slices.Equal(got, []int{1, 2}) {
+		t.Fatalf("got %v", got)
+	}
+}
+
+func TestGeneratorUnhandledPanic(t *testing.T) {
+	defer func() {
+		if r := recover(); unhandledIn(r) != "Generator" {
+			t.Fatalf("got panic %v", r)
+		}
+	}()
+	kont.NewGenerator[int](kont.Then(kont.Perform(kont.Get[int]{}), kont.Pure(struct{}{}))).Next()
+}
+
+func TestToSeq(t *testing.T) {
+	var got []int
+	for v := range kont.ToSeq[int](fib(0, 1)) {
+		if v > 20 {
+			break
+		}
+		got = append(got, v)
+	}
+	if want := []int{0, 1, 1, 2, 3, 5, 8, 13}; !slices.Equal(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	expr := kont.ExprThen(kont.ExprYieldValue("x"), kont.ExprYieldValue("y"))
+	if got := slices.Collect(kont.ToSeqExpr[string](expr)); !slices.Equal(got, []string{"x", "y"}) {
+		t.Fatalf("got %v", got)
+	}
+}
+
+func TestFromSeqRoundTrip(t *testing.T) {
+	src := slices.Values([]int{3, 1, 4, 1, 5})
+	doubled := kont.Bind(kont.FromSeq(src), func(struct{}) kont.Eff[struct{}] { return kont.YieldValue(-1) })
+	got := slices.Collect(kont.ToSeq[int](doubled))
+	if want := []int{3, 1, 4, 1, 5, -1}; !slices.Equal(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+}
+
+func TestFromSeqStopReleasesPull(t *testing.T) {
+	stopped := false
+	src := func(yield func(int) bool) {
+		defer func() { stopped = true }()
+		for i := 0; ; i++ {
+			if !yield(i) {
+				return
+			}
+		}
+	}
+	i := 0
+	for v := range kont.ToSeq[int](kont.FromSeq(src)) {
+		if i != v {
+			t.Fatalf("got %d, want %d", v, i)
+		}
+		if i++; i == 3 {
+			break
+		}
+	}
+	if !stopped {
+		t.Fatal("pull iterator not stopped after break")
+	}
+}
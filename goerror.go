@@ -0,0 +1,74 @@
+// ©Hayabusa Cloud Co., Ltd. 2026. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package kont
+
+import "errors"
+
+// Go error interop.
+// The Err family fixes the Error effect payload to Go's error interface so
+// thrown errors keep their wrap chains and can be inspected with errors.Is
+// and errors.As, both in catch handlers and in the final result.
+
+// ThrowErr performs Throw with a Go error payload.
+func ThrowErr[A any](err error) Cont[Resumed, A] {
+	return ThrowError[error, A](err)
+}
+
+// ExprThrowErr is the Expr counterpart of [ThrowErr].
+func ExprThrowErr[A any](err error) Expr[A] {
+	return ExprThrowError[error, A](err)
+}
+
+// CatchErr wraps a computation with a handler for Go errors thrown by
+// [ThrowErr]. Like [CatchError], other effects in body and handler are not
+// handled.
+func CatchErr[A any](body Cont[Resumed, A], handler func(error) Cont[Resumed, A]) Cont[Resumed, A] {
+	return CatchError(body, handler)
+}
+
+// CatchErrAs wraps a computation with a handler for thrown errors that match
+// T under errors.As. Errors that do not match are rethrown unchanged.
+func CatchErrAs[T error, A any](body Cont[Resumed, A], handler func(T) Cont[Resumed, A]) Cont[Resumed, A] {
+	return CatchError(body, func(err error) Cont[Resumed, A] {
+		var target T
+		if errors.As(err, &target) {
+			return handler(target)
+		}
+		return ThrowErr[A](err)
+	})
+}
+
+// RunErr runs a computation throwing Go errors and returns its value and
+// the thrown error, if any. The error is returned as thrown, so errors.Is
+// and errors.As see its full chain.
+func RunErr[A any](m Cont[Resumed, A]) (A, error) {
+	return eitherErr(RunError[error, A](m))
+}
+
+// RunErrExpr is the Expr counterpart of [RunErr].
+func RunErrExpr[A any](m Expr[A]) (A, error) {
+	return eitherErr(RunErrorExpr[error, A](m))
+}
+
+// EitherErr converts an Either with an error Left into Go's (value, error)
+// shape. A Left holding a nil error is reported as [ErrNilThrow].
+func EitherErr[A any](e Either[error, A]) (A, error) {
+	return eitherErr(e)
+}
+
+// ErrNilThrow reports a Throw whose Go error payload was nil.
+var ErrNilThrow = errors.New("kont: nil error thrown")
+
+func eitherErr[A any](e Either[error, A]) (A, error) {
+	if err, ok := e.GetLeft(); ok {
+		if err == nil {
+			err = ErrNilThrow
+		}
+		var zero A
+		return zero, err
+	}
+	a, _ := e.GetRight()
+	return a, nil
+}
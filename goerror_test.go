@@ -0,0 +1,65 @@
+// ©Hayabusa Cloud Co., Ltd. 2026. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package kont_test
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"testing"
+
+	"code.hybscloud.com/kont"
+)
+
+var errQuota = errors.New("quota exceeded")
+
+func TestRunErrPreservesChain(t *testing.T) {
+	comp := kont.ThrowErr[int](fmt.Errorf("upload: %w", errQuota))
+	_, err := kont.RunErr(comp)
+	if !errors.Is(err, errQuota) {
+		t.Fatalf("got %v, want wrapping errQuota", err)
+	}
+	if v, err := kont.RunErr(kont.Pure(3)); v != 3 || err != nil {
+		t.Fatalf("got %d, %v", v, err)
+	}
+}
+
+func TestCatchErrAsMatches(t *testing.T) {
+	thrown := &fs.PathError{Op: "open", Path: "/x", Err: fs.ErrNotExist}
+	comp := kont.CatchErrAs(kont.ThrowErr[string](fmt.Errorf("load: %w", thrown)),
+		func(pe *fs.PathError) kont.Eff[string] { return kont.Pure(pe.Path) })
+	if v, err := kont.RunErr(comp); err != nil || v != "/x" {
+		t.Fatalf("got %q, %v", v, err)
+	}
+}
+
+func TestCatchErrAsRethrows(t *testing.T) {
+	comp := kont.CatchErrAs(kont.ThrowErr[string](errQuota),
+		func(*fs.PathError) kont.Eff[string] { return kont.Pure("caught") })
+	if _, err := kont.RunErr(comp); err != errQuota {
+		t.Fatalf("got %v, want errQuota rethrown", err)
+	}
+}
+
+func TestCatchErr(t *testing.T) {
+	comp := kont.CatchErr(kont.ThrowErr[int](errQuota), func(err error) kont.Eff[int] {
+		if errors.Is(err, errQuota) {
+			return kont.Pure(1)
+		}
+		return kont.Pure(0)
+	})
+	if v, err := kont.RunErr(comp); err != nil || v != 1 {
+		t.Fatalf("got %d, %v", v, err)
+	}
+}
+
+func TestRunErrExprNilThrow(t *testing.T) {
+	if _, err := kont.RunErrExpr(kont.ExprThrowErr[int](nil)); !errors.Is(err, kont.ErrNilThrow) {
+		t.Fatalf("got %v, want ErrNilThrow", err)
+	}
+	if _, err := kont.EitherErr(kont.Left[error, int](errQuota)); err != errQuota {
+		t.Fatalf("got %v", err)
+	}
+}
@@ -0,0 +1,84 @@
+// ©Hayabusa Cloud Co., Ltd. 2026. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package kont
+
+import "sync"
+
+// Groups.
+// A Group collects the results of a dynamic number of children spawned into
+// the enclosing scope, in spawn order, so a computation need not keep its
+// own result slots. It is the effect-world analogue of errgroup over
+// RunScope: each Go performs Spawn, and Wait performs Join.
+
+// Group collects the results of children of one scope. Go may run in the
+// scope computation or in its children; Wait, like Join, only in the scope
+// computation.
+type Group[A any] struct {
+	failFast bool
+	mu       sync.Mutex
+	results  []Either[error, A]
+}
+
+// NewGroup creates a Group. With failFast, the first child failure cancels
+// the scope, as for a child started by Spawn; otherwise a failure is only
+// recorded and the other children continue.
+func NewGroup[A any](failFast bool) *Group[A] {
+	return &Group[A]{failFast: failFast}
+}
+
+// Go performs Spawn starting m as a child of the enclosing scope. Its slot
+// is reserved when Go runs.
+func (g *Group[A]) Go(m Cont[Resumed, A]) Cont[Resumed, struct{}] {
+	return Perform(Spawn{member: groupChild[A]{g: g, m: m}})
+}
+
+// ExprGo is the Expr counterpart of [Group.Go].
+func (g *Group[A]) ExprGo(m Cont[Resumed, A]) Expr[struct{}] {
+	return ExprPerform(Spawn{member: groupChild[A]{g: g, m: m}})
+}
+
+// Wait performs Join and resumes with one result per Go so far, in order:
+// Right with the child's value, or Left with its error. A child cancelled
+// with the scope has a [*CancelledError]; one whose operation dispatch
+// short-circuited with a value that is not an error has Left(nil).
+func (g *Group[A]) Wait() Cont[Resumed, []Either[error, A]] {
+	return Map(JoinScope(), func(error) []Either[error, A] { return g.snapshot() })
+}
+
+// ExprWait is the Expr counterpart of [Group.Wait].
+func (g *Group[A]) ExprWait() Expr[[]Either[error, A]] {
+	return ExprMap(ExprJoinScope(), func(error) []Either[error, A] { return g.snapshot() })
+}
+
+func (g *Group[A]) snapshot() []Either[error, A] {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return append([]Either[error, A](nil), g.results...)
+}
+
+func (g *Group[A]) set(i int, r Either[error, A]) {
+	g.mu.Lock()
+	g.results[i] = r
+	g.mu.Unlock()
+}
+
+// groupChild is the groupMember for one Go.
+type groupChild[A any] struct {
+	g *Group[A]
+	m Cont[Resumed, A]
+}
+
+func (c groupChild[A]) start() (Cont[Resumed, error], func(error), bool) {
+	g := c.g
+	g.mu.Lock()
+	i := len(g.results)
+	g.results = append(g.results, Either[error, A]{})
+	g.mu.Unlock()
+	body := Map(c.m, func(a A) error {
+		g.set(i, Right[error](a))
+		return nil
+	})
+	return body, func(err error) { g.set(i, Left[error, A](err)) }, g.failFast
+}
@@ -0,0 +1,63 @@
+// ©Hayabusa Cloud Co., Ltd. 2026. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package kont_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+
+	"code.hybscloud.com/kont"
+)
+
+// failWith short-circuits a scope child with its error.
+type failWith struct{ err error }
+
+func (failWith) OpResult() int { panic("phantom") }
+
+func groupDispatch(op kont.Operation) (kont.Resumed, bool) {
+	switch op := op.(type) {
+	case failWith:
+		return op.err, false
+	case block:
+		return struct{}{}, true
+	}
+	panic("unexpected operation")
+}
+
+func TestGroupCollectsInOrder(t *testing.T) {
+	g := kont.NewGroup[int](false)
+	comp := kont.Then(g.Go(kont.Pure(1)),
+		kont.Then(g.Go(kont.Perform(failWith{io.EOF})),
+			kont.Then(g.Go(kont.Then(kont.Perform(block{}), kont.Pure(3))), g.Wait())))
+	rs, err := kont.RunScope(comp, groupDispatch)
+	if err != nil || len(rs) != 3 {
+		t.Fatalf("got %v, %v", rs, err)
+	}
+	if v, ok := rs[0].GetRight(); !ok || v != 1 {
+		t.Fatalf("rs[0] = %v", rs[0])
+	}
+	if e, ok := rs[1].GetLeft(); !ok || e != io.EOF {
+		t.Fatalf("rs[1] = %v", rs[1])
+	}
+	if v, ok := rs[2].GetRight(); !ok || v != 3 {
+		t.Fatalf("rs[2] = %v", rs[2])
+	}
+}
+
+func TestGroupFailFast(t *testing.T) {
+	g := kont.NewGroup[int](true)
+	comp := kont.ExprThen(g.ExprGo(kont.Perform(failWith{io.EOF})),
+		kont.ExprThen(g.ExprWait(),
+			kont.ExprThen(g.ExprGo(kont.Then(kont.Perform(block{}), kont.Pure(2))), g.ExprWait())))
+	rs, err := kont.RunScopeExpr(comp, groupDispatch)
+	if !errors.Is(err, io.EOF) || len(rs) != 2 {
+		t.Fatalf("got %v, %v", rs, err)
+	}
+	if e, ok := rs[1].GetLeft(); !ok || !errors.Is(e, kont.ErrCancelled) || !errors.Is(e, context.Canceled) {
+		t.Fatalf("rs[1] = %v, want cancelled", rs[1])
+	}
+}
@@ -0,0 +1,138 @@
+// ©Hayabusa Cloud Co., Ltd. 2026. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package kont
+
+import (
+	"encoding"
+	"encoding/binary"
+	"hash"
+	"hash/fnv"
+	"math"
+	"reflect"
+)
+
+// Determinism hashing.
+// A hashing handler folds every dispatch into a running digest so that two
+// runs of the same program can be compared for divergence in O(1) space.
+
+// hashingHandler wraps a handler and hashes each dispatch it serves.
+type hashingHandler[H Handler[H, R], R any] struct {
+	inner H
+	sum   hash.Hash64
+	buf   []byte
+}
+
+// Dispatch implements Handler by delegating to the inner handler and folding
+// (operation type, resume value, resume flag) into the digest. The
+// operation itself is not hashed: operations such as Modify and Catch hold
+// funcs, which have no identity that survives across runs.
+func (h *hashingHandler[H, R]) Dispatch(op Operation) (Resumed, bool) {
+	v, shouldResume := h.inner.Dispatch(op)
+	b := appendHashString(h.buf[:0], reflect.TypeOf(op).String())
+	b = appendHashValue(b, reflect.ValueOf(v))
+	if shouldResume {
+		b = append(b, 1)
+	} else {
+		b = append(b, 0)
+	}
+	h.sum.Write(b)
+	h.buf = b
+	return v, shouldResume
+}
+
+// appendHashString appends s, length-prefixed.
+func appendHashString(b []byte, s string) []byte {
+	b = binary.AppendUvarint(b, uint64(len(s)))
+	return append(b, s...)
+}
+
+// appendHashValue appends a binary encoding of v: its type, then its
+// MarshalBinary form if it has one, otherwise its contents by kind.
+// Pointers contribute only whether they are nil, maps only their length,
+// and channels and funcs only their type, so the encoding never depends on
+// addresses.
+func appendHashValue(b []byte, v reflect.Value) []byte {
+	if !v.IsValid() {
+		return append(b, 0)
+	}
+	b = appendHashString(b, v.Type().String())
+	if v.CanInterface() && v.Type().Implements(binaryMarshalerType) {
+		if v.Kind() != reflect.Pointer || !v.IsNil() {
+			if data, err := v.Interface().(encoding.BinaryMarshaler).MarshalBinary(); err == nil {
+				return appendHashString(b, string(data))
+			}
+		}
+	}
+	switch v.Kind() {
+	case reflect.Bool:
+		if v.Bool() {
+			return append(b, 1)
+		}
+		return append(b, 0)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return binary.AppendVarint(b, v.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return binary.AppendUvarint(b, v.Uint())
+	case reflect.Float32, reflect.Float64:
+		return binary.LittleEndian.AppendUint64(b, math.Float64bits(v.Float()))
+	case reflect.Complex64, reflect.Complex128:
+		c := v.Complex()
+		b = binary.LittleEndian.AppendUint64(b, math.Float64bits(real(c)))
+		return binary.LittleEndian.AppendUint64(b, math.Float64bits(imag(c)))
+	case reflect.String:
+		return appendHashString(b, v.String())
+	case reflect.Array, reflect.Slice:
+		b = binary.AppendUvarint(b, uint64(v.Len()))
+		for i := range v.Len() {
+			b = appendHashValue(b, v.Index(i))
+		}
+	case reflect.Struct:
+		for i := range v.NumField() {
+			b = appendHashValue(b, v.Field(i))
+		}
+	case reflect.Interface:
+		return appendHashValue(b, v.Elem())
+	case reflect.Pointer:
+		if v.IsNil() {
+			return append(b, 0)
+		}
+		return append(b, 1)
+	case reflect.Map:
+		return binary.AppendUvarint(b, uint64(v.Len()))
+	}
+	return b
+}
+
+var binaryMarshalerType = reflect.TypeFor[encoding.BinaryMarshaler]()
+
+// HashingHandler wraps inner so that every dispatch is folded into a 64-bit
+// FNV-1a digest of the operation's type, the resume value and whether the
+// dispatch resumed. Resume values are encoded in binary: through
+// MarshalBinary when they implement encoding.BinaryMarshaler, otherwise
+// field by field; pointers, maps, channels and funcs contribute no
+// addresses or contents beyond nil-ness and length, so digests compare
+// across processes.
+// Returns a concrete handler and a function to retrieve the current digest.
+func HashingHandler[R any, H Handler[H, R]](inner H) (*hashingHandler[H, R], func() uint64) {
+	h := &hashingHandler[H, R]{inner: inner, sum: fnv.New64a()}
+	return h, h.sum.Sum64
+}
+
+// RunHashed runs a computation with h and returns its result together with
+// the determinism digest of every dispatch. Equal digests for two runs mean
+// they observed the same operation types and resume values in the same
+// order.
+func RunHashed[H Handler[H, R], R any](m Cont[Resumed, R], h H) (R, uint64) {
+	hh, sum := HashingHandler[R](h)
+	result := Handle(m, hh)
+	return result, sum()
+}
+
+// RunHashedExpr is the Expr counterpart of [RunHashed].
+func RunHashedExpr[H Handler[H, R], R any](m Expr[R], h H) (R, uint64) {
+	hh, sum := HashingHandler[R](h)
+	result := HandleExpr(m, hh)
+	return result, sum()
+}
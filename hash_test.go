@@ -0,0 +1,116 @@
+// ©Hayabusa Cloud Co., Ltd. 2026. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package kont_test
+
+import (
+	"hash/fnv"
+	"testing"
+	"time"
+
+	"code.hybscloud.com/kont"
+)
+
+func counterProgram() kont.Eff[int] {
+	return kont.GetState(func(s int) kont.Eff[int] {
+		return kont.PutState(s+1, kont.Perform(kont.Get[int]{}))
+	})
+}
+
+func TestRunHashedDeterministic(t *testing.T) {
+	h1, _ := kont.StateHandler[int, int](0)
+	r1, d1 := kont.RunHashed(counterProgram(), h1)
+	h2, _ := kont.StateHandler[int, int](0)
+	r2, d2 := kont.RunHashed(counterProgram(), h2)
+	if r1 != r2 || d1 != d2 {
+		t.Fatalf("same run diverged: %d/%x vs %d/%x", r1, d1, r2, d2)
+	}
+}
+
+func TestRunHashedDetectsDivergence(t *testing.T) {
+	h1, _ := kont.StateHandler[int, int](0)
+	_, d1 := kont.RunHashed(counterProgram(), h1)
+	h2, _ := kont.StateHandler[int, int](5) // different resume values
+	_, d2 := kont.RunHashed(counterProgram(), h2)
+	if d1 == d2 {
+		t.Fatal("different resume values must change the digest")
+	}
+}
+
+func TestRunHashedShortCircuitFlag(t *testing.T) {
+	resume := kont.HandleFunc[int](func(kont.Operation) (kont.Resumed, bool) { return 0, true })
+	stop := kont.HandleFunc[int](func(kont.Operation) (kont.Resumed, bool) { return 0, false })
+	_, d1 := kont.RunHashed(kont.Perform(kont.Get[int]{}), resume)
+	_, d2 := kont.RunHashed(kont.Perform(kont.Get[int]{}), stop)
+	if d1 == d2 {
+		t.Fatal("short-circuit must change the digest")
+	}
+}
+
+func TestRunHashedPureIsEmptyDigest(t *testing.T) {
+	h, _ := kont.StateHandler[int, int](0)
+	_, d := kont.RunHashed(kont.Pure(1), h)
+	if empty := fnv.New64a().Sum64(); d != empty {
+		t.Fatalf("pure run digest %x, want empty digest %x", d, empty)
+	}
+}
+
+func TestRunHashedExpr(t *testing.T) {
+	comp := kont.ExprBind(kont.ExprPerform(kont.Get[int]{}), func(s int) kont.Expr[int] {
+		return kont.ExprThen(kont.ExprPerform(kont.Put[int]{Value: s + 1}), kont.ExprPerform(kont.Get[int]{}))
+	})
+	h1, _ := kont.StateHandler[int, int](0)
+	r, d := kont.RunHashedExpr(comp, h1)
+	h2, _ := kont.StateHandler[int, int](0)
+	_, dc := kont.RunHashed(counterProgram(), h2)
+	if r != 1 || d != dc {
+		t.Fatalf("Expr and Cont runs of the same program diverged: %x vs %x", d, dc)
+	}
+}
+
+func TestHashingHandlerInfersHandler(t *testing.T) {
+	h, _ := kont.StateHandler[int, int](0)
+	hh, sum := kont.HashingHandler[int](h)
+	before := sum()
+	if got := kont.Handle(counterProgram(), hh); got != 1 {
+		t.Fatalf("got %d, want 1", got)
+	}
+	if sum() == before {
+		t.Fatal("dispatches must change the digest")
+	}
+}
+
+func TestRunHashedIgnoresClosureIdentity(t *testing.T) {
+	run := func(n int) uint64 {
+		step := n // a fresh closure per run
+		prog := kont.ModifyState(func(s int) int { return s + step }, func(s int) kont.Eff[*time.Time] {
+			return kont.Perform(kont.Ask[*time.Time]{})
+		})
+		at := time.Unix(int64(n), 0) // a fresh pointer per run
+		h := kont.HandleFunc[*time.Time](func(op kont.Operation) (kont.Resumed, bool) {
+			switch op := op.(type) {
+			case kont.Modify[int]:
+				return op.F(0), true
+			}
+			return &at, true
+		})
+		_, d := kont.RunHashed(prog, h)
+		return d
+	}
+	if d1, d2 := run(1), run(1); d1 != d2 {
+		t.Fatalf("identical runs diverged: %x vs %x", d1, d2)
+	}
+	if run(1) == run(2) {
+		t.Fatal("different Modify results must change the digest")
+	}
+}
+
+func BenchmarkHashingHandler(b *testing.B) {
+	h, _ := kont.StateHandler[int, int](0)
+	hh, _ := kont.HashingHandler[int](h)
+	b.ReportAllocs()
+	for b.Loop() {
+		hh.Dispatch(kont.Get[int]{})
+	}
+}
@@ -0,0 +1,73 @@
+// ©Hayabusa Cloud Co., Ltd. 2026. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package kont
+
+import "time"
+
+// Hedged dispatch.
+// For idempotent operations, a second dispatch issued after a delay caps
+// tail latency: whichever attempt returns first resumes the computation.
+
+// hedgeOpsHandler wraps a handler and hedges designated dispatches.
+type hedgeOpsHandler[H Handler[H, R], R any] struct {
+	inner H
+	delay time.Duration
+	hedge func(Operation) bool
+}
+
+// hedgeResult carries one attempt's outcome, including a recovered panic.
+type hedgeResult struct {
+	v            Resumed
+	shouldResume bool
+	panicked     any
+	didPanic     bool
+}
+
+// Dispatch implements Handler. Operations accepted by hedge are dispatched on
+// a goroutine; if no result arrives within delay, a second attempt is issued
+// and the first to finish wins. Other operations are delegated directly.
+func (h *hedgeOpsHandler[H, R]) Dispatch(op Operation) (Resumed, bool) {
+	if !h.hedge(op) {
+		return h.inner.Dispatch(op)
+	}
+	results := make(chan hedgeResult, 2)
+	go h.attempt(op, results)
+	timer := time.NewTimer(h.delay)
+	defer timer.Stop()
+	var r hedgeResult
+	select {
+	case r = <-results:
+	case <-timer.C:
+		go h.attempt(op, results)
+		r = <-results
+	}
+	if r.didPanic {
+		panic(r.panicked)
+	}
+	return r.v, r.shouldResume
+}
+
+func (h *hedgeOpsHandler[H, R]) attempt(op Operation, results chan<- hedgeResult) {
+	var r hedgeResult
+	defer func() {
+		if p := recover(); p != nil {
+			r = hedgeResult{panicked: p, didPanic: true}
+		}
+		results <- r
+	}()
+	r.v, r.shouldResume = h.inner.Dispatch(op)
+}
+
+// HedgeOps wraps inner so that operations for which hedge reports true are
+// dispatched a second time when the first attempt has not returned within
+// delay; the first result wins. The losing attempt cannot be preempted: it
+// runs to completion and its result is discarded.
+//
+// Hedge only idempotent operations, and only with an inner handler that is
+// safe for concurrent use, since both attempts dispatch concurrently.
+// Returns a concrete handler.
+func HedgeOps[R any, H Handler[H, R]](inner H, delay time.Duration, hedge func(Operation) bool) *hedgeOpsHandler[H, R] {
+	return &hedgeOpsHandler[H, R]{inner: inner, delay: delay, hedge: hedge}
+}
@@ -0,0 +1,79 @@
+// ©Hayabusa Cloud Co., Ltd. 2026. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package kont_test
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"code.hybscloud.com/kont"
+)
+
+func isGet(op kont.Operation) bool {
+	_, ok := op.(kont.Get[int])
+	return ok
+}
+
+func TestHedgeOpsSecondAttemptWins(t *testing.T) {
+	var calls atomic.Int32
+	release := make(chan struct{})
+	defer close(release)
+	inner := kont.HandleFunc[int](func(kont.Operation) (kont.Resumed, bool) {
+		if calls.Add(1) == 1 {
+			<-release // first attempt hangs
+			return 1, true
+		}
+		return 2, true
+	})
+	h := kont.HedgeOps[int](inner, time.Millisecond, isGet)
+	if got := kont.Handle(kont.Perform(kont.Get[int]{}), h); got != 2 {
+		t.Fatalf("got %d, want hedged result 2", got)
+	}
+	if calls.Load() != 2 {
+		t.Fatalf("got %d attempts, want 2", calls.Load())
+	}
+}
+
+func TestHedgeOpsFastFirstAttempt(t *testing.T) {
+	var calls atomic.Int32
+	inner := kont.HandleFunc[int](func(kont.Operation) (kont.Resumed, bool) {
+		calls.Add(1)
+		return 1, true
+	})
+	h := kont.HedgeOps[int](inner, time.Minute, isGet)
+	if got := kont.Handle(kont.Perform(kont.Get[int]{}), h); got != 1 {
+		t.Fatalf("got %d, want 1", got)
+	}
+	if calls.Load() != 1 {
+		t.Fatalf("got %d attempts, want 1", calls.Load())
+	}
+}
+
+func TestHedgeOpsNotDesignated(t *testing.T) {
+	var calls atomic.Int32
+	inner := kont.HandleFunc[int](func(kont.Operation) (kont.Resumed, bool) {
+		calls.Add(1)
+		time.Sleep(5 * time.Millisecond)
+		return struct{}{}, true
+	})
+	h := kont.HedgeOps[int](inner, time.Nanosecond, isGet)
+	kont.Handle(kont.Then(kont.Perform(kont.Put[int]{Value: 1}), kont.Pure(0)), h)
+	if calls.Load() != 1 {
+		t.Fatalf("got %d attempts, want 1 for a non-hedged op", calls.Load())
+	}
+}
+
+func TestHedgeOpsPanicPropagates(t *testing.T) {
+	inner := kont.HandleFunc[int](func(kont.Operation) (kont.Resumed, bool) {
+		panic("remote down")
+	})
+	defer func() {
+		if r := recover(); r != "remote down" {
+			t.Fatalf("got panic %v", r)
+		}
+	}()
+	kont.Handle(kont.Perform(kont.Get[int]{}), kont.HedgeOps[int](inner, time.Minute, isGet))
+}
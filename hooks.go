@@ -0,0 +1,236 @@
+// ©Hayabusa Cloud Co., Ltd. 2026. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package kont
+
+import "fmt"
+
+// Evaluation hooks.
+// HandleExprHooked runs the same evaluator as HandleExpr with callbacks
+// around every frame, for coverage-guided fuzzing and custom debuggers.
+// The hooks live in a separate copy of the evaluation loop, so HandleExpr
+// and the other runners pay nothing for them.
+
+// FrameKind identifies the kind of frame an evaluation hook observes.
+type FrameKind uint8
+
+const (
+	FrameBind   FrameKind = iota + 1 // *BindFrame
+	FrameMap                         // *MapFrame
+	FrameThen                        // *ThenFrame
+	FrameUnwind                      // *UnwindFrame
+	FrameEffect                      // *EffectFrame
+	FrameReturn                      // ReturnFrame ending the evaluation
+	FrameCustom                      // User frame implementing Unwind
+)
+
+var frameKindNames = [...]string{
+	FrameBind:   "Bind",
+	FrameMap:    "Map",
+	FrameThen:   "Then",
+	FrameUnwind: "Unwind",
+	FrameEffect: "Effect",
+	FrameReturn: "Return",
+	FrameCustom: "Custom",
+}
+
+func (k FrameKind) String() string {
+	if int(k) < len(frameKindNames) && frameKindNames[k] != "" {
+		return frameKindNames[k]
+	}
+	return fmt.Sprintf("FrameKind(%d)", k)
+}
+
+// EvalHooks observes frame evaluation. Either field may be nil.
+// Hooks see the current value only; frames may already be recycled when
+// After runs, so they are not passed.
+type EvalHooks struct {
+	// Before is called with the value flowing into each frame.
+	Before func(kind FrameKind, current Erased)
+	// After is called with the value a frame produced. For an effect frame
+	// it is the resumed value. After is not called for a frame that ends
+	// the evaluation, such as a short-circuiting effect; the final
+	// ReturnFrame is reported to Before only.
+	After func(kind FrameKind, current Erased)
+}
+
+func (hk *EvalHooks) before(f Frame, current Erased) FrameKind {
+	kind := frameKindOf(f)
+	if kind != FrameReturn && hk.Before != nil {
+		hk.Before(kind, current)
+	}
+	return kind
+}
+
+func (hk *EvalHooks) after(kind FrameKind, current Erased) {
+	if kind != FrameReturn && hk.After != nil {
+		hk.After(kind, current)
+	}
+}
+
+func (hk *EvalHooks) returned(current Erased) {
+	if hk.Before != nil {
+		hk.Before(FrameReturn, current)
+	}
+}
+
+func frameKindOf(f Frame) FrameKind {
+	switch f.(type) {
+	case ReturnFrame:
+		return FrameReturn
+	case *BindFrame[Erased, Erased]:
+		return FrameBind
+	case *MapFrame[Erased, Erased]:
+		return FrameMap
+	case *ThenFrame[Erased, Erased]:
+		return FrameThen
+	case *UnwindFrame:
+		return FrameUnwind
+	case *EffectFrame[Erased]:
+		return FrameEffect
+	}
+	return FrameCustom
+}
+
+// HandleExprHooked evaluates m with handler h like [HandleExpr], invoking
+// hooks around every frame.
+func HandleExprHooked[H Handler[H, R], R any](m Expr[R], h H, hooks EvalHooks) R {
+	return evalFramesHooked(Erased(m.Value), m.Frame, handlerProcessor[H, R]{h: h}, &hooks)
+}
+
+// evalFramesHooked is evalFrames reporting every frame to hk, which must be
+// non-nil.
+func evalFramesHooked[P frameProcessor[P, R], R any](current Erased, frame Frame, p P, hk *EvalHooks) R {
+	owns := p.ownsFrames()
+	for {
+		// Flatten chained frames
+		for {
+			cf, ok := frame.(*chainedFrame)
+			if !ok {
+				break
+			}
+			if nested, ok := cf.first.(*chainedFrame); ok {
+				rest := cf.rest
+				releaseChain(cf)
+				first := nested.first
+				nrest := nested.rest
+				releaseChain(nested)
+				frame = chainFromPool(first, chainFromPool(nrest, rest))
+				continue
+			}
+			kind := hk.before(cf.first, current)
+			switch f := cf.first.(type) {
+			case ReturnFrame:
+				frame = cf.rest
+				releaseChain(cf)
+			case *BindFrame[Erased, Erased]:
+				next := f.F(current)
+				current = Erased(next.Value)
+				fNext := f.Next
+				rest := cf.rest
+				releaseChain(cf)
+				if owns {
+					releaseBindFrame(f)
+				}
+				frame = chainFromPool(chainFromPool(next.Frame, fNext), rest)
+			case *MapFrame[Erased, Erased]:
+				current = f.F(current)
+				fNext := f.Next
+				rest := cf.rest
+				releaseChain(cf)
+				frame = chainFromPool(fNext, rest)
+			case *ThenFrame[Erased, Erased]:
+				current = f.Second.Value
+				secondFrame := f.Second.Frame
+				fNext := f.Next
+				rest := cf.rest
+				releaseChain(cf)
+				if owns {
+					releaseThenFrame(f)
+				}
+				frame = chainFromPool(chainFromPool(secondFrame, fNext), rest)
+			case *UnwindFrame:
+				nextCurrent, nextFrame := f.Unwind(f.Data1, f.Data2, f.Data3, current)
+				rest := cf.rest
+				releaseChain(cf)
+				if owns {
+					releaseUnwindFrame(f)
+				}
+				current = nextCurrent
+				frame = chainFromPool(nextFrame, rest)
+			case *EffectFrame[Erased]:
+				rest := cf.rest
+				releaseChain(cf)
+				newCurrent, newFrame, result, ok := p.processEffect(f, chainFromPool(f.Next, rest))
+				if !ok {
+					return result
+				}
+				current = newCurrent
+				frame = newFrame
+			default:
+				if u, ok := f.(interface{ Unwind(Erased) (Erased, Frame) }); ok {
+					var next Frame
+					current, next = u.Unwind(current)
+					rest := cf.rest
+					releaseChain(cf)
+					frame = chainFromPool(next, rest)
+					hk.after(kind, current)
+					continue
+				}
+				panic("kont: frame type does not implement Unwind")
+			}
+			hk.after(kind, current)
+			break
+		}
+		if _, ok := frame.(*chainedFrame); ok {
+			continue
+		}
+
+		kind := hk.before(frame, current)
+		switch f := frame.(type) {
+		case ReturnFrame:
+			hk.returned(current)
+			return p.processReturn(current)
+		case *BindFrame[Erased, Erased]:
+			next := f.F(current)
+			current = Erased(next.Value)
+			fNext := f.Next
+			if owns {
+				releaseBindFrame(f)
+			}
+			frame = chainFromPool(next.Frame, fNext)
+		case *MapFrame[Erased, Erased]:
+			current = f.F(current)
+			frame = f.Next
+		case *ThenFrame[Erased, Erased]:
+			current = f.Second.Value
+			secondFrame := f.Second.Frame
+			fNext := f.Next
+			if owns {
+				releaseThenFrame(f)
+			}
+			frame = chainFromPool(secondFrame, fNext)
+		case *UnwindFrame:
+			current, frame = f.Unwind(f.Data1, f.Data2, f.Data3, current)
+			if owns {
+				releaseUnwindFrame(f)
+			}
+		case *EffectFrame[Erased]:
+			newCurrent, newFrame, result, ok := p.processEffect(f, f.Next)
+			if !ok {
+				return result
+			}
+			current = newCurrent
+			frame = newFrame
+		default:
+			if u, ok := frame.(interface{ Unwind(Erased) (Erased, Frame) }); ok {
+				current, frame = u.Unwind(current)
+				hk.after(kind, current)
+				continue
+			}
+			panic("kont: frame type does not implement Unwind")
+		}
+		hk.after(kind, current)
+	}
+}
@@ -0,0 +1,55 @@
+// ©Hayabusa Cloud Co., Ltd. 2026. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package kont_test
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+
+	"code.hybscloud.com/kont"
+)
+
+func TestHandleExprHooked(t *testing.T) {
+	prog := kont.ExprBind(kont.ExprPerform(kont.Get[int]{}), func(n int) kont.Expr[int] {
+		return kont.ExprThen(kont.ExprPerform(kont.Put[int]{Value: n + 1}),
+			kont.ExprMap(kont.ExprReturn(n), func(x int) int { return x * 10 }))
+	})
+	var trace []string
+	hooks := kont.EvalHooks{
+		Before: func(k kont.FrameKind, v kont.Erased) { trace = append(trace, fmt.Sprintf("%v<%v", k, v)) },
+		After:  func(k kont.FrameKind, v kont.Erased) { trace = append(trace, fmt.Sprintf("%v>%v", k, v)) },
+	}
+	h, get := kont.StateHandler[int, int](4)
+	if got := kont.HandleExprHooked(prog, h, hooks); got != 40 {
+		t.Fatalf("got %d", got)
+	}
+	if get() != 5 {
+		t.Fatalf("state %d", get())
+	}
+	want := []string{
+		"Effect<0", "Effect>4", "Bind<4", "Bind>0",
+		"Effect<0", "Effect>{}", "Then<{}", "Then>40", "Return<40",
+	}
+	if !slices.Equal(trace, want) {
+		t.Fatalf("got %v, want %v", trace, want)
+	}
+}
+
+func TestHandleExprHookedNil(t *testing.T) {
+	prog := kont.ExprMap(kont.ExprPerform(kont.Ask[int]{}), func(x int) int { return x + 1 })
+	if got := kont.HandleExprHooked(prog, kont.ReaderHandler[int, int](2), kont.EvalHooks{}); got != 3 {
+		t.Fatalf("got %d", got)
+	}
+}
+
+func TestFrameKindString(t *testing.T) {
+	if s := kont.FrameEffect.String(); s != "Effect" {
+		t.Fatalf("got %q", s)
+	}
+	if s := kont.FrameKind(0).String(); s != "FrameKind(0)" {
+		t.Fatalf("got %q", s)
+	}
+}
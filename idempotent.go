@@ -0,0 +1,154 @@
+// ©Hayabusa Cloud Co., Ltd. 2026. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package kont
+
+import (
+	"slices"
+	"sync"
+)
+
+// Idempotency effect operations.
+// Idempotent[K] skips bodies whose result was already recorded under a key,
+// making at-least-once execution of side-effecting steps safe to retry.
+
+// IdempotencyStore records completed results keyed by idempotency key.
+// Implementations backed by durable storage make results survive restarts.
+type IdempotencyStore[K comparable] interface {
+	// Load returns the recorded result for key and true, or (nil, false).
+	Load(key K) (Resumed, bool)
+	// Store records v as the completed result for key.
+	Store(key K, v Resumed)
+}
+
+// IdempotentLookup is the effect operation for consulting the store.
+// Perform(IdempotentLookup[K, A]{Key: k}) returns the recorded result and true,
+// or the zero value and false when k has not completed.
+type IdempotentLookup[K comparable, A any] struct{ Key K }
+
+func (IdempotentLookup[K, A]) OpResult() Pair[A, bool] { panic("phantom") }
+
+// DispatchIdempotency handles IdempotentLookup in Idempotency handler dispatch.
+func (o IdempotentLookup[K, A]) DispatchIdempotency(store IdempotencyStore[K]) (Resumed, bool) {
+	v, ok := store.Load(o.Key)
+	if !ok {
+		return Pair[A, bool]{}, true
+	}
+	return Pair[A, bool]{Fst: valueOrZero[A](v), Snd: true}, true
+}
+
+// IdempotentCommit is the effect operation for recording a completed result.
+// Perform(IdempotentCommit[K, A]{Key: k, Value: a}) stores a under k.
+type IdempotentCommit[K comparable, A any] struct {
+	Key   K
+	Value A
+}
+
+func (IdempotentCommit[K, A]) OpResult() struct{} { panic("phantom") }
+
+// DispatchIdempotency handles IdempotentCommit in Idempotency handler dispatch.
+func (o IdempotentCommit[K, A]) DispatchIdempotency(store IdempotencyStore[K]) (Resumed, bool) {
+	store.Store(o.Key, o.Value)
+	return struct{}{}, true
+}
+
+// Idempotent runs body at most once per key to completion.
+// When key already has a recorded result, body is skipped and the recorded
+// result is returned; otherwise body runs and its result is committed.
+//
+// Effects performed by body stay on the enclosing computation and are handled
+// by the caller's handler. A body that aborts (Throw, short-circuit) commits
+// nothing, so a retry runs it again.
+func Idempotent[K comparable, A any](key K, body Cont[Resumed, A]) Cont[Resumed, A] {
+	return Bind(Perform(IdempotentLookup[K, A]{Key: key}), func(r Pair[A, bool]) Cont[Resumed, A] {
+		if r.Snd {
+			return Pure(r.Fst)
+		}
+		return Bind(body, func(a A) Cont[Resumed, A] {
+			return Then(Perform(IdempotentCommit[K, A]{Key: key, Value: a}), Pure(a))
+		})
+	})
+}
+
+// ExprIdempotent is the Expr counterpart of [Idempotent].
+func ExprIdempotent[K comparable, A any](key K, body Expr[A]) Expr[A] {
+	return ExprBind(ExprPerform(IdempotentLookup[K, A]{Key: key}), func(r Pair[A, bool]) Expr[A] {
+		if r.Snd {
+			return ExprReturn(r.Fst)
+		}
+		return ExprBind(body, func(a A) Expr[A] {
+			return ExprThen(ExprPerform(IdempotentCommit[K, A]{Key: key, Value: a}), ExprReturn(a))
+		})
+	})
+}
+
+// idempotencyHandler implements Handler for idempotency handling.
+type idempotencyHandler[K comparable, R any] struct {
+	store IdempotencyStore[K]
+}
+
+// Dispatch implements Handler for idempotency handling.
+func (h *idempotencyHandler[K, R]) Dispatch(op Operation) (Resumed, bool) {
+	if iop, ok := op.(interface {
+		DispatchIdempotency(store IdempotencyStore[K]) (Resumed, bool)
+	}); ok {
+		return iop.DispatchIdempotency(h.store)
+	}
+	unhandledEffect("IdempotencyHandler", op)
+	return nil, false
+}
+
+// IdempotencyHandler creates a handler for Idempotency effects backed by store.
+// Returns a concrete handler.
+func IdempotencyHandler[K comparable, R any](store IdempotencyStore[K]) *idempotencyHandler[K, R] {
+	return &idempotencyHandler[K, R]{store: store}
+}
+
+// RunIdempotent runs a computation whose only effects are Idempotency operations.
+func RunIdempotent[K comparable, A any](store IdempotencyStore[K], m Cont[Resumed, A]) A {
+	return Handle(m, IdempotencyHandler[K, A](store))
+}
+
+// RunIdempotentExpr runs an Expr computation whose only effects are Idempotency operations.
+func RunIdempotentExpr[K comparable, A any](store IdempotencyStore[K], m Expr[A]) A {
+	return HandleExpr(m, IdempotencyHandler[K, A](store))
+}
+
+// memoryIdempotencyStore is an in-memory IdempotencyStore safe for concurrent use.
+type memoryIdempotencyStore[K comparable] struct {
+	mu      sync.Mutex
+	results map[K]Resumed
+	order   []K
+}
+
+// NewMemoryIdempotencyStore creates an in-memory [IdempotencyStore].
+// Results live only as long as the store; use a durable store across restarts.
+func NewMemoryIdempotencyStore[K comparable]() *memoryIdempotencyStore[K] {
+	return &memoryIdempotencyStore[K]{results: make(map[K]Resumed)}
+}
+
+// Load implements IdempotencyStore.
+func (s *memoryIdempotencyStore[K]) Load(key K) (Resumed, bool) {
+	s.mu.Lock()
+	v, ok := s.results[key]
+	s.mu.Unlock()
+	return v, ok
+}
+
+// Store implements IdempotencyStore.
+func (s *memoryIdempotencyStore[K]) Store(key K, v Resumed) {
+	s.mu.Lock()
+	if _, ok := s.results[key]; !ok {
+		s.order = append(s.order, key)
+	}
+	s.results[key] = v
+	s.mu.Unlock()
+}
+
+// Keys returns the stored keys in the order they were first stored.
+func (s *memoryIdempotencyStore[K]) Keys() []K {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return slices.Clone(s.order)
+}
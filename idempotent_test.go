@@ -0,0 +1,144 @@
+// ©Hayabusa Cloud Co., Ltd. 2026. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package kont_test
+
+import (
+	"slices"
+	"testing"
+
+	"code.hybscloud.com/kont"
+)
+
+func TestIdempotentRunsOnce(t *testing.T) {
+	store := kont.NewMemoryIdempotencyStore[string]()
+	runs := 0
+	body := kont.Suspend[kont.Resumed, int](func(k func(int) kont.Resumed) kont.Resumed {
+		runs++
+		return k(42)
+	})
+	comp := kont.Idempotent("charge-1", body)
+
+	for i := range 3 {
+		got := kont.RunIdempotent(store, comp)
+		if got != 42 {
+			t.Fatalf("run %d: got %d, want 42", i, got)
+		}
+	}
+	if runs != 1 {
+		t.Fatalf("body ran %d times, want 1", runs)
+	}
+}
+
+func TestIdempotentDistinctKeys(t *testing.T) {
+	store := kont.NewMemoryIdempotencyStore[int]()
+	a := kont.RunIdempotent(store, kont.Idempotent(1, kont.Pure("a")))
+	b := kont.RunIdempotent(store, kont.Idempotent(2, kont.Pure("b")))
+	again := kont.RunIdempotent(store, kont.Idempotent(1, kont.Pure("changed")))
+	if a != "a" || b != "b" {
+		t.Fatalf("got %q, %q, want a, b", a, b)
+	}
+	if again != "a" {
+		t.Fatalf("got %q, want recorded %q", again, "a")
+	}
+}
+
+func TestIdempotentBodyEffectsReachOuterHandler(t *testing.T) {
+	store := kont.NewMemoryIdempotencyStore[string]()
+	state := 0
+	idem := kont.IdempotencyHandler[string, int](store)
+	h := kont.HandleFunc[int](func(op kont.Operation) (kont.Resumed, bool) {
+		switch o := op.(type) {
+		case kont.Get[int]:
+			return state, true
+		case kont.Put[int]:
+			state = o.Value
+			return struct{}{}, true
+		}
+		return idem.Dispatch(op)
+	})
+	incr := kont.GetState(func(s int) kont.Eff[int] {
+		return kont.PutState(s+1, kont.Pure(s+1))
+	})
+	comp := kont.Idempotent("incr", incr)
+
+	first := kont.Handle(comp, h)
+	second := kont.Handle(comp, h)
+	if first != 1 || second != 1 {
+		t.Fatalf("got %d, %d, want 1, 1", first, second)
+	}
+	if state != 1 {
+		t.Fatalf("state = %d, want 1", state)
+	}
+}
+
+func TestIdempotentThrowCommitsNothing(t *testing.T) {
+	store := kont.NewMemoryIdempotencyStore[string]()
+	comp := kont.Idempotent("job", kont.ThrowError[string, int]("transient"))
+
+	var ctx kont.ErrorContext[string]
+	idem := kont.IdempotencyHandler[string, kont.Either[string, int]](store)
+	h := kont.HandleFunc[kont.Either[string, int]](func(op kont.Operation) (kont.Resumed, bool) {
+		if th, ok := op.(kont.Throw[string]); ok {
+			th.DispatchError(&ctx)
+			return kont.Left[string, int](ctx.Err), false
+		}
+		return idem.Dispatch(op)
+	})
+	result := kont.Handle(kont.Map(comp, kont.Right[string, int]), h)
+	if !result.IsLeft() {
+		t.Fatal("expected Left")
+	}
+	if _, ok := store.Load("job"); ok {
+		t.Fatal("failed body must not be recorded")
+	}
+}
+
+func TestExprIdempotent(t *testing.T) {
+	store := kont.NewMemoryIdempotencyStore[string]()
+	runs := 0
+	body := kont.ExprMap(kont.ExprReturn(20), func(x int) int {
+		runs++
+		return x + 1
+	})
+	comp := kont.ExprIdempotent("k", body)
+
+	for range 2 {
+		if got := kont.RunIdempotentExpr(store, comp); got != 21 {
+			t.Fatalf("got %d, want 21", got)
+		}
+	}
+	if runs != 1 {
+		t.Fatalf("body ran %d times, want 1", runs)
+	}
+}
+
+func TestIdempotencyHandlerUnhandledPanic(t *testing.T) {
+	defer func() {
+		if r := recover(); unhandledIn(r) != "IdempotencyHandler" {
+			t.Fatalf("got panic %v", r)
+		}
+	}()
+	store := kont.NewMemoryIdempotencyStore[string]()
+	kont.RunIdempotent(store, kont.Perform(kont.Get[int]{}))
+}
+
+func TestOpResultPanicIdempotent(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Fatal("expected phantom panic")
+		}
+	}()
+	kont.IdempotentLookup[string, int]{}.OpResult()
+}
+
+func TestMemoryIdempotencyStoreKeysInsertionOrder(t *testing.T) {
+	store := kont.NewMemoryIdempotencyStore[int]()
+	for _, k := range []int{5, 1, 9, 1, 3} {
+		store.Store(k, k)
+	}
+	if got := store.Keys(); !slices.Equal(got, []int{5, 1, 9, 3}) {
+		t.Fatalf("Keys %v", got)
+	}
+}
@@ -0,0 +1,56 @@
+// ©Hayabusa Cloud Co., Ltd. 2026. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package kont
+
+// Interop with other Go functional libraries.
+// kont depends on none of them; conversions go through the shapes those
+// libraries already expose.
+//
+// Inbound, comma-ok and (value, error) pairs map directly:
+//
+//	kont.OptionOf(moOpt.Get())            // samber/mo Option
+//	kont.OptionOf(option.Unwrap(fpOpt))   // fp-go Option
+//	kont.EitherOf(either.UnwrapError(e))  // fp-go Either[error, A]
+//	kont.FromEitherSource[E, A](moEither) // samber/mo Either
+//
+// Foreign folds and kont's Match functions cover the rest, since the
+// foreign and kont constructors have the shape the folds expect:
+//
+//	either.MonadFold(fpEither, kont.Left[E, A], kont.Right[E, A])
+//	kont.MatchEither(e, mo.Left[E, A], mo.Right[E, A])
+//	kont.MatchOption(o, mo.None[A], mo.Some[A])
+
+// OptionOf creates an Option from a (value, ok) pair: Some when ok.
+func OptionOf[A any](a A, ok bool) Option[A] {
+	if ok {
+		return Some(a)
+	}
+	return None[A]()
+}
+
+// EitherOf creates an Either from a (value, error) pair: Right when err is nil.
+func EitherOf[A any](a A, err error) Either[error, A] {
+	if err != nil {
+		return Left[error, A](err)
+	}
+	return Right[error](a)
+}
+
+// EitherSource is an Either from another library that exposes comma-ok
+// accessors for both sides, such as samber/mo's Either.
+type EitherSource[E, A any] interface {
+	Left() (E, bool)
+	Right() (A, bool)
+}
+
+// FromEitherSource converts src to an Either. Right wins when src reports
+// both sides present.
+func FromEitherSource[E, A any](src EitherSource[E, A]) Either[E, A] {
+	if a, ok := src.Right(); ok {
+		return Right[E](a)
+	}
+	e, _ := src.Left()
+	return Left[E, A](e)
+}
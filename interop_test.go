@@ -0,0 +1,83 @@
+// ©Hayabusa Cloud Co., Ltd. 2026. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package kont_test
+
+import (
+	"errors"
+	"testing"
+
+	"code.hybscloud.com/kont"
+)
+
+// foreignEither mimics samber/mo's Either accessors.
+type foreignEither[L, R any] struct {
+	isLeft bool
+	left   L
+	right  R
+}
+
+func (e foreignEither[L, R]) Left() (L, bool)  { return e.left, e.isLeft }
+func (e foreignEither[L, R]) Right() (R, bool) { return e.right, !e.isLeft }
+
+// foreignOption mimics samber/mo's Option.
+type foreignOption[T any] struct {
+	value T
+	ok    bool
+}
+
+func (o foreignOption[T]) Get() (T, bool) { return o.value, o.ok }
+
+func foreignSome[T any](v T) foreignOption[T] { return foreignOption[T]{value: v, ok: true} }
+func foreignNone[T any]() foreignOption[T]    { return foreignOption[T]{} }
+
+func TestOptionOf(t *testing.T) {
+	if v, ok := kont.OptionOf(foreignSome(3).Get()).Get(); !ok || v != 3 {
+		t.Fatalf("got %v %v", v, ok)
+	}
+	if kont.OptionOf(foreignNone[int]().Get()).IsSome() {
+		t.Fatal("None converted to Some")
+	}
+	m := map[string]int{"a": 1}
+	if v, ok := m["b"]; kont.OptionOf(v, ok).IsSome() {
+		t.Fatal("missing key converted to Some")
+	}
+}
+
+func TestEitherOf(t *testing.T) {
+	if v, ok := kont.EitherOf(5, nil).GetRight(); !ok || v != 5 {
+		t.Fatalf("got %v %v", v, ok)
+	}
+	boom := errors.New("boom")
+	if e, ok := kont.EitherOf(0, boom).GetLeft(); !ok || e != boom {
+		t.Fatalf("got %v %v", e, ok)
+	}
+}
+
+func TestFromEitherSource(t *testing.T) {
+	r := kont.FromEitherSource[string, int](foreignEither[string, int]{right: 7})
+	if v, ok := r.GetRight(); !ok || v != 7 {
+		t.Fatalf("got %v", r)
+	}
+	l := kont.FromEitherSource[string, int](foreignEither[string, int]{isLeft: true, left: "bad"})
+	if e, ok := l.GetLeft(); !ok || e != "bad" {
+		t.Fatalf("got %v", l)
+	}
+}
+
+func TestOutboundConversion(t *testing.T) {
+	o := kont.MatchOption(kont.Some(4), foreignNone[int], foreignSome[int])
+	if v, ok := o.Get(); !ok || v != 4 {
+		t.Fatalf("got %v", o)
+	}
+	toForeign := func(e kont.Either[string, int]) foreignEither[string, int] {
+		return kont.MatchEither(e,
+			func(l string) foreignEither[string, int] { return foreignEither[string, int]{isLeft: true, left: l} },
+			func(r int) foreignEither[string, int] { return foreignEither[string, int]{right: r} })
+	}
+	back := kont.FromEitherSource[string, int](toForeign(kont.Left[string, int]("x")))
+	if e, ok := back.GetLeft(); !ok || e != "x" {
+		t.Fatalf("round trip got %v", back)
+	}
+}
@@ -0,0 +1,14 @@
+// ©Hayabusa Cloud Co., Ltd. 2026. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+// Command kontlint runs the kontlint analyzer.
+package main
+
+import (
+	"golang.org/x/tools/go/analysis/singlechecker"
+
+	"code.hybscloud.com/kont/kontlint"
+)
+
+func main() { singlechecker.Main(kontlint.Analyzer) }
@@ -0,0 +1,354 @@
+// ©Hayabusa Cloud Co., Ltd. 2026. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+// Package kontlint reports common mistakes in code that uses kont.
+//
+// [Analyzer] flags:
+//
+//   - a *kont.Suspension returned by Step, Resume and similar calls that is
+//     discarded, leaving the computation neither resumed nor released
+//   - a suspension resumed inside a loop that never reassigns it, which
+//     resumes the same suspension twice
+//   - kont.Run on a Cont[Resumed, _], which returns the first unhandled
+//     effect instead of a result, and kont.RunPure on an Expr built with
+//     ExprPerform, which panics
+//   - a closure passed to a kont function inside a loop that captures a
+//     variable the loop reassigns; the closure runs when the computation is
+//     evaluated and sees the last value, as do loop variables in files
+//     before Go 1.22
+//
+// The package lives in its own module so kont itself keeps no dependencies.
+// The kontlint command runs the analyzer standalone:
+//
+//	go run code.hybscloud.com/kont/kontlint/cmd/kontlint ./...
+package kontlint
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+	"go/version"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+const kontPath = "code.hybscloud.com/kont"
+
+// Analyzer reports misuse of kont suspensions, runners and closures.
+var Analyzer = &analysis.Analyzer{
+	Name:     "kontlint",
+	Doc:      "report common mistakes in code that uses kont",
+	URL:      "https://pkg.go.dev/code.hybscloud.com/kont/kontlint",
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      run,
+}
+
+// resumeMethods are the Suspension methods that consume the suspension.
+var resumeMethods = map[string]bool{
+	"Resume":          true,
+	"ResumeWithError": true,
+	"ResumeWithin":    true,
+	"TryResume":       true,
+}
+
+func run(pass *analysis.Pass) (any, error) {
+	ins := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+	filter := []ast.Node{(*ast.ExprStmt)(nil), (*ast.AssignStmt)(nil), (*ast.CallExpr)(nil)}
+	ins.WithStack(filter, func(n ast.Node, push bool, stack []ast.Node) bool {
+		if !push {
+			return true
+		}
+		switch n := n.(type) {
+		case *ast.ExprStmt:
+			if call, ok := ast.Unparen(n.X).(*ast.CallExpr); ok {
+				checkDropped(pass, call, nil)
+			}
+		case *ast.AssignStmt:
+			if len(n.Rhs) == 1 {
+				if call, ok := ast.Unparen(n.Rhs[0]).(*ast.CallExpr); ok {
+					checkDropped(pass, call, n.Lhs)
+				}
+			}
+		case *ast.CallExpr:
+			checkResumeInLoop(pass, n, stack)
+			checkRun(pass, n)
+			checkCaptures(pass, n, stack)
+		}
+		return true
+	})
+	return nil, nil
+}
+
+// checkDropped reports a call to kont whose *kont.Suspension result is not
+// kept. lhs is nil for a call used as a statement.
+func checkDropped(pass *analysis.Pass, call *ast.CallExpr, lhs []ast.Expr) {
+	fn := calleeFunc(pass, call)
+	if fn == nil || !fn.Exported() || runsToCompletion(pass, fn, call) {
+		return
+	}
+	tuple, ok := pass.TypesInfo.TypeOf(call).(*types.Tuple)
+	if !ok {
+		return
+	}
+	for i := range tuple.Len() {
+		if !isSuspension(tuple.At(i).Type()) {
+			continue
+		}
+		if lhs == nil {
+			pass.Reportf(call.Pos(), "suspension returned by %s is discarded", fn.Name())
+			return
+		}
+		if i < len(lhs) {
+			if id, ok := lhs[i].(*ast.Ident); ok && id.Name == "_" {
+				pass.Reportf(lhs[i].Pos(), "suspension returned by %s is discarded", fn.Name())
+				return
+			}
+		}
+	}
+}
+
+// runsToCompletion reports whether call is RunUntil, RunUntilExpr or
+// ResumeUntil with a nil pred, which never returns a suspension.
+func runsToCompletion(pass *analysis.Pass, fn *types.Func, call *ast.CallExpr) bool {
+	switch fn.Name() {
+	case "RunUntil", "RunUntilExpr", "ResumeUntil":
+		last := call.Args[len(call.Args)-1]
+		return pass.TypesInfo.Types[last].IsNil()
+	}
+	return false
+}
+
+// checkResumeInLoop reports susp.Resume inside a loop that never
+// reassigns susp.
+func checkResumeInLoop(pass *analysis.Pass, call *ast.CallExpr, stack []ast.Node) {
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok || !resumeMethods[sel.Sel.Name] {
+		return
+	}
+	recv, ok := sel.X.(*ast.Ident)
+	if !ok || !isSuspension(pass.TypesInfo.TypeOf(recv)) {
+		return
+	}
+	v, ok := pass.TypesInfo.Uses[recv].(*types.Var)
+	if !ok {
+		return
+	}
+	loops := enclosingLoops(stack)
+	if len(loops) == 0 || within(v.Pos(), loops[0]) {
+		return
+	}
+	if !assignedIn(pass, loopBody(loops[0]), nil)[v] {
+		pass.Reportf(call.Pos(), "%s.%s in a loop that never reassigns %s resumes the same suspension twice", recv.Name, sel.Sel.Name, recv.Name)
+	}
+}
+
+// checkRun reports kont.Run and kont.RunPure on computations that perform
+// effects.
+func checkRun(pass *analysis.Pass, call *ast.CallExpr) {
+	fn := kontFunc(pass, call)
+	if fn == nil || len(call.Args) != 1 {
+		return
+	}
+	switch fn.Name() {
+	case "Run":
+		named, ok := types.Unalias(pass.TypesInfo.TypeOf(call.Args[0])).(*types.Named)
+		if ok && named.TypeArgs().Len() == 2 && isKontNamed(named.TypeArgs().At(0), "Resumed") {
+			pass.Reportf(call.Pos(), "kont.Run on a Cont[Resumed, _] returns an unhandled effect instead of a result; use Handle or Step")
+		}
+	case "RunPure":
+		ast.Inspect(call.Args[0], func(n ast.Node) bool {
+			if inner, ok := n.(*ast.CallExpr); ok {
+				if f := kontFunc(pass, inner); f != nil && f.Name() == "ExprPerform" {
+					pass.Reportf(call.Pos(), "kont.RunPure panics on a computation that performs effects; use HandleExpr or StepExpr")
+					return false
+				}
+			}
+			return true
+		})
+	}
+}
+
+// checkCaptures reports closures passed to a kont function that capture a
+// variable an enclosing loop reassigns, or a loop variable shared across
+// iterations.
+func checkCaptures(pass *analysis.Pass, call *ast.CallExpr, stack []ast.Node) {
+	fn := kontFunc(pass, call)
+	if fn == nil {
+		return
+	}
+	loops := enclosingLoops(stack)
+	if len(loops) == 0 {
+		return
+	}
+	file := enclosingFile(pass, call.Pos())
+	shared := file != nil && version.Compare(pass.TypesInfo.FileVersions[file], "go1.22") < 0
+	for _, arg := range call.Args {
+		lit, ok := ast.Unparen(arg).(*ast.FuncLit)
+		if !ok {
+			continue
+		}
+		reported := make(map[*types.Var]bool)
+		ast.Inspect(lit.Body, func(n ast.Node) bool {
+			id, ok := n.(*ast.Ident)
+			if !ok {
+				return true
+			}
+			v, ok := pass.TypesInfo.Uses[id].(*types.Var)
+			if !ok || v.IsField() || reported[v] || within(v.Pos(), lit) || v.Parent() == v.Pkg().Scope() {
+				return true
+			}
+			if reason := captureHazard(pass, v, loops, lit, shared); reason != "" {
+				reported[v] = true
+				pass.Reportf(id.Pos(), "closure passed to kont.%s captures %s, %s", fn.Name(), id.Name, reason)
+			}
+			return true
+		})
+	}
+}
+
+// captureHazard describes why capturing v in lit inside loops, innermost
+// first, is unsafe, or returns "".
+func captureHazard(pass *analysis.Pass, v *types.Var, loops []ast.Node, lit *ast.FuncLit, shared bool) string {
+	for _, loop := range loops {
+		switch {
+		case within(v.Pos(), loopBody(loop)):
+			return ""
+		case within(v.Pos(), loop):
+			if shared {
+				return "a loop variable shared across iterations before Go 1.22"
+			}
+			return ""
+		case assignedIn(pass, loopBody(loop), lit)[v]:
+			return "which the enclosing loop reassigns; the closure sees its value when evaluated"
+		}
+	}
+	return ""
+}
+
+// assignedIn returns the variables assigned in body outside skip.
+func assignedIn(pass *analysis.Pass, body ast.Node, skip ast.Node) map[*types.Var]bool {
+	vars := make(map[*types.Var]bool)
+	mark := func(e ast.Expr) {
+		if id, ok := ast.Unparen(e).(*ast.Ident); ok {
+			if v, ok := pass.TypesInfo.Uses[id].(*types.Var); ok {
+				vars[v] = true
+			}
+		}
+	}
+	ast.Inspect(body, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.FuncLit:
+			return n != skip
+		case *ast.AssignStmt:
+			for _, l := range n.Lhs {
+				mark(l)
+			}
+		case *ast.IncDecStmt:
+			mark(n.X)
+		case *ast.RangeStmt:
+			if n.Tok == token.ASSIGN {
+				if n.Key != nil {
+					mark(n.Key)
+				}
+				if n.Value != nil {
+					mark(n.Value)
+				}
+			}
+		}
+		return true
+	})
+	return vars
+}
+
+// enclosingLoops returns the loops enclosing the last node of stack within
+// its function, innermost first.
+func enclosingLoops(stack []ast.Node) []ast.Node {
+	var loops []ast.Node
+	for i := len(stack) - 2; i >= 0; i-- {
+		switch n := stack[i].(type) {
+		case *ast.ForStmt, *ast.RangeStmt:
+			loops = append(loops, n)
+		case *ast.FuncLit, *ast.FuncDecl:
+			return loops
+		}
+	}
+	return loops
+}
+
+func loopBody(loop ast.Node) ast.Node {
+	if r, ok := loop.(*ast.RangeStmt); ok {
+		return r.Body
+	}
+	return loop.(*ast.ForStmt).Body
+}
+
+func within(pos token.Pos, n ast.Node) bool {
+	return n.Pos() <= pos && pos < n.End()
+}
+
+func enclosingFile(pass *analysis.Pass, pos token.Pos) *ast.File {
+	for _, f := range pass.Files {
+		if within(pos, f) {
+			return f
+		}
+	}
+	return nil
+}
+
+// kontFunc returns the kont package-level function called by call, or nil.
+func kontFunc(pass *analysis.Pass, call *ast.CallExpr) *types.Func {
+	fn := calleeFunc(pass, call)
+	if fn == nil || fn.Signature().Recv() != nil {
+		return nil
+	}
+	return fn
+}
+
+// calleeFunc returns the kont function or method called by call, or nil.
+func calleeFunc(pass *analysis.Pass, call *ast.CallExpr) *types.Func {
+	id := calleeIdent(call)
+	if id == nil {
+		return nil
+	}
+	fn, ok := pass.TypesInfo.Uses[id].(*types.Func)
+	if !ok || fn.Pkg() == nil || fn.Pkg().Path() != kontPath {
+		return nil
+	}
+	return fn
+}
+
+// calleeIdent returns the name of the function or method called by call,
+// without instantiation, or nil.
+func calleeIdent(call *ast.CallExpr) *ast.Ident {
+	fun := ast.Unparen(call.Fun)
+	switch ix := fun.(type) {
+	case *ast.IndexExpr:
+		fun = ix.X
+	case *ast.IndexListExpr:
+		fun = ix.X
+	}
+	switch f := fun.(type) {
+	case *ast.Ident:
+		return f
+	case *ast.SelectorExpr:
+		return f.Sel
+	}
+	return nil
+}
+
+func isSuspension(t types.Type) bool {
+	ptr, ok := types.Unalias(t).(*types.Pointer)
+	return ok && isKontNamed(ptr.Elem(), "Suspension")
+}
+
+func isKontNamed(t types.Type, name string) bool {
+	named, ok := types.Unalias(t).(*types.Named)
+	if !ok {
+		return false
+	}
+	obj := named.Origin().Obj()
+	return obj.Pkg() != nil && obj.Pkg().Path() == kontPath && obj.Name() == name
+}
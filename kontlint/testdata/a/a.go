@@ -0,0 +1,60 @@
+package a
+
+import "code.hybscloud.com/kont"
+
+type Ask struct{}
+
+func (Ask) OpResult() int { panic("phantom") }
+
+func ask() kont.Eff[int] { return kont.Perform(Ask{}) }
+
+func dropped() {
+	kont.Step(ask())         // want `suspension returned by Step is discarded`
+	v, _ := kont.Step(ask()) // want `suspension returned by Step is discarded`
+	_ = v
+	n, _ := kont.RunUntil(ask(), kont.HandleFunc[int](func(kont.Operation) (kont.Resumed, bool) { return 1, true }), nil)
+	_ = n
+	_, susp := kont.StepExpr(kont.ExprPerform(Ask{}))
+	for susp != nil {
+		_, susp = susp.Resume(1)
+	}
+}
+
+func resumedTwice(susp *kont.Suspension[int]) int {
+	var v int
+	for range 2 {
+		v, _ = susp.Resume(1) // want `suspension returned by Resume is discarded` `susp.Resume in a loop that never reassigns susp resumes the same suspension twice`
+	}
+	return v
+}
+
+func resumedOnce(pending []*kont.Suspension[int]) {
+	for _, susp := range pending {
+		for susp != nil {
+			_, susp = susp.Resume(1)
+		}
+	}
+}
+
+func runners() {
+	kont.Run(kont.Map(ask(), func(n int) kont.Resumed { return n })) // want `kont.Run on a Cont\[Resumed, _\] returns an unhandled effect`
+	_ = kont.Run(kont.Return[int](1))
+	_ = kont.RunPure(kont.ExprMap(kont.ExprPerform(Ask{}), func(n int) int { return n })) // want `kont.RunPure panics on a computation that performs effects`
+	_ = kont.RunPure(kont.ExprReturn(1))
+}
+
+func captures(xs []int) kont.Eff[int] {
+	m := kont.Pure(0)
+	cur := 0
+	for _, x := range xs {
+		cur = x
+		m = kont.Bind(m, func(n int) kont.Eff[int] {
+			return kont.Pure(n + cur + x) // want `closure passed to kont.Bind captures cur, which the enclosing loop reassigns`
+		})
+	}
+	for i := 0; i < len(xs); i++ {
+		local := xs[i]
+		m = kont.Map(m, func(n int) int { return n + local + i })
+	}
+	return m
+}
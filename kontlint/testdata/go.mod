@@ -0,0 +1,7 @@
+module example.com/lint
+
+go 1.26
+
+require code.hybscloud.com/kont v0.0.0
+
+replace code.hybscloud.com/kont => ../..
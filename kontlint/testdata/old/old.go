@@ -0,0 +1,15 @@
+//go:build go1.21
+
+package old
+
+import "code.hybscloud.com/kont"
+
+func captures(xs []int) kont.Eff[int] {
+	m := kont.Pure(0)
+	for _, x := range xs {
+		m = kont.Map(m, func(n int) int {
+			return n + x // want `closure passed to kont.Map captures x, a loop variable shared across iterations before Go 1.22`
+		})
+	}
+	return m
+}
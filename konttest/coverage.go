@@ -0,0 +1,124 @@
+// ©Hayabusa Cloud Co., Ltd. 2026. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package konttest
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+
+	"code.hybscloud.com/kont"
+)
+
+// Coverage records which operation types were dispatched through covered
+// handlers and which way each dispatch went, so a test can fail when an
+// operation it declares was never performed.
+// A Coverage is safe for concurrent use.
+type Coverage struct {
+	mu       sync.Mutex
+	declared []reflect.Type
+	hits     map[reflect.Type]*opHits
+}
+
+// opHits counts the dispatches of one operation type by outcome.
+type opHits struct {
+	resumed  int
+	shortCut int
+}
+
+// NewCoverage creates a Coverage expecting every declared operation type,
+// given as sample values such as kont.Get[int]{}.
+func NewCoverage(declared ...kont.Operation) *Coverage {
+	c := &Coverage{hits: make(map[reflect.Type]*opHits)}
+	for _, op := range declared {
+		c.declared = append(c.declared, reflect.TypeOf(op))
+	}
+	return c
+}
+
+func (c *Coverage) record(op kont.Operation, shouldResume bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	t := reflect.TypeOf(op)
+	h := c.hits[t]
+	if h == nil {
+		h = new(opHits)
+		c.hits[t] = h
+	}
+	if shouldResume {
+		h.resumed++
+	} else {
+		h.shortCut++
+	}
+}
+
+// Count returns the number of dispatches of op's type.
+func (c *Coverage) Count(op kont.Operation) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if h := c.hits[reflect.TypeOf(op)]; h != nil {
+		return h.resumed + h.shortCut
+	}
+	return 0
+}
+
+// Missing returns the declared operation types never dispatched, by name.
+func (c *Coverage) Missing() []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	var missing []string
+	for _, t := range c.declared {
+		if c.hits[t] == nil {
+			missing = append(missing, t.String())
+		}
+	}
+	return missing
+}
+
+// Report returns one line per dispatched operation type, sorted by name,
+// with its resumed and short-circuited counts.
+func (c *Coverage) Report() string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	lines := make([]string, 0, len(c.hits))
+	for t, h := range c.hits {
+		lines = append(lines, fmt.Sprintf("%s: %d resumed, %d short-circuited", t, h.resumed, h.shortCut))
+	}
+	slices.Sort(lines)
+	return strings.Join(lines, "\n")
+}
+
+// AssertCovered fails t for every declared operation type that was never
+// dispatched.
+func (c *Coverage) AssertCovered(t testing.TB) {
+	t.Helper()
+	if missing := c.Missing(); len(missing) > 0 {
+		t.Errorf("operations never performed: %s", strings.Join(missing, ", "))
+	}
+}
+
+// coveredHandler wraps a handler and records its dispatches.
+type coveredHandler[H kont.Handler[H, R], R any] struct {
+	inner H
+	c     *Coverage
+}
+
+// Dispatch implements kont.Handler by delegating to the inner handler and
+// recording the operation type and outcome. Operations the inner handler
+// panics on are not recorded.
+func (h *coveredHandler[H, R]) Dispatch(op kont.Operation) (kont.Resumed, bool) {
+	v, shouldResume := h.inner.Dispatch(op)
+	h.c.record(op, shouldResume)
+	return v, shouldResume
+}
+
+// Cover wraps inner so its dispatches are recorded in c.
+// Returns a concrete handler.
+func Cover[R any, H kont.Handler[H, R]](c *Coverage, inner H) *coveredHandler[H, R] {
+	return &coveredHandler[H, R]{inner: inner, c: c}
+}
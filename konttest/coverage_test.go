@@ -0,0 +1,57 @@
+// ©Hayabusa Cloud Co., Ltd. 2026. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package konttest_test
+
+import (
+	"slices"
+	"testing"
+
+	"code.hybscloud.com/kont"
+	"code.hybscloud.com/kont/konttest"
+)
+
+func TestCoverage(t *testing.T) {
+	cov := konttest.NewCoverage(kont.Get[int]{}, kont.Put[int]{}, kont.Modify[int]{})
+	h, _ := kont.StateHandler[int, int](1)
+	comp := kont.Bind(kont.Perform(kont.Get[int]{}), func(n int) kont.Eff[int] {
+		return kont.Then(kont.Perform(kont.Put[int]{Value: n + 1}), kont.Perform(kont.Get[int]{}))
+	})
+	if got := kont.Handle(comp, konttest.Cover[int](cov, h)); got != 2 {
+		t.Fatalf("got %d", got)
+	}
+	if n := cov.Count(kont.Get[int]{}); n != 2 {
+		t.Fatalf("Get count %d", n)
+	}
+	if missing := cov.Missing(); !slices.Equal(missing, []string{"kont.Modify[int]"}) {
+		t.Fatalf("missing %v", missing)
+	}
+	want := "kont.Get[int]: 2 resumed, 0 short-circuited\nkont.Put[int]: 1 resumed, 0 short-circuited"
+	if got := cov.Report(); got != want {
+		t.Fatalf("report:\n%s", got)
+	}
+
+	r := &recorder{TB: t}
+	cov.AssertCovered(r)
+	if !r.failed {
+		t.Fatal("AssertCovered must fail with an operation missing")
+	}
+}
+
+// stopHandler short-circuits every operation with "stopped".
+type stopHandler struct{}
+
+func (stopHandler) Dispatch(kont.Operation) (kont.Resumed, bool) { return "stopped", false }
+
+func TestCoverageShortCircuit(t *testing.T) {
+	cov := konttest.NewCoverage(kont.Ask[int]{})
+	comp := kont.Map(kont.Perform(kont.Ask[int]{}), func(int) string { return "ran" })
+	if got := kont.Handle(comp, konttest.Cover[string](cov, stopHandler{})); got != "stopped" {
+		t.Fatalf("got %q", got)
+	}
+	if got := cov.Report(); got != "kont.Ask[int]: 0 resumed, 1 short-circuited" {
+		t.Fatalf("report: %s", got)
+	}
+	cov.AssertCovered(t)
+}
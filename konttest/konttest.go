@@ -0,0 +1,66 @@
+// ©Hayabusa Cloud Co., Ltd. 2026. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+// Package konttest provides allocation guardrails and effect coverage for
+// code built on kont.
+//
+// Handler loops are expected to run allocation-free once constructed.
+// These helpers turn that expectation into a failing test or benchmark,
+// for kont itself and for downstream handlers. Coverage records the
+// operations a handler dispatched, so handler branches for rare
+// operations do not go untested unnoticed. Mock answers an ordered list of
+// expected operations with canned responses, replacing hand-rolled
+// HandleFunc type switches in tests.
+//
+// The assertions use [testing.AllocsPerRun] and must not be called from
+// parallel tests.
+package konttest
+
+import (
+	"runtime"
+	"testing"
+)
+
+// allocRuns is the number of runs averaged by the assertions.
+const allocRuns = 100
+
+// AssertAllocs fails t when f allocates more than allowed times per run on
+// average. Use allowed to budget for per-run construction allocations
+// (handler, state cells) that the measured loop legitimately performs.
+func AssertAllocs(t testing.TB, allowed float64, f func()) {
+	t.Helper()
+	if got := testing.AllocsPerRun(allocRuns, f); got > allowed {
+		t.Errorf("allocs per run = %v; want <= %v", got, allowed)
+	}
+}
+
+// AssertZeroAllocs fails t when f allocates.
+func AssertZeroAllocs(t testing.TB, f func()) {
+	t.Helper()
+	AssertAllocs(t, 0, f)
+}
+
+// BenchmarkAllocs runs f b.N times with allocation reporting and fails b
+// when the average number of allocations per op exceeds allowed.
+func BenchmarkAllocs(b *testing.B, allowed float64, f func()) {
+	b.Helper()
+	b.ReportAllocs()
+	var before, after runtime.MemStats
+	runtime.ReadMemStats(&before)
+	b.ResetTimer()
+	for b.Loop() {
+		f()
+	}
+	b.StopTimer()
+	runtime.ReadMemStats(&after)
+	if got := float64(after.Mallocs-before.Mallocs) / float64(b.N); got > allowed {
+		b.Errorf("allocs per op = %v; want <= %v", got, allowed)
+	}
+}
+
+// BenchmarkZeroAllocs runs f b.N times and fails b when f allocates.
+func BenchmarkZeroAllocs(b *testing.B, f func()) {
+	b.Helper()
+	BenchmarkAllocs(b, 0, f)
+}
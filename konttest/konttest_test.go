@@ -0,0 +1,51 @@
+// ©Hayabusa Cloud Co., Ltd. 2026. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package konttest_test
+
+import (
+	"testing"
+
+	"code.hybscloud.com/kont"
+	"code.hybscloud.com/kont/konttest"
+)
+
+// recorder captures failures without failing the enclosing test.
+type recorder struct {
+	testing.TB
+	failed bool
+}
+
+func (r *recorder) Helper()               {}
+func (r *recorder) Errorf(string, ...any) { r.failed = true }
+
+var sink []byte
+
+func TestAssertZeroAllocsPasses(t *testing.T) {
+	comp := kont.ExprReturn(1)
+	konttest.AssertZeroAllocs(t, func() {
+		kont.StepExpr(comp)
+	})
+}
+
+func TestAssertZeroAllocsFails(t *testing.T) {
+	r := &recorder{TB: t}
+	konttest.AssertZeroAllocs(r, func() { sink = make([]byte, 64) })
+	if !r.failed {
+		t.Fatal("allocating function must fail AssertZeroAllocs")
+	}
+}
+
+func TestAssertAllocsBudget(t *testing.T) {
+	r := &recorder{TB: t}
+	konttest.AssertAllocs(r, 1, func() { sink = make([]byte, 64) })
+	if r.failed {
+		t.Fatal("one allocation must fit a budget of one")
+	}
+}
+
+func BenchmarkZeroAllocsStepExpr(b *testing.B) {
+	comp := kont.ExprReturn(1)
+	konttest.BenchmarkZeroAllocs(b, func() { kont.StepExpr(comp) })
+}
@@ -0,0 +1,118 @@
+// ©Hayabusa Cloud Co., Ltd. 2026. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package konttest
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"code.hybscloud.com/kont"
+)
+
+// Mock is a handler answering an ordered list of expected operations with
+// canned responses. It fails its test on an operation out of order or
+// beyond the list, and, when the test ends, on expectations never met:
+//
+//	m := konttest.MockHandler[int](t)
+//	m.Expect(kont.Get[int]{}).Return(5)
+//	m.Expect(kont.Put[int]{Value: 6}).Return(struct{}{})
+//	got := kont.Handle(comp, m)
+//
+// A Mock is not safe for concurrent use.
+type Mock[R any] struct {
+	t       testing.TB
+	expects []*Expectation
+	pos     int
+}
+
+// Expectation is one expected operation and its response.
+type Expectation struct {
+	op       kont.Operation
+	typeOnly bool
+	value    kont.Resumed
+	stop     bool
+}
+
+// Return resumes the computation with v when the operation is performed.
+func (e *Expectation) Return(v kont.Resumed) {
+	e.value, e.stop = v, false
+}
+
+// Stop ends the computation with result when the operation is performed.
+// result must have the mock's result type R.
+func (e *Expectation) Stop(result kont.Resumed) {
+	e.value, e.stop = result, true
+}
+
+func (e *Expectation) matches(op kont.Operation) bool {
+	if e.typeOnly {
+		return reflect.TypeOf(op) == reflect.TypeOf(e.op)
+	}
+	return reflect.DeepEqual(op, e.op)
+}
+
+func (e *Expectation) String() string {
+	if e.typeOnly {
+		return fmt.Sprintf("any %T", e.op)
+	}
+	return fmt.Sprintf("%T%+v", e.op, e.op)
+}
+
+// MockHandler creates a Mock reporting to t. Expectations not met by the
+// end of the test fail it.
+func MockHandler[R any](t testing.TB) *Mock[R] {
+	m := &Mock[R]{t: t}
+	t.Cleanup(func() {
+		if missing := m.Missing(); len(missing) > 0 {
+			t.Errorf("expected operations never performed: %v", missing)
+		}
+	})
+	return m
+}
+
+// Expect appends an expectation for an operation equal to op under
+// reflect.DeepEqual. Operations holding functions, such as Modify, never
+// compare equal; use ExpectType for them.
+func (m *Mock[R]) Expect(op kont.Operation) *Expectation {
+	e := &Expectation{op: op}
+	m.expects = append(m.expects, e)
+	return e
+}
+
+// ExpectType appends an expectation for any operation of op's type.
+func (m *Mock[R]) ExpectType(op kont.Operation) *Expectation {
+	e := &Expectation{op: op, typeOnly: true}
+	m.expects = append(m.expects, e)
+	return e
+}
+
+// Dispatch implements kont.Handler by answering op with the next
+// expectation. An operation that does not match fails the test and ends
+// the computation with the zero R.
+func (m *Mock[R]) Dispatch(op kont.Operation) (kont.Resumed, bool) {
+	m.t.Helper()
+	var zero R
+	if m.pos >= len(m.expects) {
+		m.t.Errorf("unexpected operation %T%+v after %d expected", op, op, len(m.expects))
+		return zero, false
+	}
+	e := m.expects[m.pos]
+	if !e.matches(op) {
+		m.t.Errorf("operation %d: got %T%+v, want %v", m.pos, op, op, e)
+		return zero, false
+	}
+	m.pos++
+	return e.value, !e.stop
+}
+
+// Missing returns the expectations not yet met, in order.
+func (m *Mock[R]) Missing() []string {
+	missing := make([]string, 0, len(m.expects)-m.pos)
+	for _, e := range m.expects[m.pos:] {
+		missing = append(missing, e.String())
+	}
+	return missing
+}
@@ -0,0 +1,78 @@
+// ©Hayabusa Cloud Co., Ltd. 2026. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package konttest_test
+
+import (
+	"slices"
+	"testing"
+
+	"code.hybscloud.com/kont"
+	"code.hybscloud.com/kont/konttest"
+)
+
+func incrementState() kont.Eff[int] {
+	return kont.Bind(kont.Perform(kont.Get[int]{}), func(n int) kont.Eff[int] {
+		return kont.Then(kont.Perform(kont.Put[int]{Value: n + 1}), kont.Pure(n+1))
+	})
+}
+
+func TestMockHandler(t *testing.T) {
+	m := konttest.MockHandler[int](t)
+	m.Expect(kont.Get[int]{}).Return(5)
+	m.Expect(kont.Put[int]{Value: 6}).Return(struct{}{})
+	if got := kont.Handle(incrementState(), m); got != 6 {
+		t.Fatalf("got %d", got)
+	}
+	if missing := m.Missing(); len(missing) != 0 {
+		t.Fatalf("missing %v", missing)
+	}
+}
+
+func TestMockHandlerExpectTypeAndStop(t *testing.T) {
+	m := konttest.MockHandler[int](t)
+	m.ExpectType(kont.Modify[int]{}).Return(3)
+	m.Expect(kont.Ask[int]{}).Stop(-1)
+	comp := kont.Bind(kont.Perform(kont.Modify[int]{F: func(n int) int { return n + 1 }}), func(int) kont.Eff[int] {
+		return kont.Perform(kont.Ask[int]{})
+	})
+	if got := kont.Handle(comp, m); got != -1 {
+		t.Fatalf("got %d", got)
+	}
+}
+
+func TestMockHandlerUnexpected(t *testing.T) {
+	r := &recorder{TB: t}
+	m := konttest.MockHandler[int](r)
+	m.Expect(kont.Get[int]{}).Return(5)
+	m.Expect(kont.Put[int]{Value: 7}).Return(struct{}{})
+	if got := kont.Handle(incrementState(), m); got != 0 || !r.failed {
+		t.Fatalf("got %d, failed %v", got, r.failed)
+	}
+	if missing := m.Missing(); !slices.Equal(missing, []string{"kont.Put[int]{Value:7}"}) {
+		t.Fatalf("missing %v", missing)
+	}
+}
+
+func TestMockHandlerBeyondExpectations(t *testing.T) {
+	r := &recorder{TB: t}
+	m := konttest.MockHandler[int](r)
+	m.Expect(kont.Get[int]{}).Return(5)
+	kont.Handle(incrementState(), m)
+	if !r.failed {
+		t.Fatal("an operation past the expectations must fail")
+	}
+}
+
+func TestMockHandlerMissingFailsAtCleanup(t *testing.T) {
+	r := &recorder{TB: t}
+	t.Run("sub", func(t *testing.T) {
+		r.TB = t
+		m := konttest.MockHandler[int](r)
+		m.Expect(kont.Get[int]{}).Return(5)
+	})
+	if !r.failed {
+		t.Fatal("an unmet expectation must fail the test")
+	}
+}
@@ -0,0 +1,24 @@
+// ©Hayabusa Cloud Co., Ltd. 2026. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+//go:build !kontdebug
+
+package kont
+
+import "time"
+
+// leakRecord tracks a live suspension for CheckLeaks.
+// Without the kontdebug build tag it is zero-size and tracks nothing.
+type leakRecord struct{}
+
+func trackSuspension(Operation) leakRecord { return leakRecord{} }
+
+func (leakRecord) untrack() {}
+
+func (leakRecord) age() time.Duration { return 0 }
+
+// CheckLeaks returns the suspensions created by Step, StepExpr, or Clone
+// that have been neither resumed nor discarded, oldest first. Tracking
+// needs the kontdebug build tag; without it CheckLeaks returns nil.
+func CheckLeaks() []LeakedSuspension { return nil }
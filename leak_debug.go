@@ -0,0 +1,93 @@
+// ©Hayabusa Cloud Co., Ltd. 2026. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+//go:build kontdebug
+
+package kont
+
+import (
+	"runtime"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// leakRecord tracks a live suspension for CheckLeaks.
+type leakRecord struct {
+	id      uint64
+	created time.Time
+}
+
+// liveSuspension is the tracking entry of one live suspension.
+type liveSuspension struct {
+	op      Operation
+	created time.Time
+	pcs     []uintptr
+}
+
+var leaks struct {
+	mu   sync.Mutex
+	next uint64
+	live map[uint64]liveSuspension
+}
+
+func trackSuspension(op Operation) leakRecord {
+	var pcs [32]uintptr
+	n := runtime.Callers(2, pcs[:])
+	created := time.Now()
+	leaks.mu.Lock()
+	defer leaks.mu.Unlock()
+	if leaks.live == nil {
+		leaks.live = make(map[uint64]liveSuspension)
+	}
+	leaks.next++
+	leaks.live[leaks.next] = liveSuspension{op: op, created: created, pcs: slices.Clone(pcs[:n])}
+	return leakRecord{id: leaks.next, created: created}
+}
+
+func (r leakRecord) untrack() {
+	leaks.mu.Lock()
+	delete(leaks.live, r.id)
+	leaks.mu.Unlock()
+}
+
+func (r leakRecord) age() time.Duration {
+	if r.created.IsZero() {
+		return 0
+	}
+	return time.Since(r.created)
+}
+
+// CheckLeaks returns the suspensions created by Step, StepExpr, or Clone
+// that have been neither resumed nor discarded, oldest first. Tracking
+// needs the kontdebug build tag; without it CheckLeaks returns nil.
+func CheckLeaks() []LeakedSuspension {
+	leaks.mu.Lock()
+	ids := make([]uint64, 0, len(leaks.live))
+	for id := range leaks.live {
+		ids = append(ids, id)
+	}
+	slices.Sort(ids)
+	out := make([]LeakedSuspension, len(ids))
+	for i, id := range ids {
+		e := leaks.live[id]
+		out[i] = LeakedSuspension{Op: e.op, Age: time.Since(e.created), Stack: formatStack(e.pcs)}
+	}
+	leaks.mu.Unlock()
+	return out
+}
+
+func formatStack(pcs []uintptr) string {
+	var b strings.Builder
+	frames := runtime.CallersFrames(pcs)
+	for {
+		f, more := frames.Next()
+		b.WriteString(f.Function + "\n\t" + f.File + ":" + strconv.Itoa(f.Line) + "\n")
+		if !more {
+			return b.String()
+		}
+	}
+}
@@ -0,0 +1,84 @@
+// ©Hayabusa Cloud Co., Ltd. 2026. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+//go:build kontdebug
+
+package kont_test
+
+import (
+	"strings"
+	"testing"
+
+	"code.hybscloud.com/kont"
+)
+
+// leakProbe is performed only by the leak tests, so suspensions other tests
+// leave behind do not affect them.
+type leakProbe struct{ id int }
+
+func (leakProbe) OpResult() int { panic("phantom") }
+
+func probeLeaks() []kont.LeakedSuspension {
+	var out []kont.LeakedSuspension
+	for _, l := range kont.CheckLeaks() {
+		if _, ok := l.Op.(leakProbe); ok {
+			out = append(out, l)
+		}
+	}
+	return out
+}
+
+func TestCheckLeaks(t *testing.T) {
+	_, resumed := kont.StepExpr(kont.ExprPerform(leakProbe{1}))
+	_, discarded := kont.Step(kont.Perform(leakProbe{2}))
+	_, leaked := kont.StepExpr(kont.ExprPerform(leakProbe{3}))
+	clone := leaked.Clone()
+
+	resumed.Resume(0)
+	discarded.Discard()
+	leaks := probeLeaks()
+	if len(leaks) != 2 || leaks[0].Op != (leakProbe{3}) || leaks[1].Op != (leakProbe{3}) {
+		t.Fatalf("got %+v", leaks)
+	}
+	if !strings.Contains(leaks[0].Stack, "TestCheckLeaks") || !strings.Contains(leaks[0].Stack, "leak_debug_test.go:") {
+		t.Fatalf("stack does not name the creating test:\n%s", leaks[0].Stack)
+	}
+
+	leaked.Discard()
+	clone.Discard()
+	if leaks := probeLeaks(); len(leaks) != 0 {
+		t.Fatalf("got %+v after discard", leaks)
+	}
+}
+
+func TestCheckLeaksFollowsResumption(t *testing.T) {
+	comp := kont.ExprBind(kont.ExprPerform(leakProbe{1}), func(int) kont.Expr[int] {
+		return kont.ExprPerform(leakProbe{2})
+	})
+	_, susp := kont.StepExpr(comp)
+	_, next := susp.Resume(0)
+	if leaks := probeLeaks(); len(leaks) != 1 || leaks[0].Op != (leakProbe{2}) {
+		t.Fatalf("got %+v", leaks)
+	}
+	next.Resume(0)
+	if leaks := probeLeaks(); len(leaks) != 0 {
+		t.Fatalf("got %+v", leaks)
+	}
+}
+
+func TestRaceDiscardsLoser(t *testing.T) {
+	_, s := kont.Race(kont.Perform(leakProbe{id: 1}), kont.Perform(leakProbe{id: 2}))
+	if got, s := kont.ResumeLeft(s, 7); got != 7 || s != nil {
+		t.Fatalf("got %d", got)
+	}
+	if leaks := probeLeaks(); len(leaks) != 0 {
+		t.Fatalf("leaked %v", leaks)
+	}
+
+	_, z := kont.ZipExpr(kont.ExprPerform(leakProbe{id: 1}), kont.ExprPerform(leakProbe{id: 2}))
+	z.Discard()
+	if leaks := probeLeaks(); len(leaks) != 0 {
+		t.Fatalf("leaked %v", leaks)
+	}
+}
@@ -0,0 +1,20 @@
+// ©Hayabusa Cloud Co., Ltd. 2026. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+//go:build !kontdebug
+
+package kont_test
+
+import (
+	"testing"
+
+	"code.hybscloud.com/kont"
+)
+
+func TestCheckLeaksEmptyWithoutDebugTag(t *testing.T) {
+	kont.StepExpr(kont.ExprPerform(kont.Get[int]{}))
+	if leaks := kont.CheckLeaks(); leaks != nil {
+		t.Fatalf("got %d leaks", len(leaks))
+	}
+}
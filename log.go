@@ -0,0 +1,128 @@
+// ©Hayabusa Cloud Co., Ltd. 2026. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package kont
+
+import (
+	"context"
+	"log/slog"
+)
+
+// Structured logging effect.
+// Unlike Writer's Tell, a Log record is forwarded to log/slog the moment
+// it is dispatched. Log is not a Writer operation, so Listen and Censor
+// never capture it.
+
+// Log is the effect operation for emitting a structured log record.
+type Log struct {
+	Level slog.Level
+	Msg   string
+	Attrs []slog.Attr
+}
+
+func (Log) OpResult() struct{} { panic("phantom") }
+
+// DispatchLog forwards the record to l.
+func (o Log) DispatchLog(ctx context.Context, l *slog.Logger) (Resumed, bool) {
+	l.LogAttrs(ctx, o.Level, o.Msg, o.Attrs...)
+	return struct{}{}, true
+}
+
+// LogMessage performs Log.
+func LogMessage(level slog.Level, msg string, attrs ...slog.Attr) Cont[Resumed, struct{}] {
+	return Perform(Log{Level: level, Msg: msg, Attrs: attrs})
+}
+
+// ExprLogMessage is the Expr counterpart of [LogMessage].
+func ExprLogMessage(level slog.Level, msg string, attrs ...slog.Attr) Expr[struct{}] {
+	return ExprPerform(Log{Level: level, Msg: msg, Attrs: attrs})
+}
+
+// WithLogAttrs runs body with attrs added in front of the attributes of
+// every Log it performs, such as a request id read from Reader before
+// entering the scope. Nested scopes list outer attributes first.
+// Effects performed after body completes are not annotated.
+func WithLogAttrs[A any](attrs []slog.Attr, body Cont[Resumed, A]) Cont[Resumed, A] {
+	return func(k func(A) Resumed) Resumed {
+		return logScopeResult(body(scopeDoneCont[A]), attrs, k)
+	}
+}
+
+// ExprWithLogAttrs is the Expr counterpart of [WithLogAttrs].
+func ExprWithLogAttrs[A any](attrs []slog.Attr, m Expr[A]) Expr[A] {
+	return Reify(WithLogAttrs(attrs, Reflect(m)))
+}
+
+// logScopeSuspension wraps a suspension raised inside WithLogAttrs,
+// presenting the annotated operation.
+type logScopeSuspension[A any] struct {
+	inner effectSuspension
+	op    Operation
+	attrs []slog.Attr
+	k     func(A) Resumed
+}
+
+func (s *logScopeSuspension[A]) Op() Operation { return s.op }
+func (s *logScopeSuspension[A]) Resume(v Resumed) Resumed {
+	return logScopeResult(s.inner.Resume(v), s.attrs, s.k)
+}
+func (s *logScopeSuspension[A]) resumeWithError(throw Operation) Resumed {
+	return logScopeResult(s.inner.resumeWithError(throw), s.attrs, s.k)
+}
+func (s *logScopeSuspension[A]) release()         { s.inner.release() }
+func (s *logScopeSuspension[A]) site() provenance { return s.inner.site() }
+
+func logScopeResult[A any](r Resumed, attrs []slog.Attr, k func(A) Resumed) Resumed {
+	switch r := r.(type) {
+	case scopeDone[A]:
+		return k(r.value)
+	case effectSuspension:
+		op := r.Op()
+		if l, ok := op.(Log); ok {
+			merged := make([]slog.Attr, 0, len(attrs)+len(l.Attrs))
+			l.Attrs = append(append(merged, attrs...), l.Attrs...)
+			op = l
+		}
+		return &logScopeSuspension[A]{inner: r, op: op, attrs: attrs, k: k}
+	}
+	return r
+}
+
+// slogHandler implements Handler for the Log effect.
+type slogHandler[R any] struct {
+	ctx    context.Context
+	logger *slog.Logger
+}
+
+// Dispatch implements Handler for the Log effect.
+func (h *slogHandler[R]) Dispatch(op Operation) (Resumed, bool) {
+	if lop, ok := op.(interface {
+		DispatchLog(ctx context.Context, l *slog.Logger) (Resumed, bool)
+	}); ok {
+		return lop.DispatchLog(h.ctx, h.logger)
+	}
+	unhandledEffect("SlogHandler", op)
+	return nil, false
+}
+
+// SlogHandler creates a Log handler forwarding records to logger, passing
+// ctx to its handler. A nil logger uses slog.Default.
+// Handler-wide attributes come from logger.With.
+// Returns a concrete handler.
+func SlogHandler[R any](ctx context.Context, logger *slog.Logger) *slogHandler[R] {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return &slogHandler[R]{ctx: ctx, logger: logger}
+}
+
+// RunSlog runs a computation with the Log effect forwarded to logger.
+func RunSlog[A any](ctx context.Context, logger *slog.Logger, m Cont[Resumed, A]) A {
+	return Handle(m, SlogHandler[A](ctx, logger))
+}
+
+// RunSlogExpr is the Expr counterpart of [RunSlog].
+func RunSlogExpr[A any](ctx context.Context, logger *slog.Logger, m Expr[A]) A {
+	return HandleExpr(m, SlogHandler[A](ctx, logger))
+}
@@ -0,0 +1,115 @@
+// ©Hayabusa Cloud Co., Ltd. 2026. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package kont_test
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"code.hybscloud.com/kont"
+)
+
+// textLogger returns a logger writing text records without timestamps to buf.
+func textLogger(buf *bytes.Buffer) *slog.Logger {
+	return slog.New(slog.NewTextHandler(buf, &slog.HandlerOptions{
+		ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+			if a.Key == slog.TimeKey && len(groups) == 0 {
+				return slog.Attr{}
+			}
+			return a
+		},
+	}))
+}
+
+func TestRunSlog(t *testing.T) {
+	var buf bytes.Buffer
+	comp := kont.Then(
+		kont.LogMessage(slog.LevelInfo, "start", slog.Int("n", 1)),
+		kont.Then(kont.LogMessage(slog.LevelDebug, "hidden"), kont.Pure(7)),
+	)
+	if got := kont.RunSlog(context.Background(), textLogger(&buf), comp); got != 7 {
+		t.Fatalf("got %d", got)
+	}
+	if want := "level=INFO msg=start n=1\n"; buf.String() != want {
+		t.Fatalf("log %q, want %q", buf.String(), want)
+	}
+}
+
+func TestRunSlogStreamsImmediately(t *testing.T) {
+	var buf bytes.Buffer
+	comp := kont.Bind(kont.LogMessage(slog.LevelWarn, "now"), func(struct{}) kont.Eff[string] {
+		return kont.Pure(buf.String())
+	})
+	if got := kont.RunSlog(context.Background(), textLogger(&buf), comp); !strings.Contains(got, "msg=now") {
+		t.Fatalf("record not written before continuation: %q", got)
+	}
+}
+
+func TestRunSlogExprWithLogAttrs(t *testing.T) {
+	var buf bytes.Buffer
+	inner := kont.ExprWithLogAttrs([]slog.Attr{slog.String("span", "b")},
+		kont.ExprLogMessage(slog.LevelInfo, "inner", slog.Int("k", 2)))
+	comp := kont.ExprThen(
+		kont.ExprWithLogAttrs([]slog.Attr{slog.String("req", "r1")}, inner),
+		kont.ExprLogMessage(slog.LevelInfo, "after"),
+	)
+	kont.RunSlogExpr(context.Background(), textLogger(&buf), comp)
+	want := "level=INFO msg=inner req=r1 span=b k=2\nlevel=INFO msg=after\n"
+	if buf.String() != want {
+		t.Fatalf("log %q, want %q", buf.String(), want)
+	}
+}
+
+func TestSlogHandlerNilLoggerUsesDefault(t *testing.T) {
+	var buf bytes.Buffer
+	prev := slog.Default()
+	slog.SetDefault(textLogger(&buf))
+	defer slog.SetDefault(prev)
+	kont.Handle(kont.Then(kont.LogMessage(slog.LevelError, "x"), kont.Pure(0)), kont.SlogHandler[int](context.Background(), nil))
+	if !strings.Contains(buf.String(), "msg=x") {
+		t.Fatalf("log %q", buf.String())
+	}
+}
+
+// requestHandler answers Reader[string], Writer[string] and Log.
+type requestHandler[R any] struct {
+	id     string
+	out    []string
+	logger *slog.Logger
+}
+
+func (h *requestHandler[R]) Dispatch(op kont.Operation) (kont.Resumed, bool) {
+	switch o := op.(type) {
+	case kont.Ask[string]:
+		return o.DispatchReader(&h.id)
+	case kont.Tell[string]:
+		h.out = append(h.out, o.Value)
+		return struct{}{}, true
+	case kont.Log:
+		return o.DispatchLog(context.Background(), h.logger)
+	}
+	panic("unhandled")
+}
+
+func TestLogAttrsFromReader(t *testing.T) {
+	var buf bytes.Buffer
+	h := &requestHandler[int]{id: "req-9", logger: textLogger(&buf)}
+	comp := kont.AskReader(func(id string) kont.Eff[int] {
+		return kont.WithLogAttrs([]slog.Attr{slog.String("request_id", id)},
+			kont.Then(kont.TellWriter("audit", kont.LogMessage(slog.LevelInfo, "handled")), kont.Pure(1)))
+	})
+	if got := kont.Handle(comp, h); got != 1 {
+		t.Fatalf("got %d", got)
+	}
+	if want := "level=INFO msg=handled request_id=req-9\n"; buf.String() != want {
+		t.Fatalf("log %q, want %q", buf.String(), want)
+	}
+	if len(h.out) != 1 || h.out[0] != "audit" {
+		t.Fatalf("writer output %v", h.out)
+	}
+}
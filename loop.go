@@ -0,0 +1,284 @@
+// ©Hayabusa Cloud Co., Ltd. 2026. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package kont
+
+import "slices"
+
+// Effectful loops.
+// A loop built from nested Bind grows one closure per iteration, and on the
+// Cont path each iteration that completes without suspending deepens the Go
+// stack. iterate runs such iterations in a for loop instead, and re-enters
+// it from the continuation only after a suspension, so the stack depth is
+// bounded by the loop body whatever the number of iterations.
+
+// iterate runs the computations next returns, each fed the state the
+// previous one completed with, until next reports false, and completes with
+// the final state.
+func iterate[S any](s S, next func(S) (Eff[S], bool)) Eff[S] {
+	return func(k func(S) Resumed) Resumed {
+		return (&loopRun[S]{next: next, k: k}).restart(s)
+	}
+}
+
+// loopRun is one run of an iterate loop. step is bound once and passed to
+// every iteration as its continuation.
+type loopRun[S any] struct {
+	next   func(S) (Eff[S], bool)
+	k      func(S) Resumed
+	step   func(S) Resumed
+	inCall bool
+	done   bool
+	out    S
+}
+
+func (l *loopRun[S]) run(s S) Resumed {
+	for {
+		m, ok := l.next(s)
+		if !ok {
+			return l.k(s)
+		}
+		l.inCall, l.done = true, false
+		r := m(l.step)
+		l.inCall = false
+		if !l.done {
+			return r
+		}
+		s = l.out
+	}
+}
+
+// stepped receives an iteration's result. During the iteration's call it
+// hands the result back to run. After a suspension run has returned, and
+// the loop continues in a new run, since clones of the suspension may
+// resume it more than once, possibly concurrently.
+func (l *loopRun[S]) stepped(s S) Resumed {
+	if l.inCall {
+		l.out, l.done = s, true
+		return nil
+	}
+	return (&loopRun[S]{next: l.next, k: l.k}).restart(s)
+}
+
+// restart binds step and runs the loop from s.
+func (l *loopRun[S]) restart(s S) Resumed {
+	l.step = l.stepped
+	return l.run(s)
+}
+
+// iterateExpr is the Expr counterpart of iterate. Iterations that complete
+// without frames are folded in directly rather than through ExprBind, which
+// would apply its function recursively; later iterations are linked with
+// pooled frames.
+func iterateExpr[S any](s S, next func(S) (Expr[S], bool)) Expr[S] {
+	it := &exprIteration[S]{next: next}
+	it.bound = it.resume
+	m, done := it.advance(s)
+	if done {
+		return m
+	}
+	return Expr[S]{Value: m.Value, Frame: ChainFrames(m.Frame, &BindFrame[Erased, Erased]{F: it.bound, Next: ReturnFrame{}})}
+}
+
+// exprIteration is an iterateExpr loop. bound is its resume method, bound
+// once and shared by the frames of every iteration.
+type exprIteration[S any] struct {
+	next  func(S) (Expr[S], bool)
+	bound func(Erased) Expr[Erased]
+}
+
+// advance runs iterations that complete without frames, returning the first
+// that has frames, or the final state with done.
+func (it *exprIteration[S]) advance(s S) (m Expr[S], done bool) {
+	for {
+		m, ok := it.next(s)
+		if !ok {
+			return ExprReturn(s), true
+		}
+		if _, pure := m.Frame.(ReturnFrame); !pure {
+			return m, false
+		}
+		s = m.Value
+	}
+}
+
+func (it *exprIteration[S]) resume(v Erased) Expr[Erased] {
+	m, done := it.advance(v.(S))
+	if done {
+		return Expr[Erased]{Value: Erased(m.Value), Frame: ReturnFrame{}}
+	}
+	bf := AcquireBindFrame()
+	bf.F = it.bound
+	bf.Next = ReturnFrame{}
+	return Expr[Erased]{Value: Erased(m.Value), Frame: chainFromPool(m.Frame, bf)}
+}
+
+// extend returns a function appending to s. Appends after the first copy s,
+// so continuations resumed more than once, as under Amb, do not overwrite
+// each other's elements.
+func extend[A any](s []A) func(A) []A {
+	extended := false
+	return func(a A) []A {
+		if extended {
+			s = slices.Clip(s)
+		}
+		extended = true
+		return append(s, a)
+	}
+}
+
+// foldState is a fold's accumulator and the index of the next element.
+type foldState[S any] struct {
+	acc S
+	i   int
+}
+
+// FoldM folds step over xs from init, running each step's computation in
+// order. Iterations run in a loop rather than by recursion, so long slices
+// do not deepen the stack.
+func FoldM[S, A any](init S, xs []A, step func(S, A) Eff[S]) Eff[S] {
+	return Map(iterate(foldState[S]{acc: init}, func(s foldState[S]) (Eff[foldState[S]], bool) {
+		if s.i == len(xs) {
+			return nil, false
+		}
+		return Map(step(s.acc, xs[s.i]), func(acc S) foldState[S] {
+			return foldState[S]{acc: acc, i: s.i + 1}
+		}), true
+	}), func(s foldState[S]) S { return s.acc })
+}
+
+// FoldMExpr is the Expr counterpart of [FoldM].
+func FoldMExpr[S, A any](init S, xs []A, step func(S, A) Expr[S]) Expr[S] {
+	return ExprMap(iterateExpr(foldState[S]{acc: init}, func(s foldState[S]) (Expr[foldState[S]], bool) {
+		if s.i == len(xs) {
+			return Expr[foldState[S]]{}, false
+		}
+		return ExprMap(step(s.acc, xs[s.i]), func(acc S) foldState[S] {
+			return foldState[S]{acc: acc, i: s.i + 1}
+		}), true
+	}), func(s foldState[S]) S { return s.acc })
+}
+
+// WhileM runs body for as long as cond completes with true, checking cond
+// before each run. Iterations run in a loop rather than by recursion, so
+// long-running loops neither deepen the stack nor build a closure chain.
+func WhileM(cond Eff[bool], body Eff[struct{}]) Eff[struct{}] {
+	step := Bind(cond, func(c bool) Eff[bool] {
+		if !c {
+			return Pure(false)
+		}
+		return Then(body, Pure(true))
+	})
+	return loopM(step)
+}
+
+// UntilM runs body, then cond, repeating until cond completes with true.
+// body runs at least once. Like [WhileM], it runs in a loop.
+func UntilM(body Eff[struct{}], cond Eff[bool]) Eff[struct{}] {
+	return loopM(Then(body, Map(cond, func(c bool) bool { return !c })))
+}
+
+// loopM runs step until it completes with false.
+func loopM(step Eff[bool]) Eff[struct{}] {
+	return Map(iterate(true, func(more bool) (Eff[bool], bool) {
+		return step, more
+	}), func(bool) struct{} { return struct{}{} })
+}
+
+// WhileMExpr is the Expr counterpart of [WhileM]. Each iteration links
+// body and cond with pooled frames, which the evaluator returns to their
+// pools as the iteration completes.
+func WhileMExpr(cond Expr[bool], body Expr[struct{}]) Expr[struct{}] {
+	l := &exprLoop{body: eraseExpr(body), cond: eraseExpr(cond), while: true}
+	return Expr[struct{}]{Frame: &ThenFrame[Erased, Erased]{
+		Second: l.cond,
+		Next:   &BindFrame[Erased, Erased]{F: l.next, Next: ReturnFrame{}},
+	}}
+}
+
+// UntilMExpr is the Expr counterpart of [UntilM], linking iterations with
+// pooled frames like [WhileMExpr].
+func UntilMExpr(body Expr[struct{}], cond Expr[bool]) Expr[struct{}] {
+	l := &exprLoop{body: eraseExpr(body), cond: eraseExpr(cond)}
+	return Expr[struct{}]{Value: body.Value, Frame: ChainFrames(body.Frame, &ThenFrame[Erased, Erased]{
+		Second: l.cond,
+		Next:   &BindFrame[Erased, Erased]{F: l.next, Next: ReturnFrame{}},
+	})}
+}
+
+// exprLoop repeats body, then cond, until cond completes with !while.
+type exprLoop struct {
+	body, cond Expr[Erased]
+	while      bool
+}
+
+func eraseExpr[A any](m Expr[A]) Expr[Erased] {
+	return Expr[Erased]{Value: Erased(m.Value), Frame: m.Frame}
+}
+
+// next continues the loop with the result of cond.
+func (l *exprLoop) next(c Erased) Expr[Erased] {
+	if c.(bool) != l.while {
+		return Expr[Erased]{Value: Erased(struct{}{}), Frame: ReturnFrame{}}
+	}
+	return l.iteration()
+}
+
+// iteration runs body and cond once, then next.
+func (l *exprLoop) iteration() Expr[Erased] {
+	bf := AcquireBindFrame()
+	bf.F = l.next
+	bf.Next = ReturnFrame{}
+	tf := AcquireThenFrame()
+	tf.Second = l.cond
+	tf.Next = bf
+	return Expr[Erased]{Value: l.body.Value, Frame: chainFromPool(l.body.Frame, tf)}
+}
+
+// ReplicateM runs m n times in order and completes with the n results.
+// Like [Sequence], it runs in a loop rather than by recursion.
+func ReplicateM[A any](n int, m Eff[A]) Eff[[]A] {
+	return iterate([]A(nil), func(s []A) (Eff[[]A], bool) {
+		if len(s) >= n {
+			return nil, false
+		}
+		if s == nil {
+			s = make([]A, 0, n)
+		}
+		return Map(m, extend(s)), true
+	})
+}
+
+// ReplicateMExpr is the Expr counterpart of [ReplicateM].
+func ReplicateMExpr[A any](n int, m Expr[A]) Expr[[]A] {
+	return iterateExpr([]A(nil), func(s []A) (Expr[[]A], bool) {
+		if len(s) >= n {
+			return Expr[[]A]{}, false
+		}
+		if s == nil {
+			s = make([]A, 0, n)
+		}
+		return ExprMap(m, extend(s)), true
+	})
+}
+
+// Forever runs m again each time it completes. The loop ends only when a
+// handler short-circuits an operation m performs, with that handler's
+// value; m must therefore perform one. The result type B is that of the
+// enclosing computation, as Forever never completes itself.
+func Forever[B, A any](m Eff[A]) Eff[B] {
+	step := Then(m, Pure(struct{}{}))
+	return Map(iterate(struct{}{}, func(struct{}) (Eff[struct{}], bool) {
+		return step, true
+	}), func(struct{}) B { panic("kont: Forever completed") })
+}
+
+// ForeverExpr is the Expr counterpart of [Forever]. Iterations are linked
+// with pooled frames.
+func ForeverExpr[B, A any](m Expr[A]) Expr[B] {
+	step := ExprThen(m, ExprReturn(struct{}{}))
+	return ExprMap(iterateExpr(struct{}{}, func(struct{}) (Expr[struct{}], bool) {
+		return step, true
+	}), func(struct{}) B { panic("kont: Forever completed") })
+}
@@ -0,0 +1,147 @@
+// ©Hayabusa Cloud Co., Ltd. 2026. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package kont_test
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"code.hybscloud.com/kont"
+)
+
+func TestFoldM(t *testing.T) {
+	// Sum the elements, logging each running total.
+	step := func(acc, x int) kont.Eff[int] {
+		return kont.Then(kont.Perform(kont.Tell[int]{Value: acc + x}), kont.Pure(acc+x))
+	}
+	got, log := kont.RunWriter[int](kont.FoldM(100, []int{1, 2, 3}, step))
+	if got != 106 || len(log) != 3 || log[2] != 106 {
+		t.Fatalf("got %d, log %v", got, log)
+	}
+	if got := kont.RunPure(kont.FoldMExpr(7, []int(nil), func(acc, x int) kont.Expr[int] { return kont.ExprReturn(acc + x) })); got != 7 {
+		t.Fatalf("empty fold got %d", got)
+	}
+}
+
+func TestFoldMStackSafe(t *testing.T) {
+	xs := make([]int, 1_000_000)
+	step := func(acc, _ int) kont.Eff[int] {
+		if acc%2 == 0 {
+			return kont.Pure(acc + 1)
+		}
+		return kont.Map(kont.Perform(kont.Ask[int]{}), func(n int) int { return acc + n })
+	}
+	if got := kont.RunReader(1, kont.FoldM(0, xs, step)); got != len(xs) {
+		t.Fatalf("got %d", got)
+	}
+	stepExpr := func(acc, x int) kont.Expr[int] { return kont.Reify(step(acc, x)) }
+	if got := kont.RunReaderExpr(1, kont.FoldMExpr(0, xs, stepExpr)); got != len(xs) {
+		t.Fatalf("Expr got %d", got)
+	}
+}
+
+// countdown is a WhileM loop decrementing state to zero.
+func countdown() (kont.Eff[bool], kont.Eff[struct{}]) {
+	cond := kont.Map(kont.Perform(kont.Get[int]{}), func(n int) bool { return n > 0 })
+	body := kont.Then(kont.Perform(kont.Modify[int]{F: func(n int) int { return n - 1 }}), kont.Pure(struct{}{}))
+	return cond, body
+}
+
+// countdownExpr is the Expr counterpart of countdown.
+func countdownExpr() (kont.Expr[bool], kont.Expr[struct{}]) {
+	cond := kont.ExprMap(kont.ExprPerform(kont.Get[int]{}), func(n int) bool { return n > 0 })
+	body := kont.ExprThen(kont.ExprPerform(kont.Modify[int]{F: func(n int) int { return n - 1 }}), kont.ExprReturn(struct{}{}))
+	return cond, body
+}
+
+func TestWhileM(t *testing.T) {
+	cond, body := countdown()
+	if _, n := kont.RunState[int](1_000_000, kont.WhileM(cond, body)); n != 0 {
+		t.Fatalf("state %d", n)
+	}
+	// cond is checked first: a false condition runs no iteration.
+	if _, n := kont.RunState[int](-3, kont.WhileM(cond, body)); n != -3 {
+		t.Fatalf("state %d", n)
+	}
+	condExpr, bodyExpr := countdownExpr()
+	loop := kont.WhileMExpr(condExpr, bodyExpr)
+	for _, start := range []int{100_000, 0, 7} {
+		if _, n := kont.RunStateExpr[int](start, loop); n != 0 {
+			t.Fatalf("Expr from %d: state %d", start, n)
+		}
+	}
+}
+
+func TestUntilM(t *testing.T) {
+	cond, body := countdown()
+	done := kont.Map(cond, func(more bool) bool { return !more })
+	// body runs at least once.
+	if _, n := kont.RunState[int](0, kont.UntilM(body, done)); n != -1 {
+		t.Fatalf("state %d", n)
+	}
+	if _, n := kont.RunState[int](500_000, kont.UntilM(body, done)); n != 0 {
+		t.Fatalf("state %d", n)
+	}
+	condExpr, bodyExpr := countdownExpr()
+	loop := kont.UntilMExpr(bodyExpr, kont.ExprMap(condExpr, func(more bool) bool { return !more }))
+	for start, want := range map[int]int{0: -1, 100_000: 0} {
+		if _, n := kont.RunStateExpr[int](start, loop); n != want {
+			t.Fatalf("Expr from %d: state %d", start, n)
+		}
+	}
+}
+
+func TestWhileMExprPure(t *testing.T) {
+	// A loop over pure Exprs runs on the trampoline, not the stack.
+	n := 0
+	cond := kont.ExprMap(kont.ExprPerform(kont.Ask[int]{}), func(limit int) bool { n++; return n < limit })
+	kont.RunReaderExpr(1_000_000, kont.WhileMExpr(cond, kont.ExprReturn(struct{}{})))
+	if n != 1_000_000 {
+		t.Fatalf("checked %d times", n)
+	}
+}
+
+func BenchmarkWhileMExpr(b *testing.B) {
+	cond, body := countdownExpr()
+	b.ReportAllocs()
+	kont.RunStateExpr[int](b.N, kont.WhileMExpr(cond, body))
+}
+
+func TestReplicateM(t *testing.T) {
+	next := kont.Perform(kont.Modify[int]{F: func(n int) int { return n * 2 }})
+	got, n := kont.RunState[int](1, kont.ReplicateM(4, next))
+	if !slices.Equal(got, []int{2, 4, 8, 16}) || n != 16 {
+		t.Fatalf("got %v, state %d", got, n)
+	}
+	gotExpr, _ := kont.RunStateExpr[int](1, kont.ReplicateMExpr(3, kont.ExprPerform(kont.Modify[int]{F: func(n int) int { return n + 1 }})))
+	if !slices.Equal(gotExpr, []int{2, 3, 4}) {
+		t.Fatalf("got %v", gotExpr)
+	}
+	if got := kont.RunPure(kont.ReplicateMExpr(0, kont.ExprReturn(1))); len(got) != 0 {
+		t.Fatalf("got %v", got)
+	}
+	if got := kont.RunPure(kont.ReplicateMExpr(3, kont.ExprReturn(1))); !slices.Equal(got, []int{1, 1, 1}) {
+		t.Fatalf("got %v", got)
+	}
+}
+
+func TestForever(t *testing.T) {
+	// Count Ticks until the handler stops the loop.
+	ticks := 0
+	h := kont.HandleFunc[string](func(op kont.Operation) (kont.Resumed, bool) {
+		if ticks++; ticks == 100_000 {
+			return "stopped", false
+		}
+		return time.Millisecond, true
+	})
+	if got := kont.Handle(kont.Forever[string](kont.AwaitTick()), h); got != "stopped" || ticks != 100_000 {
+		t.Fatalf("got %q after %d ticks", got, ticks)
+	}
+	ticks = 0
+	if got := kont.HandleExpr(kont.ForeverExpr[string](kont.ExprAwaitTick()), h); got != "stopped" || ticks != 100_000 {
+		t.Fatalf("Expr got %q after %d ticks", got, ticks)
+	}
+}
@@ -15,11 +15,16 @@ type genericMarker struct {
 	resume func(*genericMarker, Resumed) Resumed
 	f      any
 	k      any
+	at     provenance
 }
 
 func (m *genericMarker) Op() Operation            { return m.op }
 func (m *genericMarker) Resume(v Resumed) Resumed { return m.resume(m, v) }
-func (m *genericMarker) release()                 { releaseMarker(m) }
+func (m *genericMarker) resumeWithError(throw Operation) Resumed {
+	return &injectedSuspension{op: throw, inner: m}
+}
+func (m *genericMarker) release()         { releaseMarker(m) }
+func (m *genericMarker) site() provenance { return m.at }
 
 func acquireMarker() *genericMarker {
 	return genericMarkerPool.Get().(*genericMarker)
@@ -30,5 +35,6 @@ func releaseMarker(m *genericMarker) {
 	m.resume = nil
 	m.f = nil
 	m.k = nil
+	m.at = provenance{}
 	genericMarkerPool.Put(m)
 }
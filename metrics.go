@@ -0,0 +1,83 @@
+// ©Hayabusa Cloud Co., Ltd. 2026. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package kont
+
+import "time"
+
+// Dispatch metrics.
+// Metrics is bound to a backend such as Prometheus or expvar by the caller.
+// Only the metered runners and suspensions passed to Suspension.Meter
+// report to it. Other runners pay nothing; other suspensions pay only a
+// nil check when consumed.
+
+// Metrics receives dispatch, run, and suspension measurements.
+// Implementations called from concurrent runs must be safe for concurrent use.
+type Metrics interface {
+	// Dispatched is called after a handler dispatches op, with the time
+	// the handler took and whether it resumed the computation.
+	Dispatched(op Operation, latency time.Duration, resumed bool)
+	// RunDispatches is called when a metered run returns, with the number
+	// of operations it dispatched.
+	RunDispatches(n int)
+	// SuspensionEnded is called when a metered suspension is resumed or
+	// discarded, with the operation it was suspended on and its lifetime.
+	SuspensionEnded(op Operation, lifetime time.Duration)
+}
+
+// meteredHandler reports every dispatch of an inner handler.
+type meteredHandler[H Handler[H, R], R any] struct {
+	inner      H
+	metrics    Metrics
+	dispatches int
+}
+
+// Dispatch implements Handler by timing the inner handler.
+func (h *meteredHandler[H, R]) Dispatch(op Operation) (Resumed, bool) {
+	start := time.Now()
+	v, resume := h.inner.Dispatch(op)
+	h.dispatches++
+	h.metrics.Dispatched(op, time.Since(start), resume)
+	return v, resume
+}
+
+// HandleMetered runs m with h like [Handle], reporting each dispatch and
+// the run's dispatch count to metrics.
+func HandleMetered[H Handler[H, R], R any](m Cont[Resumed, R], h H, metrics Metrics) R {
+	mh := &meteredHandler[H, R]{inner: h, metrics: metrics}
+	result := Handle(m, mh)
+	metrics.RunDispatches(mh.dispatches)
+	return result
+}
+
+// HandleExprMetered is the Expr counterpart of [HandleMetered].
+func HandleExprMetered[H Handler[H, R], R any](m Expr[R], h H, metrics Metrics) R {
+	mh := &meteredHandler[H, R]{inner: h, metrics: metrics}
+	result := HandleExpr(m, mh)
+	metrics.RunDispatches(mh.dispatches)
+	return result
+}
+
+// Meter reports the lifetime of s, and of every suspension it leads to, to
+// metrics when each is resumed or discarded. The lifetime of s is measured
+// from the call, as by [Suspension.TrackAge]. A nil metrics stops reporting.
+func (s *Suspension[A]) Meter(metrics Metrics) {
+	if metrics == nil {
+		if s.opt != nil {
+			s.opt.metrics = nil
+		}
+		return
+	}
+	s.options().metrics = metrics
+	s.TrackAge()
+}
+
+// ended runs when the suspension is consumed: it stops leak tracking and
+// reports the suspension's lifetime if it is metered.
+func (s *Suspension[A]) ended() {
+	s.leak.untrack()
+	if o := s.opt; o != nil && o.metrics != nil {
+		o.metrics.SuspensionEnded(s.op, s.Age())
+	}
+}
@@ -0,0 +1,90 @@
+// ©Hayabusa Cloud Co., Ltd. 2026. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package kont_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"code.hybscloud.com/kont"
+)
+
+// countingMetrics counts measurements by operation type.
+type countingMetrics struct {
+	dispatched map[string]int
+	stopped    int
+	runs       []int
+	ended      map[string]int
+}
+
+func newCountingMetrics() *countingMetrics {
+	return &countingMetrics{dispatched: map[string]int{}, ended: map[string]int{}}
+}
+
+func (m *countingMetrics) Dispatched(op kont.Operation, _ time.Duration, resumed bool) {
+	m.dispatched[fmt.Sprintf("%T", op)]++
+	if !resumed {
+		m.stopped++
+	}
+}
+
+func (m *countingMetrics) RunDispatches(n int) { m.runs = append(m.runs, n) }
+
+func (m *countingMetrics) SuspensionEnded(op kont.Operation, lifetime time.Duration) {
+	if lifetime < 0 {
+		panic("negative lifetime")
+	}
+	m.ended[fmt.Sprintf("%T", op)]++
+}
+
+func TestHandleMetered(t *testing.T) {
+	m := newCountingMetrics()
+	st, _ := kont.StateHandler[int, int](1)
+	comp := kont.GetState(func(s int) kont.Eff[int] {
+		return kont.PutState(s+1, kont.Perform(kont.Get[int]{}))
+	})
+	if got := kont.HandleMetered(comp, st, m); got != 2 {
+		t.Fatalf("got %d", got)
+	}
+	if m.dispatched["kont.Get[int]"] != 2 || m.dispatched["kont.Put[int]"] != 1 {
+		t.Fatalf("dispatched %v", m.dispatched)
+	}
+	if len(m.runs) != 1 || m.runs[0] != 3 {
+		t.Fatalf("runs %v", m.runs)
+	}
+}
+
+func TestHandleExprMeteredShortCircuit(t *testing.T) {
+	m := newCountingMetrics()
+	h := kont.HandleFunc[int](func(kont.Operation) (kont.Resumed, bool) { return -1, false })
+	if got := kont.HandleExprMetered(kont.ExprPerform(kont.Ask[int]{}), h, m); got != -1 {
+		t.Fatalf("got %d", got)
+	}
+	if m.stopped != 1 || m.runs[0] != 1 {
+		t.Fatalf("stopped %d runs %v", m.stopped, m.runs)
+	}
+}
+
+func TestSuspensionMeter(t *testing.T) {
+	m := newCountingMetrics()
+	comp := kont.ExprBind(kont.ExprPerform(kont.Get[int]{}), func(n int) kont.Expr[int] {
+		return kont.ExprPerform(kont.Ask[int]{})
+	})
+	_, susp := kont.StepExpr(comp)
+	susp.Meter(m)
+	_, susp = susp.Resume(1)
+	susp.Discard()
+	susp.Discard()
+	if m.ended["kont.Get[int]"] != 1 || m.ended["kont.Ask[int]"] != 1 {
+		t.Fatalf("ended %v", m.ended)
+	}
+
+	_, unmetered := kont.StepExpr(comp)
+	unmetered.Discard()
+	if len(m.ended) != 2 || m.ended["kont.Get[int]"] != 1 {
+		t.Fatalf("unmetered suspension reported: %v", m.ended)
+	}
+}
@@ -0,0 +1,148 @@
+// ©Hayabusa Cloud Co., Ltd. 2026. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package kont
+
+import "fmt"
+
+// Handler middleware chains.
+// A chain assembles dispatch decorators in a fixed, checked order so that,
+// for example, tracing always observes the retried dispatch as one operation
+// and retries never re-run an authorization check.
+
+// DispatchFunc is the dispatch signature shared by handlers and middleware.
+type DispatchFunc func(op Operation) (Resumed, bool)
+
+// MiddlewareStage orders middleware in a chain. Stages run outermost first:
+// every Observe middleware sees an operation before any Guard middleware,
+// and Guard before Recover.
+type MiddlewareStage int
+
+const (
+	// StageObserve is for tracing, metrics, and logging.
+	StageObserve MiddlewareStage = iota
+	// StageGuard is for authorization, rate limits, and timeouts.
+	StageGuard
+	// StageRecover is for retries and hedging, closest to the handler.
+	StageRecover
+)
+
+func (s MiddlewareStage) String() string {
+	switch s {
+	case StageObserve:
+		return "Observe"
+	case StageGuard:
+		return "Guard"
+	case StageRecover:
+		return "Recover"
+	}
+	return fmt.Sprintf("MiddlewareStage(%d)", int(s))
+}
+
+// HandlerMiddleware decorates dispatch. Wrap receives the next dispatch in
+// the chain and returns the decorated one.
+type HandlerMiddleware struct {
+	Name  string
+	Stage MiddlewareStage
+	Wrap  func(next DispatchFunc) DispatchFunc
+}
+
+// HandlerChain is an ordered, immutable list of middleware.
+type HandlerChain struct {
+	middlewares []HandlerMiddleware
+}
+
+// Chain assembles middlewares into a HandlerChain. The first middleware is
+// outermost. Panics if stages are not in non-decreasing order, if a name is
+// empty or repeated, or if Wrap is nil.
+func Chain(middlewares ...HandlerMiddleware) *HandlerChain {
+	seen := make(map[string]bool, len(middlewares))
+	for i, mw := range middlewares {
+		if mw.Name == "" || mw.Wrap == nil {
+			panic(fmt.Sprintf("kont: middleware %d needs a Name and Wrap", i))
+		}
+		if seen[mw.Name] {
+			panic("kont: duplicate middleware " + mw.Name)
+		}
+		seen[mw.Name] = true
+		if i > 0 && mw.Stage < middlewares[i-1].Stage {
+			prev := middlewares[i-1]
+			panic(fmt.Sprintf("kont: middleware %s (%v) must not be inside %s (%v)", mw.Name, mw.Stage, prev.Name, prev.Stage))
+		}
+	}
+	return &HandlerChain{middlewares: append([]HandlerMiddleware(nil), middlewares...)}
+}
+
+// Names returns the middleware names, outermost first.
+func (c *HandlerChain) Names() []string {
+	names := make([]string, len(c.middlewares))
+	for i, mw := range c.middlewares {
+		names[i] = mw.Name
+	}
+	return names
+}
+
+// String describes the chain, outermost first.
+func (c *HandlerChain) String() string {
+	s := "Chain("
+	for i, mw := range c.middlewares {
+		if i > 0 {
+			s += " → "
+		}
+		s += mw.Name + "/" + mw.Stage.String()
+	}
+	return s + ")"
+}
+
+// chainHandler dispatches through a HandlerChain to an inner handler.
+type chainHandler[H Handler[H, R], R any] struct {
+	chain    *HandlerChain
+	dispatch DispatchFunc
+}
+
+// Dispatch implements Handler through the assembled middleware.
+func (h *chainHandler[H, R]) Dispatch(op Operation) (Resumed, bool) {
+	return h.dispatch(op)
+}
+
+// Chain returns the chain the handler was assembled from.
+func (h *chainHandler[H, R]) Chain() *HandlerChain { return h.chain }
+
+// ApplyChain wraps inner with every middleware in c, the first outermost.
+// Returns a concrete handler whose Chain method reports c.
+func ApplyChain[R any, H Handler[H, R]](c *HandlerChain, inner H) *chainHandler[H, R] {
+	dispatch := DispatchFunc(inner.Dispatch)
+	for i := len(c.middlewares) - 1; i >= 0; i-- {
+		dispatch = c.middlewares[i].Wrap(dispatch)
+	}
+	return &chainHandler[H, R]{chain: c, dispatch: dispatch}
+}
+
+// wrappedHandler observes every dispatch of an inner handler.
+type wrappedHandler[H Handler[H, R], R any] struct {
+	inner  H
+	before func(op Operation)
+	after  func(op Operation, v Resumed, resume bool)
+}
+
+// Dispatch implements Handler by calling before, the inner handler, and after.
+func (h *wrappedHandler[H, R]) Dispatch(op Operation) (Resumed, bool) {
+	if h.before != nil {
+		h.before(op)
+	}
+	v, resume := h.inner.Dispatch(op)
+	if h.after != nil {
+		h.after(op, v, resume)
+	}
+	return v, resume
+}
+
+// WrapHandler wraps inner so before sees every operation and after sees its
+// outcome, for logging and metrics. Either may be nil. after is not called
+// when inner panics. The wrapper is itself a handler, so it can be passed to
+// Handle, HandleExpr, or wrapped again.
+// Returns a concrete handler.
+func WrapHandler[R any, H Handler[H, R]](inner H, before func(op Operation), after func(op Operation, v Resumed, resume bool)) *wrappedHandler[H, R] {
+	return &wrappedHandler[H, R]{inner: inner, before: before, after: after}
+}
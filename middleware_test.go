@@ -0,0 +1,107 @@
+// ©Hayabusa Cloud Co., Ltd. 2026. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package kont_test
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+
+	"code.hybscloud.com/kont"
+)
+
+func recording(log *[]string, name string, stage kont.MiddlewareStage) kont.HandlerMiddleware {
+	return kont.HandlerMiddleware{
+		Name:  name,
+		Stage: stage,
+		Wrap: func(next kont.DispatchFunc) kont.DispatchFunc {
+			return func(op kont.Operation) (kont.Resumed, bool) {
+				*log = append(*log, name)
+				return next(op)
+			}
+		},
+	}
+}
+
+func TestChainOrder(t *testing.T) {
+	var log []string
+	c := kont.Chain(
+		recording(&log, "trace", kont.StageObserve),
+		recording(&log, "authz", kont.StageGuard),
+		recording(&log, "retry", kont.StageRecover),
+	)
+	inner := kont.HandleFunc[int](func(kont.Operation) (kont.Resumed, bool) {
+		log = append(log, "handler")
+		return 5, true
+	})
+	h := kont.ApplyChain[int](c, inner)
+	if got := kont.Handle(kont.Perform(kont.Get[int]{}), h); got != 5 {
+		t.Fatalf("got %d, want 5", got)
+	}
+	if want := []string{"trace", "authz", "retry", "handler"}; !slices.Equal(log, want) {
+		t.Fatalf("got %v, want %v", log, want)
+	}
+	if !slices.Equal(h.Chain().Names(), []string{"trace", "authz", "retry"}) {
+		t.Fatalf("got names %v", h.Chain().Names())
+	}
+	if got := c.String(); got != "Chain(trace/Observe → authz/Guard → retry/Recover)" {
+		t.Fatalf("got %q", got)
+	}
+}
+
+func TestChainRejectsMisorderedStages(t *testing.T) {
+	var log []string
+	defer func() {
+		if r := recover(); r != "kont: middleware trace (Observe) must not be inside retry (Recover)" {
+			t.Fatalf("got panic %v", r)
+		}
+	}()
+	kont.Chain(recording(&log, "retry", kont.StageRecover), recording(&log, "trace", kont.StageObserve))
+}
+
+func TestChainRejectsDuplicateNames(t *testing.T) {
+	var log []string
+	defer func() {
+		if r := recover(); r != "kont: duplicate middleware trace" {
+			t.Fatalf("got panic %v", r)
+		}
+	}()
+	kont.Chain(recording(&log, "trace", kont.StageObserve), recording(&log, "trace", kont.StageObserve))
+}
+
+func TestEmptyChain(t *testing.T) {
+	h := kont.ApplyChain[int](kont.Chain(), kont.HandleFunc[int](func(kont.Operation) (kont.Resumed, bool) { return 1, true }))
+	if got := kont.Handle(kont.Perform(kont.Get[int]{}), h); got != 1 {
+		t.Fatalf("got %d", got)
+	}
+}
+
+func TestWrapHandlerObservesDispatch(t *testing.T) {
+	st, state := kont.StateHandler[int, int](1)
+	var trace []string
+	h := kont.WrapHandler[int](st,
+		func(op kont.Operation) { trace = append(trace, fmt.Sprintf("before %T", op)) },
+		func(op kont.Operation, v kont.Resumed, resume bool) {
+			trace = append(trace, fmt.Sprintf("after %T %v %v", op, v, resume))
+		})
+	comp := kont.GetState(func(s int) kont.Eff[int] { return kont.PutState(s+1, kont.Pure(s)) })
+	if got := kont.Handle(comp, h); got != 1 || state() != 2 {
+		t.Fatalf("got %d state %d", got, state())
+	}
+	want := []string{"before kont.Get[int]", "after kont.Get[int] 1 true", "before kont.Put[int]", "after kont.Put[int] {} true"}
+	if !slices.Equal(trace, want) {
+		t.Fatalf("trace %q", trace)
+	}
+}
+
+func TestWrapHandlerExprAndStack(t *testing.T) {
+	st := kont.NewStack[int]()
+	kont.StackReader(st, 4)
+	count := 0
+	h := kont.WrapHandler[int](st, nil, func(kont.Operation, kont.Resumed, bool) { count++ })
+	if got := kont.HandleExpr(kont.ExprPerform(kont.Ask[int]{}), h); got != 4 || count != 1 {
+		t.Fatalf("got %d count %d", got, count)
+	}
+}
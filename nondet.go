@@ -0,0 +1,168 @@
+// ©Hayabusa Cloud Co., Ltd. 2026. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package kont
+
+// Non-determinism effect operations.
+// Choose forks the computation once per option and Fail abandons a branch.
+// RunNonDet resumes the captured continuation once per option, depth-first,
+// and collects the result of every branch that completes.
+
+// Choose is the effect operation for non-deterministic choice.
+// Under RunNonDet, the continuation is resumed once with each option in order.
+type Choose[A any] struct{ Options []A }
+
+func (Choose[A]) OpResult() A { panic("phantom") }
+
+func (o Choose[A]) choices(yield func(Resumed)) {
+	for _, x := range o.Options {
+		yield(x)
+	}
+}
+
+// Fail is the effect operation for abandoning a non-deterministic branch.
+type Fail struct{}
+
+func (Fail) OpResult() Resumed { panic("phantom") }
+
+// nonDetChoice is implemented by every Choose instantiation.
+type nonDetChoice interface {
+	choices(yield func(Resumed))
+}
+
+// choiceSuspension is an unpooled suspension that may be resumed any number
+// of times, one per option.
+type choiceSuspension[A any] struct {
+	op Choose[A]
+	k  func(A) Resumed
+	at provenance
+}
+
+func (s *choiceSuspension[A]) Op() Operation            { return s.op }
+func (s *choiceSuspension[A]) Resume(v Resumed) Resumed { return s.k(v.(A)) }
+func (s *choiceSuspension[A]) resumeWithError(throw Operation) Resumed {
+	return &injectedSuspension{op: throw, inner: s}
+}
+func (s *choiceSuspension[A]) release()         {}
+func (s *choiceSuspension[A]) site() provenance { return s.at }
+func (s *choiceSuspension[A]) multiShot()       {}
+
+// Amb performs Choose with options. Unlike Perform(Choose{...}), the captured
+// continuation is multi-shot, as RunNonDet requires.
+func Amb[A any](options ...A) Cont[Resumed, A] {
+	op := Choose[A]{Options: options}
+	at := captureProvenance()
+	return func(k func(A) Resumed) Resumed {
+		return &choiceSuspension[A]{op: op, k: k, at: at}
+	}
+}
+
+// FailBranch performs Fail, abandoning the current branch.
+func FailBranch[A any]() Cont[Resumed, A] {
+	resume := effectMarkerResume[A]
+	at := captureProvenance()
+	return func(k func(A) Resumed) Resumed {
+		m := acquireMarker()
+		m.op = Fail{}
+		m.k = k
+		m.resume = resume
+		m.at = at
+		return m
+	}
+}
+
+// ExprAmb is the Expr counterpart of [Amb].
+func ExprAmb[A any](options ...A) Expr[A] {
+	return ExprPerform(Choose[A]{Options: options})
+}
+
+// ExprFailBranch creates an Expr that performs Fail.
+// Constructs EffectFrame directly because Fail.OpResult() returns
+// Resumed, not A — ExprPerform would produce Expr[Resumed].
+func ExprFailBranch[A any]() Expr[A] {
+	var zero A
+	return Expr[A]{
+		Value: zero,
+		Frame: &EffectFrame[Erased]{
+			Operation: Fail{},
+			Resume:    identityResume,
+			Next:      ReturnFrame{},
+			at:        captureProvenance(),
+		},
+	}
+}
+
+// RunNonDet runs a non-deterministic computation and returns the results of
+// all branches that complete, in depth-first option order.
+// Choose must be performed with [Amb]; suspensions wrapped by [MapError] or
+// [Recover] are one-shot and panic if chosen from.
+func RunNonDet[A any](m Cont[Resumed, A]) []A {
+	var out []A
+	runNonDet(m(toResumed[A]), &out)
+	return out
+}
+
+func runNonDet[A any](r Resumed, out *[]A) {
+	s, ok := r.(effectSuspension)
+	if !ok {
+		*out = append(*out, valueOrZero[A](r))
+		return
+	}
+	switch op := s.Op().(type) {
+	case nonDetChoice:
+		if _, ok := s.(interface{ multiShot() }); !ok {
+			panic("kont: Choose performed without Amb cannot be resumed more than once")
+		}
+		op.choices(func(v Resumed) { runNonDet(s.Resume(v), out) })
+	case Fail:
+		s.release()
+	default:
+		unhandledEffect("NonDetHandler", op)
+	}
+}
+
+// RunNonDetExpr is the Expr counterpart of [RunNonDet].
+// Frames following a Choose are evaluated once per option without being
+// released, so the Expr must not rely on pooled frames being recycled.
+func RunNonDetExpr[A any](m Expr[A]) []A {
+	var out []A
+	evalFrames(Erased(m.Value), m.Frame, nonDetProcessor[A]{out: &out})
+	return out
+}
+
+// nonDetProcessor evaluates every option of a Choose against the remaining
+// frames and collects completed branches.
+type nonDetProcessor[A any] struct{ out *[]A }
+
+func (p nonDetProcessor[A]) processEffect(f *EffectFrame[Erased], rest Frame) (Erased, Frame, struct{}, bool) {
+	switch op := f.Operation.(type) {
+	case nonDetChoice:
+		rest = detachFrames(rest)
+		op.choices(func(v Resumed) { evalFrames(f.Resume(v), rest, p) })
+	case Fail:
+	default:
+		unhandledEffect("NonDetHandler", op)
+	}
+	return nil, nil, struct{}{}, false
+}
+
+func (p nonDetProcessor[A]) processReturn(current Erased) struct{} {
+	*p.out = append(*p.out, valueOrZero[A](current))
+	return struct{}{}
+}
+
+func (nonDetProcessor[A]) ownsFrames() bool { return false }
+
+// detachFrames replaces the transient pooled chain nodes in f with unpooled
+// ones, so the chain survives being evaluated more than once. Unpooled nodes
+// come from ChainFrames and only link caller frames, so they are kept as is.
+func detachFrames(f Frame) Frame {
+	cf, ok := f.(*chainedFrame)
+	if !ok || !cf.pooled {
+		return f
+	}
+	first, rest := detachFrames(cf.first), detachFrames(cf.rest)
+	releaseChain(cf)
+	return &chainedFrame{first: first, rest: rest}
+}
@@ -0,0 +1,68 @@
+// ©Hayabusa Cloud Co., Ltd. 2026. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package kont_test
+
+import (
+	"slices"
+	"testing"
+
+	"code.hybscloud.com/kont"
+)
+
+func TestRunNonDetCollectsBranches(t *testing.T) {
+	comp := kont.Bind(kont.Amb(1, 2, 3), func(a int) kont.Eff[[2]int] {
+		return kont.Bind(kont.Amb(1, 2, 3), func(b int) kont.Eff[[2]int] {
+			if a+b != 4 {
+				return kont.FailBranch[[2]int]()
+			}
+			return kont.Pure([2]int{a, b})
+		})
+	})
+	want := [][2]int{{1, 3}, {2, 2}, {3, 1}}
+	if got := kont.RunNonDet(comp); !slices.Equal(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+}
+
+func TestRunNonDetAllFail(t *testing.T) {
+	comp := kont.Then(kont.Amb("x", "y"), kont.FailBranch[int]())
+	if got := kont.RunNonDet(comp); len(got) != 0 {
+		t.Fatalf("got %v, want no results", got)
+	}
+	if got := kont.RunNonDet(kont.Pure(7)); !slices.Equal(got, []int{7}) {
+		t.Fatalf("got %v", got)
+	}
+}
+
+func TestRunNonDetExpr(t *testing.T) {
+	comp := kont.ExprBind(kont.ExprAmb(1, 2, 3, 4), func(a int) kont.Expr[int] {
+		if a%2 != 0 {
+			return kont.ExprFailBranch[int]()
+		}
+		return kont.ExprMap(kont.ExprAmb(0, 10), func(b int) int { return a + b })
+	})
+	comp = kont.ExprMap(comp, func(x int) int { return x * 2 })
+	if got, want := kont.RunNonDetExpr(comp), []int{4, 24, 8, 28}; !slices.Equal(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+}
+
+func TestRunNonDetRequiresAmb(t *testing.T) {
+	defer func() {
+		if r := recover(); r != "kont: Choose performed without Amb cannot be resumed more than once" {
+			t.Fatalf("got panic %v", r)
+		}
+	}()
+	kont.RunNonDet(kont.Perform(kont.Choose[int]{Options: []int{1, 2}}))
+}
+
+func TestNonDetHandlerUnhandledPanic(t *testing.T) {
+	defer func() {
+		if r := recover(); unhandledIn(r) != "NonDetHandler" {
+			t.Fatalf("got panic %v", r)
+		}
+	}()
+	kont.RunNonDet(kont.Perform(kont.Get[int]{}))
+}
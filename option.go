@@ -0,0 +1,166 @@
+// ©Hayabusa Cloud Co., Ltd. 2026. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package kont
+
+// Option represents a value that is either present (Some) or absent (None).
+type Option[A any] struct {
+	ok    bool
+	value A
+}
+
+// Some creates a present Option.
+func Some[A any](a A) Option[A] {
+	return Option[A]{ok: true, value: a}
+}
+
+// None creates an absent Option.
+func None[A any]() Option[A] {
+	return Option[A]{}
+}
+
+// IsSome returns true if a value is present.
+func (o Option[A]) IsSome() bool {
+	return o.ok
+}
+
+// IsNone returns true if no value is present.
+func (o Option[A]) IsNone() bool {
+	return !o.ok
+}
+
+// Get returns the value and true, or zero and false.
+func (o Option[A]) Get() (A, bool) {
+	return o.value, o.ok
+}
+
+// GetOrElse returns the value, or fallback if absent.
+func (o Option[A]) GetOrElse(fallback A) A {
+	if o.ok {
+		return o.value
+	}
+	return fallback
+}
+
+// MatchOption pattern matches on the Option, calling onNone or onSome.
+func MatchOption[A, T any](o Option[A], onNone func() T, onSome func(A) T) T {
+	if o.ok {
+		return onSome(o.value)
+	}
+	return onNone()
+}
+
+// MapOption applies a function to the present value.
+func MapOption[A, B any](o Option[A], f func(A) B) Option[B] {
+	if o.ok {
+		return Some(f(o.value))
+	}
+	return None[B]()
+}
+
+// FlatMapOption sequences two Option computations.
+func FlatMapOption[A, B any](o Option[A], f func(A) Option[B]) Option[B] {
+	if o.ok {
+		return f(o.value)
+	}
+	return None[B]()
+}
+
+// Maybe effect operations.
+// FailNone aborts a computation with an absent result.
+
+// FailNone is the effect operation for aborting with no result.
+// Perform(FailNone{}) under RunOption yields None.
+type FailNone struct{}
+
+func (FailNone) OpResult() Resumed { panic("phantom") }
+
+// DispatchOption handles FailNone in Option handler dispatch.
+// Returns (nil, false) — the handler short-circuits with None.
+func (FailNone) DispatchOption() (Resumed, bool) {
+	return nil, false
+}
+
+// FailOption performs FailNone, aborting the computation with None.
+// The continuation k is never called.
+func FailOption[A any]() Cont[Resumed, A] {
+	resume := effectMarkerResume[A]
+	at := captureProvenance()
+	return func(k func(A) Resumed) Resumed {
+		m := acquireMarker()
+		m.op = FailNone{}
+		m.k = k
+		m.resume = resume
+		m.at = at
+		return m
+	}
+}
+
+// FromOption continues with the present value, or performs FailNone.
+func FromOption[A any](o Option[A]) Cont[Resumed, A] {
+	if o.ok {
+		return Pure(o.value)
+	}
+	return FailOption[A]()
+}
+
+// ExprFailOption creates an Expr that performs FailNone.
+// Constructs EffectFrame directly because FailNone.OpResult() returns
+// Resumed, not A — ExprPerform would produce Expr[Resumed].
+func ExprFailOption[A any]() Expr[A] {
+	var zero A
+	return Expr[A]{
+		Value: zero,
+		Frame: &EffectFrame[Erased]{
+			Operation: FailNone{},
+			Resume:    identityResume,
+			Next:      ReturnFrame{},
+			at:        captureProvenance(),
+		},
+	}
+}
+
+// ExprFromOption is the Expr counterpart of [FromOption].
+func ExprFromOption[A any](o Option[A]) Expr[A] {
+	if o.ok {
+		return ExprReturn(o.value)
+	}
+	return ExprFailOption[A]()
+}
+
+// optionHandler implements Handler for the Maybe effect.
+type optionHandler[A any] struct{}
+
+// Dispatch implements Handler for the Maybe effect.
+func (optionHandler[A]) Dispatch(op Operation) (Resumed, bool) {
+	if oop, ok := op.(interface {
+		DispatchOption() (Resumed, bool)
+	}); ok {
+		v, shouldResume := oop.DispatchOption()
+		if !shouldResume {
+			return None[A](), false
+		}
+		return v, true
+	}
+	unhandledEffect("OptionHandler", op)
+	return nil, false
+}
+
+// someCont is the identity continuation for option runners.
+func someCont[A any](a A) Resumed { return Some(a) }
+
+// RunOption runs a computation that may perform FailNone and returns Option.
+func RunOption[A any](m Cont[Resumed, A]) Option[A] {
+	result := m(someCont[A])
+	if result == nil {
+		var zero A
+		return Some(zero)
+	}
+	return handleDispatch[optionHandler[A], Option[A]](result, optionHandler[A]{})
+}
+
+// RunOptionExpr runs an Expr that may perform FailNone and returns Option.
+func RunOptionExpr[A any](m Expr[A]) Option[A] {
+	return HandleExpr(ExprMap(m, Some[A]), optionHandler[A]{})
+}
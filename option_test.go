@@ -0,0 +1,78 @@
+// ©Hayabusa Cloud Co., Ltd. 2026. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package kont_test
+
+import (
+	"strconv"
+	"testing"
+
+	"code.hybscloud.com/kont"
+)
+
+func lookup(m map[string]int, key string) kont.Option[int] {
+	if v, ok := m[key]; ok {
+		return kont.Some(v)
+	}
+	return kont.None[int]()
+}
+
+func TestOptionCombinators(t *testing.T) {
+	s := kont.MapOption(kont.Some(2), strconv.Itoa)
+	if v, ok := s.Get(); !ok || v != "2" {
+		t.Fatalf("got %+v", s)
+	}
+	n := kont.FlatMapOption(kont.Some(0), func(x int) kont.Option[int] {
+		if x == 0 {
+			return kont.None[int]()
+		}
+		return kont.Some(10 / x)
+	})
+	if !n.IsNone() || n.GetOrElse(-1) != -1 {
+		t.Fatalf("got %+v", n)
+	}
+	got := kont.MatchOption(kont.Some(3), func() string { return "none" }, strconv.Itoa)
+	if got != "3" || !kont.Some(1).IsSome() {
+		t.Fatalf("got %q", got)
+	}
+}
+
+func TestRunOption(t *testing.T) {
+	env := map[string]int{"a": 1, "b": 2}
+	sum := func(x, y string) kont.Eff[int] {
+		return kont.Bind(kont.FromOption(lookup(env, x)), func(a int) kont.Eff[int] {
+			return kont.Map(kont.FromOption(lookup(env, y)), func(b int) int { return a + b })
+		})
+	}
+	if v, ok := kont.RunOption(sum("a", "b")).Get(); !ok || v != 3 {
+		t.Fatalf("got %d, %v", v, ok)
+	}
+	if o := kont.RunOption(sum("a", "z")); o.IsSome() {
+		t.Fatalf("got %+v, want None", o)
+	}
+}
+
+func TestRunOptionExpr(t *testing.T) {
+	comp := kont.ExprBind(kont.ExprFromOption(kont.Some(4)), func(x int) kont.Expr[int] {
+		if x > 3 {
+			return kont.ExprFailOption[int]()
+		}
+		return kont.ExprReturn(x)
+	})
+	if o := kont.RunOptionExpr(comp); o.IsSome() {
+		t.Fatalf("got %+v, want None", o)
+	}
+	if v, _ := kont.RunOptionExpr(kont.ExprReturn(5)).Get(); v != 5 {
+		t.Fatalf("got %d", v)
+	}
+}
+
+func TestOptionHandlerUnhandledPanic(t *testing.T) {
+	defer func() {
+		if r := recover(); unhandledIn(r) != "OptionHandler" {
+			t.Fatalf("got panic %v", r)
+		}
+	}()
+	kont.RunOption(kont.Perform(kont.Get[int]{}))
+}
@@ -0,0 +1,90 @@
+// ©Hayabusa Cloud Co., Ltd. 2026. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+// Package otelkont traces kont effect dispatch with OpenTelemetry.
+//
+// Every dispatch through a wrapped handler opens a span named after the
+// operation type, such as "kont.Get[int]", and records how long the handler
+// took to produce its resume value and whether it short-circuited the
+// computation. The package lives in its own module so kont itself keeps no
+// dependencies.
+//
+//	h := otelkont.Wrap[int](ctx, otel.Tracer("app"), inner)
+//	result := kont.Handle(comp, h)
+//
+// Middleware provides the same tracing as a [kont.StageObserve] stage of a
+// [kont.HandlerChain].
+package otelkont
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	"code.hybscloud.com/kont"
+)
+
+// Span attribute keys.
+const (
+	// ShortCircuitKey is true when the handler ended the computation
+	// instead of resuming it.
+	ShortCircuitKey = attribute.Key("kont.short_circuit")
+	// ResumeLatencyKey is the time the handler took to dispatch, in
+	// nanoseconds.
+	ResumeLatencyKey = attribute.Key("kont.resume_latency_ns")
+)
+
+// tracedHandler wraps a handler with a span per dispatch.
+type tracedHandler[H kont.Handler[H, R], R any] struct {
+	dispatch kont.DispatchFunc
+}
+
+// Dispatch implements kont.Handler.
+func (h *tracedHandler[H, R]) Dispatch(op kont.Operation) (kont.Resumed, bool) {
+	return h.dispatch(op)
+}
+
+// Wrap returns inner with a span opened under ctx for every dispatch.
+// Returns a concrete handler.
+func Wrap[R any, H kont.Handler[H, R]](ctx context.Context, tracer trace.Tracer, inner H) *tracedHandler[H, R] {
+	return &tracedHandler[H, R]{dispatch: traced(ctx, tracer, inner.Dispatch)}
+}
+
+// Middleware returns an Observe-stage middleware named "otel" opening a
+// span under ctx for every dispatch.
+func Middleware(ctx context.Context, tracer trace.Tracer) kont.HandlerMiddleware {
+	return kont.HandlerMiddleware{
+		Name:  "otel",
+		Stage: kont.StageObserve,
+		Wrap: func(next kont.DispatchFunc) kont.DispatchFunc {
+			return traced(ctx, tracer, next)
+		},
+	}
+}
+
+func traced(ctx context.Context, tracer trace.Tracer, next kont.DispatchFunc) kont.DispatchFunc {
+	return func(op kont.Operation) (v kont.Resumed, resume bool) {
+		_, span := tracer.Start(ctx, fmt.Sprintf("%T", op), trace.WithSpanKind(trace.SpanKindInternal))
+		start := time.Now()
+		dispatched := false
+		defer func() {
+			span.SetAttributes(ResumeLatencyKey.Int64(time.Since(start).Nanoseconds()))
+			if !dispatched {
+				r := recover()
+				span.SetStatus(codes.Error, fmt.Sprint(r))
+				span.End()
+				panic(r)
+			}
+			span.SetAttributes(ShortCircuitKey.Bool(!resume))
+			span.End()
+		}()
+		v, resume = next(op)
+		dispatched = true
+		return v, resume
+	}
+}
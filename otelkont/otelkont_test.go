@@ -0,0 +1,73 @@
+// ©Hayabusa Cloud Co., Ltd. 2026. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package otelkont_test
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	"code.hybscloud.com/kont"
+	"code.hybscloud.com/kont/otelkont"
+)
+
+func recordedTracer() (*tracetest.SpanRecorder, *sdktrace.TracerProvider) {
+	sr := tracetest.NewSpanRecorder()
+	return sr, sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(sr))
+}
+
+func TestWrap(t *testing.T) {
+	sr, tp := recordedTracer()
+	st, _ := kont.StateHandler[int, int](1)
+	h := otelkont.Wrap[int](context.Background(), tp.Tracer("test"), st)
+	comp := kont.GetState(func(s int) kont.Eff[int] {
+		return kont.PutState(s+1, kont.Pure(s+1))
+	})
+	if got := kont.Handle(comp, h); got != 2 {
+		t.Fatalf("got %d", got)
+	}
+	var names []string
+	for _, span := range sr.Ended() {
+		names = append(names, span.Name())
+		if !hasAttr(span, otelkont.ResumeLatencyKey) {
+			t.Fatalf("span %s has no latency", span.Name())
+		}
+	}
+	if want := []string{"kont.Get[int]", "kont.Put[int]"}; !slices.Equal(names, want) {
+		t.Fatalf("got spans %v, want %v", names, want)
+	}
+}
+
+func TestMiddlewareShortCircuit(t *testing.T) {
+	sr, tp := recordedTracer()
+	inner := kont.HandleFunc[int](func(kont.Operation) (kont.Resumed, bool) { return -1, false })
+	h := kont.ApplyChain[int](kont.Chain(otelkont.Middleware(context.Background(), tp.Tracer("test"))), inner)
+	if got := kont.Handle(kont.Perform(kont.Ask[int]{}), h); got != -1 {
+		t.Fatalf("got %d", got)
+	}
+	spans := sr.Ended()
+	if len(spans) != 1 {
+		t.Fatalf("got %d spans", len(spans))
+	}
+	for _, kv := range spans[0].Attributes() {
+		if kv.Key == otelkont.ShortCircuitKey && kv.Value.AsBool() {
+			return
+		}
+	}
+	t.Fatal("short-circuit not recorded")
+}
+
+func hasAttr(span sdktrace.ReadOnlySpan, key attribute.Key) bool {
+	for _, kv := range span.Attributes() {
+		if kv.Key == key {
+			return true
+		}
+	}
+	return false
+}
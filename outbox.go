@@ -0,0 +1,140 @@
+// ©Hayabusa Cloud Co., Ltd. 2026. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package kont
+
+// Outbox effect operations.
+// Outbox[Ev] buffers emitted events so that they are published together
+// with the state they describe (transactional outbox), or not at all.
+
+// EmitEvent is the effect operation for emitting a domain event.
+// Perform(EmitEvent[Ev]{Event: e}) appends e to the pending outbox.
+type EmitEvent[Ev any] struct{ Event Ev }
+
+func (EmitEvent[Ev]) OpResult() struct{} { panic("phantom") }
+
+// DispatchOutbox handles EmitEvent in Outbox handler dispatch.
+func (o EmitEvent[Ev]) DispatchOutbox(pending *[]Ev) (Resumed, bool) {
+	*pending = append(*pending, o.Event)
+	return struct{}{}, true
+}
+
+// EmitOutbox fuses EmitEvent + Then: emits e, then runs next.
+func EmitOutbox[Ev, B any](e Ev, next Cont[Resumed, B]) Cont[Resumed, B] {
+	resume := thenMarkerResume[B]
+	at := captureProvenance()
+	return func(k func(B) Resumed) Resumed {
+		m := acquireMarker()
+		m.op = EmitEvent[Ev]{Event: e}
+		m.f = next
+		m.k = k
+		m.resume = resume
+		m.at = at
+		return m
+	}
+}
+
+// outboxHandler implements Handler for outbox handling.
+type outboxHandler[Ev, R any] struct {
+	pending *[]Ev
+}
+
+// Dispatch implements Handler for outbox handling.
+func (h *outboxHandler[Ev, R]) Dispatch(op Operation) (Resumed, bool) {
+	if oop, ok := op.(interface {
+		DispatchOutbox(pending *[]Ev) (Resumed, bool)
+	}); ok {
+		return oop.DispatchOutbox(h.pending)
+	}
+	unhandledEffect("OutboxHandler", op)
+	return nil, false
+}
+
+// OutboxHandler creates a handler for Outbox effects.
+// Returns a concrete handler and a function to retrieve pending events.
+func OutboxHandler[Ev, R any]() (*outboxHandler[Ev, R], func() []Ev) {
+	var pending []Ev
+	return &outboxHandler[Ev, R]{pending: &pending}, func() []Ev { return pending }
+}
+
+// stateErrorOutboxHandler handles State, Error, and Outbox effects.
+type stateErrorOutboxHandler[S, E, Ev, A any] struct {
+	state   *S
+	ctx     *ErrorContext[E]
+	pending *[]Ev
+}
+
+// Dispatch implements Handler for the composed State+Error+Outbox handler.
+// Dispatch order: State → Error → Outbox.
+func (h *stateErrorOutboxHandler[S, E, Ev, A]) Dispatch(op Operation) (Resumed, bool) {
+	if sop, ok := op.(interface {
+		DispatchState(state *S) (Resumed, bool)
+	}); ok {
+		return sop.DispatchState(h.state)
+	}
+	if dop, ok := op.(interface {
+		DispatchErrorDeep(ctx *ErrorContext[E], outer func(Operation) (Resumed, bool)) (Resumed, bool)
+	}); ok {
+		v, _ := dop.DispatchErrorDeep(h.ctx, h.Dispatch)
+		if h.ctx.HasErr {
+			return Left[E, A](h.ctx.Err), false
+		}
+		return v, true
+	}
+	if eop, ok := op.(interface {
+		DispatchError(ctx *ErrorContext[E]) (Resumed, bool)
+	}); ok {
+		v, _ := eop.DispatchError(h.ctx)
+		if h.ctx.HasErr {
+			return Left[E, A](h.ctx.Err), false
+		}
+		return v, true
+	}
+	if oop, ok := op.(interface {
+		DispatchOutbox(pending *[]Ev) (Resumed, bool)
+	}); ok {
+		return oop.DispatchOutbox(h.pending)
+	}
+	unhandledEffect("StateErrorOutboxHandler", op)
+	return nil, false
+}
+
+// RunStateOutbox runs a computation with State, Error, and Outbox effects.
+// When the computation succeeds, commit receives the final state and every
+// emitted event in order, so both can be persisted in one transaction. When it
+// throws, the emitted events are dropped and commit is not called.
+// Returns (Either[E, A], S) — state is always available, even on error.
+func RunStateOutbox[S, E, Ev, A any](initial S, m Cont[Resumed, A], commit func(state S, events []Ev)) (Either[E, A], S) {
+	state := initial
+	var ctx ErrorContext[E]
+	var pending []Ev
+	h := &stateErrorOutboxHandler[S, E, Ev, A]{state: &state, ctx: &ctx, pending: &pending}
+	result := m(rightCont[E, A])
+	var either Either[E, A]
+	if result == nil {
+		var zero A
+		either = Right[E, A](zero)
+	} else {
+		either = handleDispatch[*stateErrorOutboxHandler[S, E, Ev, A], Either[E, A]](result, h)
+	}
+	if either.IsRight() {
+		commit(state, pending)
+	}
+	return either, state
+}
+
+// RunStateOutboxExpr runs an Expr with State, Error, and Outbox effects.
+// Commit semantics match [RunStateOutbox].
+func RunStateOutboxExpr[S, E, Ev, A any](initial S, m Expr[A], commit func(state S, events []Ev)) (Either[E, A], S) {
+	wrapped := ExprMap(m, func(a A) Either[E, A] { return Right[E, A](a) })
+	state := initial
+	var ctx ErrorContext[E]
+	var pending []Ev
+	h := &stateErrorOutboxHandler[S, E, Ev, A]{state: &state, ctx: &ctx, pending: &pending}
+	either := HandleExpr(wrapped, h)
+	if either.IsRight() {
+		commit(state, pending)
+	}
+	return either, state
+}
@@ -0,0 +1,129 @@
+// ©Hayabusa Cloud Co., Ltd. 2026. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package kont_test
+
+import (
+	"slices"
+	"testing"
+
+	"code.hybscloud.com/kont"
+)
+
+type persisted struct {
+	state  int
+	events []string
+	calls  int
+}
+
+func (p *persisted) commit(state int, events []string) {
+	p.state = state
+	p.events = events
+	p.calls++
+}
+
+func deposit(amount int) kont.Eff[int] {
+	return kont.ModifyState(func(s int) int { return s + amount }, func(s int) kont.Eff[int] {
+		return kont.EmitOutbox("deposited", kont.Pure(s))
+	})
+}
+
+func TestRunStateOutboxCommits(t *testing.T) {
+	var p persisted
+	comp := kont.Then(deposit(10), deposit(5))
+	result, state := kont.RunStateOutbox[int, string, string](100, comp, p.commit)
+
+	if v, ok := result.GetRight(); !ok || v != 115 {
+		t.Fatalf("got %+v, want Right(115)", result)
+	}
+	if state != 115 || p.calls != 1 || p.state != 115 {
+		t.Fatalf("state %d, commit %+v", state, p)
+	}
+	if !slices.Equal(p.events, []string{"deposited", "deposited"}) {
+		t.Fatalf("got events %v", p.events)
+	}
+}
+
+func TestRunStateOutboxDropsOnThrow(t *testing.T) {
+	var p persisted
+	comp := kont.Then(deposit(10), kont.ThrowError[string, int]("overdraft"))
+	result, state := kont.RunStateOutbox[int, string, string](0, comp, p.commit)
+
+	if err, ok := result.GetLeft(); !ok || err != "overdraft" {
+		t.Fatalf("got %+v, want Left(overdraft)", result)
+	}
+	if state != 10 {
+		t.Fatalf("got state %d, want 10", state)
+	}
+	if p.calls != 0 {
+		t.Fatal("commit must not run when the computation throws")
+	}
+}
+
+func TestRunStateOutboxNilResult(t *testing.T) {
+	var p persisted
+	comp := kont.Suspend[kont.Resumed, []int](func(k func([]int) kont.Resumed) kont.Resumed {
+		return nil
+	})
+	result, _ := kont.RunStateOutbox[int, string, string](0, comp, p.commit)
+	if !result.IsRight() || p.calls != 1 {
+		t.Fatalf("got %+v, %d commits", result, p.calls)
+	}
+}
+
+func TestRunStateOutboxExpr(t *testing.T) {
+	var p persisted
+	comp := kont.ExprThen(kont.ExprPerform(kont.Put[int]{Value: 3}),
+		kont.ExprThen(kont.ExprPerform(kont.EmitEvent[string]{Event: "set"}), kont.ExprReturn("ok")))
+	result, state := kont.RunStateOutboxExpr[int, string, string](0, comp, p.commit)
+	if !result.IsRight() || state != 3 || !slices.Equal(p.events, []string{"set"}) {
+		t.Fatalf("got %+v, state %d, events %v", result, state, p.events)
+	}
+
+	var q persisted
+	failing := kont.ExprThen(kont.ExprPerform(kont.EmitEvent[string]{Event: "set"}), kont.ExprThrowError[string, string]("boom"))
+	result, _ = kont.RunStateOutboxExpr[int, string, string](0, failing, q.commit)
+	if !result.IsLeft() || q.calls != 0 {
+		t.Fatalf("got %+v, %d commits", result, q.calls)
+	}
+}
+
+func TestOutboxHandler(t *testing.T) {
+	h, pending := kont.OutboxHandler[int, string]()
+	comp := kont.EmitOutbox(1, kont.EmitOutbox(2, kont.Pure("done")))
+	if got := kont.Handle(comp, h); got != "done" {
+		t.Fatalf("got %q", got)
+	}
+	if !slices.Equal(pending(), []int{1, 2}) {
+		t.Fatalf("got pending %v", pending())
+	}
+}
+
+func TestOutboxHandlerUnhandledPanic(t *testing.T) {
+	defer func() {
+		if r := recover(); unhandledIn(r) != "OutboxHandler" {
+			t.Fatalf("got panic %v", r)
+		}
+	}()
+	h, _ := kont.OutboxHandler[int, int]()
+	kont.Handle(kont.Perform(kont.Get[int]{}), h)
+}
+
+func TestStateErrorOutboxHandlerUnhandledPanic(t *testing.T) {
+	defer func() {
+		if r := recover(); unhandledIn(r) != "StateErrorOutboxHandler" {
+			t.Fatalf("got panic %v", r)
+		}
+	}()
+	kont.RunStateOutbox[int, string, string](0, kont.Perform(kont.Ask[int]{}), func(int, []string) {})
+}
+
+func TestOpResultPanicEmitEvent(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Fatal("expected phantom panic")
+		}
+	}()
+	kont.EmitEvent[int]{}.OpResult()
+}
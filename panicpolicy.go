@@ -0,0 +1,26 @@
+// ©Hayabusa Cloud Co., Ltd. 2026. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package kont
+
+// Panic policies.
+// A driver running many computations can contain a panic raised while one
+// of them runs, in a user closure, a handler or a driver callback, instead
+// of letting it take down every other computation with the process.
+
+// PanicPolicy chooses what a driver does when a computation it runs panics.
+type PanicPolicy uint8
+
+const (
+	// PanicCrash lets the panic propagate out of the driver, crashing the
+	// process unless the caller recovers it. It is the default.
+	PanicCrash PanicPolicy = iota
+	// PanicStop recovers the panic and stops the computation, as when
+	// its operation is short-circuited.
+	PanicStop
+	// PanicRestart recovers the panic and starts the computation again
+	// from the beginning. A computation that always panics restarts
+	// indefinitely.
+	PanicRestart
+)
@@ -58,6 +58,7 @@ func releaseEffectFrame(f *EffectFrame[Erased]) {
 	f.Resume = nil
 	f.Next = nil
 	f.pooled = false
+	f.at = provenance{}
 	effectFramePool.Put(f)
 }
 
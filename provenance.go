@@ -0,0 +1,26 @@
+// ©Hayabusa Cloud Co., Ltd. 2026. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+//go:build !kontdebug
+
+package kont
+
+// provenance records where an effect operation was performed.
+// Without the kontdebug build tag it is zero-size and records nothing.
+type provenance struct{}
+
+func captureProvenance() provenance { return provenance{} }
+
+func (provenance) String() string { return "" }
+
+// dispatchAt dispatches op to h. With the kontdebug build tag, unhandled-effect
+// panics are annotated with the site where op was performed.
+func dispatchAt[H Handler[H, R], R any](h H, op Operation, _ provenance) (Resumed, bool) {
+	return h.Dispatch(op)
+}
+
+// dispatchStateAt is dispatchState with the annotation of dispatchAt.
+func dispatchStateAt[S any](op Operation, state *S, _ provenance) (Resumed, bool) {
+	return dispatchState(op, state)
+}
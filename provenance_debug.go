@@ -0,0 +1,68 @@
+// ©Hayabusa Cloud Co., Ltd. 2026. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+//go:build kontdebug
+
+package kont
+
+import (
+	"runtime"
+	"strconv"
+	"strings"
+)
+
+// provenance records where an effect operation was performed: the first
+// caller outside package kont when the operation was constructed.
+type provenance struct {
+	file string
+	line int
+}
+
+func captureProvenance() provenance {
+	var pcs [16]uintptr
+	n := runtime.Callers(2, pcs[:])
+	frames := runtime.CallersFrames(pcs[:n])
+	for {
+		f, more := frames.Next()
+		if !strings.HasPrefix(f.Function, "code.hybscloud.com/kont.") {
+			return provenance{file: f.File, line: f.Line}
+		}
+		if !more {
+			return provenance{}
+		}
+	}
+}
+
+func (p provenance) String() string {
+	if p.file == "" {
+		return ""
+	}
+	return p.file + ":" + strconv.Itoa(p.line)
+}
+
+// dispatchAt dispatches op to h, annotating unhandled-effect panics with the
+// site where op was performed.
+func dispatchAt[H Handler[H, R], R any](h H, op Operation, at provenance) (Resumed, bool) {
+	defer annotateUnhandled(op, at)
+	return h.Dispatch(op)
+}
+
+// dispatchStateAt is dispatchState with the annotation of dispatchAt.
+func dispatchStateAt[S any](op Operation, state *S, at provenance) (Resumed, bool) {
+	defer annotateUnhandled(op, at)
+	return dispatchState(op, state)
+}
+
+// annotateUnhandled re-panics an [*UnhandledError] with the site where op was
+// performed recorded. Other panics propagate unchanged.
+func annotateUnhandled(op Operation, at provenance) {
+	r := recover()
+	if r == nil {
+		return
+	}
+	if u, ok := r.(*UnhandledError); ok && u.Site == "" && at.file != "" {
+		u.Site = at.String()
+	}
+	panic(r)
+}
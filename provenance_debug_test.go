@@ -0,0 +1,60 @@
+// ©Hayabusa Cloud Co., Ltd. 2026. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+//go:build kontdebug
+
+package kont_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"code.hybscloud.com/kont"
+)
+
+func TestUnhandledPanicNamesSite(t *testing.T) {
+	for name, run := range map[string]func(){
+		"Cont": func() { kont.RunState[int, int](0, kont.Perform(kont.Ask[int]{})) },
+		"Expr": func() { kont.RunStateExpr[int, int](0, kont.ExprPerform(kont.Ask[int]{})) },
+	} {
+		t.Run(name, func(t *testing.T) {
+			defer func() {
+				r := recover()
+				if ue, ok := r.(*kont.UnhandledError); !ok || !strings.Contains(ue.Site, "provenance_debug_test.go:") {
+					t.Fatalf("got panic %v", r)
+				}
+				msg := fmt.Sprint(r)
+				if !strings.HasPrefix(msg, "kont: unhandled effect in StateHandler (kont.Ask[int] performed at ") ||
+					!strings.Contains(msg, "provenance_debug_test.go:") {
+					t.Fatalf("got panic %q", msg)
+				}
+			}()
+			run()
+		})
+	}
+}
+
+func TestSuspensionSite(t *testing.T) {
+	_, susp := kont.Step(kont.GetState(func(s int) kont.Eff[int] { return kont.Pure(s) }))
+	if !strings.Contains(susp.Site(), "provenance_debug_test.go:") {
+		t.Fatalf("got site %q", susp.Site())
+	}
+	susp.Discard()
+
+	_, esusp := kont.StepExpr(kont.ExprPerform(kont.Get[int]{}))
+	if !strings.Contains(esusp.Site(), "provenance_debug_test.go:") {
+		t.Fatalf("got site %q", esusp.Site())
+	}
+	esusp.Discard()
+}
+
+func TestNonEffectPanicUnchanged(t *testing.T) {
+	defer func() {
+		if r := recover(); r != "boom" {
+			t.Fatalf("got panic %v", r)
+		}
+	}()
+	kont.Handle(kont.Perform(kont.Get[int]{}), kont.HandleFunc[int](func(kont.Operation) (kont.Resumed, bool) { panic("boom") }))
+}
@@ -0,0 +1,31 @@
+// ©Hayabusa Cloud Co., Ltd. 2026. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+//go:build !kontdebug
+
+package kont_test
+
+import (
+	"testing"
+
+	"code.hybscloud.com/kont"
+)
+
+func TestSuspensionSiteEmptyWithoutDebugTag(t *testing.T) {
+	_, susp := kont.Step(kont.Perform(kont.Get[int]{}))
+	if susp.Site() != "" {
+		t.Fatalf("got site %q, want empty", susp.Site())
+	}
+	susp.Discard()
+}
+
+func TestUnhandledPanicUnannotatedWithoutDebugTag(t *testing.T) {
+	defer func() {
+		r := recover()
+		if ue, ok := r.(*kont.UnhandledError); !ok || ue.Handler != "StateHandler" || ue.Site != "" {
+			t.Fatalf("got panic %v", r)
+		}
+	}()
+	kont.RunState[int, int](0, kont.Perform(kont.Ask[int]{}))
+}
@@ -0,0 +1,127 @@
+// ©Hayabusa Cloud Co., Ltd. 2026. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package kont
+
+// Bounded queues.
+// A Queue carries values between the tasks of a RunAsync event loop with
+// backpressure: Offer parks the producer while the queue is full, and Poll
+// parks the consumer while it is empty. A parked task becomes runnable again
+// when another task makes room or a value available, so a queue never holds
+// more than its capacity however far a producer runs ahead.
+
+// Queue is a bounded FIFO queue between the tasks of one event loop.
+// It is not safe for concurrent use.
+type Queue[T any] struct {
+	capacity int
+	buf      []T
+	offers   []queueOffer[T]
+	polls    []*asyncTask
+}
+
+// queueOffer is a producer parked on a full queue with its value.
+type queueOffer[T any] struct {
+	t *asyncTask
+	v T
+}
+
+// NewQueue creates a queue holding up to capacity values. With capacity
+// zero every Offer parks until a Poll takes its value.
+// Panics if capacity is negative.
+func NewQueue[T any](capacity int) *Queue[T] {
+	if capacity < 0 {
+		panic("kont: NewQueue requires a non-negative capacity")
+	}
+	return &Queue[T]{capacity: capacity}
+}
+
+// Len returns the number of values buffered in the queue.
+func (q *Queue[T]) Len() int { return len(q.buf) }
+
+// Cap returns the capacity of the queue.
+func (q *Queue[T]) Cap() int { return q.capacity }
+
+// Offer is the effect operation for adding Value to Queue.
+// Parks the task while the queue is full; resumes once Value is queued or
+// taken by a parked consumer.
+type Offer[T any] struct {
+	Queue *Queue[T]
+	Value T
+}
+
+func (Offer[T]) OpResult() struct{} { panic("phantom") }
+
+func (o Offer[T]) await(t *asyncTask) (Resumed, bool) {
+	q := o.Queue
+	if len(q.polls) > 0 {
+		w := q.polls[0]
+		q.polls[0] = nil
+		q.polls = q.polls[1:]
+		w.wake(o.Value)
+		return struct{}{}, true
+	}
+	if len(q.buf) < q.capacity {
+		q.buf = append(q.buf, o.Value)
+		return struct{}{}, true
+	}
+	q.offers = append(q.offers, queueOffer[T]{t: t, v: o.Value})
+	return nil, false
+}
+
+// Poll is the effect operation for taking the oldest value from Queue.
+// Parks the task while the queue is empty; resumes with the value.
+type Poll[T any] struct{ Queue *Queue[T] }
+
+func (Poll[T]) OpResult() T { panic("phantom") }
+
+func (o Poll[T]) await(t *asyncTask) (Resumed, bool) {
+	q := o.Queue
+	if len(q.buf) > 0 {
+		v := q.buf[0]
+		var zero T
+		q.buf[0] = zero
+		q.buf = q.buf[1:]
+		if p, ok := q.popOffer(); ok {
+			q.buf = append(q.buf, p.v)
+			p.t.wake(struct{}{})
+		}
+		return v, true
+	}
+	if p, ok := q.popOffer(); ok {
+		p.t.wake(struct{}{})
+		return p.v, true
+	}
+	q.polls = append(q.polls, t)
+	return nil, false
+}
+
+func (q *Queue[T]) popOffer() (queueOffer[T], bool) {
+	if len(q.offers) == 0 {
+		return queueOffer[T]{}, false
+	}
+	p := q.offers[0]
+	q.offers[0] = queueOffer[T]{}
+	q.offers = q.offers[1:]
+	return p, true
+}
+
+// OfferQueue performs Offer.
+func OfferQueue[T any](q *Queue[T], v T) Cont[Resumed, struct{}] {
+	return Perform(Offer[T]{Queue: q, Value: v})
+}
+
+// PollQueue performs Poll.
+func PollQueue[T any](q *Queue[T]) Cont[Resumed, T] {
+	return Perform(Poll[T]{Queue: q})
+}
+
+// ExprOfferQueue is the Expr counterpart of [OfferQueue].
+func ExprOfferQueue[T any](q *Queue[T], v T) Expr[struct{}] {
+	return ExprPerform(Offer[T]{Queue: q, Value: v})
+}
+
+// ExprPollQueue is the Expr counterpart of [PollQueue].
+func ExprPollQueue[T any](q *Queue[T]) Expr[T] {
+	return ExprPerform(Poll[T]{Queue: q})
+}
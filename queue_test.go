@@ -0,0 +1,97 @@
+// ©Hayabusa Cloud Co., Ltd. 2026. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package kont_test
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+
+	"code.hybscloud.com/kont"
+)
+
+// produceConsume forks a producer offering 1..n into q and a consumer
+// polling n values, and returns their sum.
+func produceConsume(q *kont.Queue[int], n int, log *[]string) kont.Eff[int] {
+	var produce func(i int) kont.Eff[struct{}]
+	produce = func(i int) kont.Eff[struct{}] {
+		if i > n {
+			return kont.Pure(struct{}{})
+		}
+		return kont.Bind(kont.OfferQueue(q, i), func(struct{}) kont.Eff[struct{}] {
+			*log = append(*log, fmt.Sprint("put ", i))
+			return produce(i + 1)
+		})
+	}
+	var consume func(i, sum int) kont.Eff[int]
+	consume = func(i, sum int) kont.Eff[int] {
+		if i > n {
+			return kont.Pure(sum)
+		}
+		return kont.Bind(kont.PollQueue(q), func(v int) kont.Eff[int] {
+			*log = append(*log, fmt.Sprint("got ", v))
+			return consume(i+1, sum+v)
+		})
+	}
+	return kont.Bind(kont.ForkAsync(produce(1)), func(fp *kont.Future[struct{}]) kont.Eff[int] {
+		return kont.Bind(kont.ForkAsync(consume(1, 0)), func(fc *kont.Future[int]) kont.Eff[int] {
+			return kont.Then(kont.AwaitAsync(fp), kont.AwaitAsync(fc))
+		})
+	})
+}
+
+func TestQueueBackpressure(t *testing.T) {
+	var log []string
+	q := kont.NewQueue[int](2)
+	if got := kont.RunAsync(produceConsume(q, 5, &log), nil); got != 15 {
+		t.Fatalf("got %d, want 15", got)
+	}
+	// The producer parks on the third Offer until the consumer makes room.
+	want := []string{"put 1", "put 2", "got 1", "got 2", "got 3", "put 3", "put 4", "put 5", "got 4", "got 5"}
+	if !slices.Equal(log, want) {
+		t.Fatalf("got %v, want %v", log, want)
+	}
+	if q.Len() != 0 || q.Cap() != 2 {
+		t.Fatalf("Len %d Cap %d", q.Len(), q.Cap())
+	}
+}
+
+func TestQueueRendezvous(t *testing.T) {
+	var log []string
+	q := kont.NewQueue[int](0)
+	if got := kont.RunAsync(produceConsume(q, 3, &log), nil); got != 6 {
+		t.Fatalf("got %d, want 6", got)
+	}
+	want := []string{"got 1", "put 1", "put 2", "got 2", "got 3", "put 3"}
+	if !slices.Equal(log, want) {
+		t.Fatalf("got %v, want %v", log, want)
+	}
+}
+
+func TestQueueExpr(t *testing.T) {
+	q := kont.NewQueue[string](1)
+	comp := kont.ExprThen(kont.ExprOfferQueue(q, "x"), kont.ExprPollQueue(q))
+	got := kont.HandleExpr(comp, kont.HandleFunc[string](func(op kont.Operation) (kont.Resumed, bool) {
+		switch op := op.(type) {
+		case kont.Offer[string]:
+			return struct{}{}, true
+		case kont.Poll[string]:
+			return "y", op.Queue == q
+		}
+		return nil, false
+	}))
+	if got != "y" {
+		t.Fatalf("got %q", got)
+	}
+}
+
+func TestNewQueueRequiresCapacity(t *testing.T) {
+	defer func() {
+		if r := recover(); r != "kont: NewQueue requires a non-negative capacity" {
+			t.Fatalf("got panic %v", r)
+		}
+	}()
+	kont.NewQueue[int](-1)
+}
@@ -0,0 +1,192 @@
+// ©Hayabusa Cloud Co., Ltd. 2026. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package kont
+
+import "fmt"
+
+// Racing stepped computations.
+// Race and Zip step two computations side by side. While both are
+// suspended, the combined suspension is pending on [Both], so an async
+// driver can start both operations and resume whichever finishes first;
+// neither side waits for the other to be resumed.
+
+// Both is the pending operation of a suspension from Race or Zip while both
+// computations are suspended. Resume it with [ResumeLeft] or [ResumeRight].
+type Both struct {
+	Left, Right Operation
+}
+
+// sideResumed is the resumption value of a Both suspension.
+type sideResumed struct {
+	right bool
+	v     Resumed
+}
+
+// ResumeLeft resumes s, pending on [Both], with the result of its Left
+// operation. The Right operation stays pending.
+func ResumeLeft[A any](s *Suspension[A], v Resumed) (A, *Suspension[A]) {
+	return s.Resume(sideResumed{v: v})
+}
+
+// ResumeRight resumes s, pending on [Both], with the result of its Right
+// operation. The Left operation stays pending.
+func ResumeRight[A any](s *Suspension[A], v Resumed) (A, *Suspension[A]) {
+	return s.Resume(sideResumed{right: true, v: v})
+}
+
+// side selects the computation a resumption of s advances.
+func side(s effectSuspension, both bool, v Resumed) (bool, Resumed) {
+	if !both {
+		return false, v
+	}
+	r, ok := v.(sideResumed)
+	if !ok {
+		panic(fmt.Sprintf("kont: %T resumed without ResumeLeft or ResumeRight", s.Op()))
+	}
+	return r.right, r.v
+}
+
+// Race steps a and b and completes with whichever completes first; the
+// other computation's suspension is discarded. If a completes without
+// suspending, b is not started.
+func Race[A any](a, b Cont[Resumed, A]) (A, *Suspension[A]) {
+	return race(func() (A, *Suspension[A]) { return Step(a) }, func() (A, *Suspension[A]) { return Step(b) })
+}
+
+// RaceExpr is the Expr counterpart of [Race].
+func RaceExpr[A any](a, b Expr[A]) (A, *Suspension[A]) {
+	return race(func() (A, *Suspension[A]) { return StepExpr(a) }, func() (A, *Suspension[A]) { return StepExpr(b) })
+}
+
+func race[A any](a, b func() (A, *Suspension[A])) (A, *Suspension[A]) {
+	va, sa := a()
+	if sa == nil {
+		return va, nil
+	}
+	vb, sb := b()
+	if sb == nil {
+		sa.Discard()
+		return vb, nil
+	}
+	return classifyResumed[A](&raceSuspension[A]{l: sa, r: sb})
+}
+
+// raceSuspension is a Race pending on both sides.
+type raceSuspension[A any] struct {
+	l, r *Suspension[A]
+}
+
+func (s *raceSuspension[A]) Op() Operation { return Both{Left: s.l.Op(), Right: s.r.Op()} }
+
+func (s *raceSuspension[A]) Resume(v Resumed) Resumed {
+	right, v := side(s, true, v)
+	won, lost := s.l, s.r
+	if right {
+		won, lost = s.r, s.l
+	}
+	a, next := won.Resume(v)
+	if next == nil {
+		lost.Discard()
+		return a
+	}
+	if right {
+		return &raceSuspension[A]{l: s.l, r: next}
+	}
+	return &raceSuspension[A]{l: next, r: s.r}
+}
+
+func (s *raceSuspension[A]) resumeWithError(Operation) Resumed {
+	side(s, true, nil)
+	return nil
+}
+
+func (s *raceSuspension[A]) release() {
+	s.l.Discard()
+	s.r.Discard()
+}
+
+func (*raceSuspension[A]) site() provenance { return provenance{} }
+
+// Zip steps a and b and completes with both results once both have
+// completed. While both are suspended, the combined suspension is pending
+// on [Both]; once one has completed, it is pending on the other's
+// operation and is resumed as usual.
+func Zip[A, B any](a Cont[Resumed, A], b Cont[Resumed, B]) (Pair[A, B], *Suspension[Pair[A, B]]) {
+	va, sa := Step(a)
+	vb, sb := Step(b)
+	return zip(va, sa, vb, sb)
+}
+
+// ZipExpr is the Expr counterpart of [Zip].
+func ZipExpr[A, B any](a Expr[A], b Expr[B]) (Pair[A, B], *Suspension[Pair[A, B]]) {
+	va, sa := StepExpr(a)
+	vb, sb := StepExpr(b)
+	return zip(va, sa, vb, sb)
+}
+
+func zip[A, B any](va A, sa *Suspension[A], vb B, sb *Suspension[B]) (Pair[A, B], *Suspension[Pair[A, B]]) {
+	if sa == nil && sb == nil {
+		return Pair[A, B]{Fst: va, Snd: vb}, nil
+	}
+	return classifyResumed[Pair[A, B]](&zipSuspension[A, B]{a: va, l: sa, b: vb, r: sb})
+}
+
+// zipSuspension is a Zip with at least one side pending; a nil side has
+// completed with its value.
+type zipSuspension[A, B any] struct {
+	a A
+	l *Suspension[A]
+	b B
+	r *Suspension[B]
+}
+
+func (s *zipSuspension[A, B]) Op() Operation {
+	switch {
+	case s.r == nil:
+		return s.l.Op()
+	case s.l == nil:
+		return s.r.Op()
+	}
+	return Both{Left: s.l.Op(), Right: s.r.Op()}
+}
+
+func (s *zipSuspension[A, B]) Resume(v Resumed) Resumed {
+	right, v := side(s, s.l != nil && s.r != nil, v)
+	next := *s
+	if right || s.l == nil {
+		next.b, next.r = s.r.Resume(v)
+	} else {
+		next.a, next.l = s.l.Resume(v)
+	}
+	if next.l == nil && next.r == nil {
+		return Pair[A, B]{Fst: next.a, Snd: next.b}
+	}
+	return &next
+}
+
+func (s *zipSuspension[A, B]) resumeWithError(throw Operation) Resumed {
+	side(s, s.l != nil && s.r != nil, nil)
+	next := *s
+	if s.l == nil {
+		next.b, next.r = s.r.resumeWithError(throw)
+	} else {
+		next.a, next.l = s.l.resumeWithError(throw)
+	}
+	if next.l == nil && next.r == nil {
+		return Pair[A, B]{Fst: next.a, Snd: next.b}
+	}
+	return &next
+}
+
+func (s *zipSuspension[A, B]) release() {
+	if s.l != nil {
+		s.l.Discard()
+	}
+	if s.r != nil {
+		s.r.Discard()
+	}
+}
+
+func (*zipSuspension[A, B]) site() provenance { return provenance{} }
@@ -0,0 +1,84 @@
+// ©Hayabusa Cloud Co., Ltd. 2026. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package kont_test
+
+import (
+	"testing"
+
+	"code.hybscloud.com/kont"
+)
+
+// sleep and read stand in for a timer and an I/O operation.
+type sleep struct{ ms int }
+type read struct{ fd int }
+
+func (sleep) OpResult() struct{} { panic("phantom") }
+func (read) OpResult() string    { panic("phantom") }
+
+func TestRace(t *testing.T) {
+	timeout := kont.Map(kont.Perform(sleep{ms: 100}), func(struct{}) string { return "timeout" })
+	io := kont.Bind(kont.Perform(read{fd: 3}), func(s string) kont.Eff[string] {
+		return kont.Map(kont.Perform(read{fd: 3}), func(t string) string { return s + t })
+	})
+	_, s := kont.Race(timeout, io)
+	both, ok := s.Op().(kont.Both)
+	if !ok || both.Left != (sleep{ms: 100}) || both.Right != (read{fd: 3}) {
+		t.Fatalf("pending %T %v", s.Op(), s.Op())
+	}
+	// The read finishes first but the computation performs a second one.
+	_, s = kont.ResumeRight(s, "ab")
+	if both, ok = s.Op().(kont.Both); !ok || both.Left != (sleep{ms: 100}) {
+		t.Fatalf("pending %T %v", s.Op(), s.Op())
+	}
+	if got, s := kont.ResumeLeft(s, struct{}{}); got != "timeout" || s != nil {
+		t.Fatalf("got %q", got)
+	}
+}
+
+func TestRaceExprCompletesWithoutSuspending(t *testing.T) {
+	got, s := kont.RaceExpr(kont.ExprPerform(sleep{ms: 1}), kont.ExprReturn(struct{}{}))
+	if s != nil || got != struct{}{} {
+		t.Fatalf("got %v, %v", got, s)
+	}
+	got, s = kont.RaceExpr(kont.ExprReturn(struct{}{}), kont.ExprPerform(sleep{ms: 1}))
+	if s != nil || got != struct{}{} {
+		t.Fatalf("got %v, %v", got, s)
+	}
+}
+
+func TestZip(t *testing.T) {
+	a := kont.Map(kont.Perform(read{fd: 1}), func(s string) int { return len(s) })
+	b := kont.Bind(kont.Perform(read{fd: 2}), func(s string) kont.Eff[string] {
+		return kont.Map(kont.Perform(read{fd: 2}), func(t string) string { return s + t })
+	})
+	_, s := kont.Zip(a, b)
+	_, s = kont.ResumeRight(s, "x")
+	_, s = kont.ResumeLeft(s, "abc")
+	// Only the right side is pending now; it resumes with a plain value.
+	if op, ok := s.Op().(read); !ok || op.fd != 2 {
+		t.Fatalf("pending %T %v", s.Op(), s.Op())
+	}
+	got, s := s.Resume("y")
+	if s != nil || got != (kont.Pair[int, string]{Fst: 3, Snd: "xy"}) {
+		t.Fatalf("got %+v", got)
+	}
+}
+
+func TestZipExprCompletesWithoutSuspending(t *testing.T) {
+	got, s := kont.ZipExpr(kont.ExprReturn(1), kont.ExprReturn("a"))
+	if s != nil || got.Fst != 1 || got.Snd != "a" {
+		t.Fatalf("got %+v", got)
+	}
+}
+
+func TestBothResumedPlainly(t *testing.T) {
+	_, s := kont.Race(kont.Perform(read{fd: 1}), kont.Perform(read{fd: 2}))
+	defer func() {
+		if r := recover(); r != "kont: kont.Both resumed without ResumeLeft or ResumeRight" {
+			t.Fatalf("recovered %v", r)
+		}
+	}()
+	s.Resume("x")
+}
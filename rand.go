@@ -0,0 +1,137 @@
+// ©Hayabusa Cloud Co., Ltd. 2026. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package kont
+
+import "math/rand/v2"
+
+// Random effect operations.
+// Programs draw randomness through effects, so a simulation runs on fresh
+// entropy in production and replays exactly from a seed in tests.
+
+// RandInt is the effect operation for a uniform int in [0, N).
+// N must be positive.
+type RandInt struct{ N int }
+
+func (RandInt) OpResult() int { panic("phantom") }
+
+// DispatchRand draws from r.
+func (o RandInt) DispatchRand(r *rand.Rand) (Resumed, bool) {
+	return r.IntN(o.N), true
+}
+
+// RandFloat is the effect operation for a uniform float64 in [0, 1).
+type RandFloat struct{}
+
+func (RandFloat) OpResult() float64 { panic("phantom") }
+
+// DispatchRand draws from r.
+func (RandFloat) DispatchRand(r *rand.Rand) (Resumed, bool) {
+	return r.Float64(), true
+}
+
+// Shuffle is the effect operation for a random permutation of Items.
+// Resumes with a shuffled copy; Items is not modified.
+type Shuffle[T any] struct{ Items []T }
+
+func (Shuffle[T]) OpResult() []T { panic("phantom") }
+
+// DispatchRand shuffles a copy of Items with r.
+func (o Shuffle[T]) DispatchRand(r *rand.Rand) (Resumed, bool) {
+	out := make([]T, len(o.Items))
+	copy(out, o.Items)
+	r.Shuffle(len(out), func(i, j int) { out[i], out[j] = out[j], out[i] })
+	return out, true
+}
+
+// RandomInt performs RandInt.
+func RandomInt(n int) Cont[Resumed, int] {
+	return Perform(RandInt{N: n})
+}
+
+// RandomFloat performs RandFloat.
+func RandomFloat() Cont[Resumed, float64] {
+	return Perform(RandFloat{})
+}
+
+// ShuffleItems performs Shuffle.
+func ShuffleItems[T any](items []T) Cont[Resumed, []T] {
+	return Perform(Shuffle[T]{Items: items})
+}
+
+// ExprRandomInt is the Expr counterpart of [RandomInt].
+func ExprRandomInt(n int) Expr[int] {
+	return ExprPerform(RandInt{N: n})
+}
+
+// ExprRandomFloat is the Expr counterpart of [RandomFloat].
+func ExprRandomFloat() Expr[float64] {
+	return ExprPerform(RandFloat{})
+}
+
+// ExprShuffleItems is the Expr counterpart of [ShuffleItems].
+func ExprShuffleItems[T any](items []T) Expr[[]T] {
+	return ExprPerform(Shuffle[T]{Items: items})
+}
+
+// randHandler implements Handler for the Random effect.
+type randHandler[R any] struct {
+	r     *rand.Rand
+	draws *int
+}
+
+// Dispatch implements Handler for the Random effect.
+func (h *randHandler[R]) Dispatch(op Operation) (Resumed, bool) {
+	if rop, ok := op.(interface {
+		DispatchRand(r *rand.Rand) (Resumed, bool)
+	}); ok {
+		*h.draws++
+		return rop.DispatchRand(h.r)
+	}
+	unhandledEffect("RandHandler", op)
+	return nil, false
+}
+
+// RandHandler creates a Random handler over a math/rand/v2 source seeded
+// from the runtime's entropy.
+// Returns a concrete handler and a function to retrieve the number of draws.
+func RandHandler[R any]() (*randHandler[R], func() int) {
+	return newRandHandler[R](rand.New(rand.NewPCG(rand.Uint64(), rand.Uint64())))
+}
+
+// SeededRandHandler creates a Random handler whose draws are fully
+// determined by seed, for reproducible simulations.
+// Returns a concrete handler and a function to retrieve the number of draws.
+func SeededRandHandler[R any](seed uint64) (*randHandler[R], func() int) {
+	return newRandHandler[R](rand.New(rand.NewPCG(seed, seed)))
+}
+
+func newRandHandler[R any](r *rand.Rand) (*randHandler[R], func() int) {
+	draws := 0
+	return &randHandler[R]{r: r, draws: &draws}, func() int { return draws }
+}
+
+// RunRand runs a computation with the Random effect on fresh entropy.
+func RunRand[A any](m Cont[Resumed, A]) A {
+	h, _ := RandHandler[A]()
+	return Handle(m, h)
+}
+
+// RunRandExpr is the Expr counterpart of [RunRand].
+func RunRandExpr[A any](m Expr[A]) A {
+	h, _ := RandHandler[A]()
+	return HandleExpr(m, h)
+}
+
+// RunSeededRand runs a computation with the Random effect determined by seed.
+func RunSeededRand[A any](seed uint64, m Cont[Resumed, A]) A {
+	h, _ := SeededRandHandler[A](seed)
+	return Handle(m, h)
+}
+
+// RunSeededRandExpr is the Expr counterpart of [RunSeededRand].
+func RunSeededRandExpr[A any](seed uint64, m Expr[A]) A {
+	h, _ := SeededRandHandler[A](seed)
+	return HandleExpr(m, h)
+}
@@ -0,0 +1,78 @@
+// ©Hayabusa Cloud Co., Ltd. 2026. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package kont_test
+
+import (
+	"slices"
+	"testing"
+
+	"code.hybscloud.com/kont"
+)
+
+// dice rolls n six-sided dice.
+func dice(n int) kont.Eff[[]int] {
+	if n == 0 {
+		return kont.Pure([]int(nil))
+	}
+	return kont.Bind(kont.RandomInt(6), func(d int) kont.Eff[[]int] {
+		return kont.Map(dice(n-1), func(rest []int) []int { return append([]int{d + 1}, rest...) })
+	})
+}
+
+func TestRunSeededRandReproducible(t *testing.T) {
+	a := kont.RunSeededRand(42, dice(20))
+	b := kont.RunSeededRand(42, dice(20))
+	if !slices.Equal(a, b) {
+		t.Fatalf("same seed differs: %v vs %v", a, b)
+	}
+	for _, d := range a {
+		if d < 1 || d > 6 {
+			t.Fatalf("die out of range: %d", d)
+		}
+	}
+	if c := kont.RunSeededRand(43, dice(20)); slices.Equal(a, c) {
+		t.Fatalf("different seeds gave the same rolls %v", a)
+	}
+}
+
+func TestSeededRandHandlerDraws(t *testing.T) {
+	h, draws := kont.SeededRandHandler[float64](1)
+	comp := kont.Bind(kont.RandomFloat(), func(x float64) kont.Eff[float64] {
+		return kont.Map(kont.RandomFloat(), func(y float64) float64 { return x + y })
+	})
+	if got := kont.Handle(comp, h); got < 0 || got >= 2 {
+		t.Fatalf("got %v", got)
+	}
+	if draws() != 2 {
+		t.Fatalf("draws %d", draws())
+	}
+}
+
+func TestShuffleItems(t *testing.T) {
+	items := []int{1, 2, 3, 4, 5, 6, 7, 8}
+	got := kont.RunSeededRandExpr(7, kont.ExprShuffleItems(items))
+	if !slices.Equal(items, []int{1, 2, 3, 4, 5, 6, 7, 8}) {
+		t.Fatalf("input modified: %v", items)
+	}
+	sorted := slices.Sorted(slices.Values(got))
+	if !slices.Equal(sorted, items) {
+		t.Fatalf("not a permutation: %v", got)
+	}
+	if again := kont.RunSeededRand(7, kont.ShuffleItems(items)); !slices.Equal(again, got) {
+		t.Fatalf("Cont and Expr differ: %v vs %v", again, got)
+	}
+}
+
+func TestRunRand(t *testing.T) {
+	if n := kont.RunRand(kont.RandomInt(3)); n < 0 || n >= 3 {
+		t.Fatalf("got %d", n)
+	}
+	if x := kont.RunRandExpr(kont.ExprRandomFloat()); x < 0 || x >= 1 {
+		t.Fatalf("got %v", x)
+	}
+	if n := kont.RunRandExpr(kont.ExprRandomInt(1)); n != 0 {
+		t.Fatalf("got %d", n)
+	}
+}
@@ -21,12 +21,14 @@ func (Ask[E]) DispatchReader(env *E) (Resumed, bool) {
 // AskReader fuses Ask + Bind: performs Ask, passes environment to f.
 func AskReader[E, B any](f func(E) Cont[Resumed, B]) Cont[Resumed, B] {
 	resume := bindMarkerResume[E, B]
+	at := captureProvenance()
 	return func(k func(B) Resumed) Resumed {
 		m := acquireMarker()
 		m.op = Ask[E]{}
 		m.f = f
 		m.k = k
 		m.resume = resume
+		m.at = at
 		return m
 	}
 }
@@ -34,16 +36,70 @@ func AskReader[E, B any](f func(E) Cont[Resumed, B]) Cont[Resumed, B] {
 // MapReader fuses Ask + Map: performs Ask, applies projection f.
 func MapReader[E, A any](f func(E) A) Cont[Resumed, A] {
 	resume := mapMarkerResume[E, A]
+	at := captureProvenance()
 	return func(k func(A) Resumed) Resumed {
 		m := acquireMarker()
 		m.op = Ask[E]{}
 		m.f = f
 		m.k = k
 		m.resume = resume
+		m.at = at
 		return m
 	}
 }
 
+// Asks is the effect operation for projecting the environment.
+// Perform(Asks[E, A]{F: f}) returns f(env). The projection runs at dispatch
+// time, so only the projected value crosses the Resumed boundary.
+type Asks[E, A any] struct{ F func(E) A }
+
+func (Asks[E, A]) OpResult() A { panic("phantom") }
+
+// DispatchReader handles Asks in Reader handler dispatch.
+func (o Asks[E, A]) DispatchReader(env *E) (Resumed, bool) {
+	return o.F(*env), true
+}
+
+// AsksReader performs Asks: projects the environment with f at dispatch time.
+func AsksReader[E, A any](f func(E) A) Cont[Resumed, A] {
+	return Perform(Asks[E, A]{F: f})
+}
+
+// ExprAsksReader performs Asks in an Expr computation.
+func ExprAsksReader[E, A any](f func(E) A) Expr[A] {
+	return ExprPerform(Asks[E, A]{F: f})
+}
+
+// Local is the effect operation for running a sub-computation with a
+// modified environment.
+// Perform(Local[E, A]{F: f, Body: m}) runs m with environment f(env).
+//
+// Like Listen/Catch, Local runs the body with a reader-only handler internally.
+// Other effects (State, Writer, Error) in the body are not handled.
+type Local[E, A any] struct {
+	F    func(E) E
+	Body Cont[Resumed, A]
+}
+
+func (Local[E, A]) OpResult() A { panic("phantom") }
+
+// DispatchReader handles Local in Reader handler dispatch.
+// The outer environment is left unchanged.
+func (o Local[E, A]) DispatchReader(env *E) (Resumed, bool) {
+	local := o.F(*env)
+	return Handle(o.Body, &readerHandler[E, A]{env: &local}), true
+}
+
+// LocalReader runs body with the environment transformed by f.
+func LocalReader[E, A any](f func(E) E, body Cont[Resumed, A]) Cont[Resumed, A] {
+	return Perform(Local[E, A]{F: f, Body: body})
+}
+
+// ExprLocalReader runs an Expr body with the environment transformed by f.
+func ExprLocalReader[E, A any](f func(E) E, body Expr[A]) Expr[A] {
+	return ExprPerform(Local[E, A]{F: f, Body: Reflect(body)})
+}
+
 // readerHandler implements Handler for zero-allocation reader handling.
 type readerHandler[E, R any] struct {
 	env *E
@@ -54,7 +110,7 @@ func (h *readerHandler[E, R]) Dispatch(op Operation) (Resumed, bool) {
 	if rop, ok := op.(interface{ DispatchReader(env *E) (Resumed, bool) }); ok {
 		return rop.DispatchReader(h.env)
 	}
-	unhandledEffect("ReaderHandler")
+	unhandledEffect("ReaderHandler", op)
 	return nil, false
 }
 
@@ -162,3 +162,79 @@ func TestExprReaderWithConfig(t *testing.T) {
 		t.Fatalf("got %q, want %q", result, "production")
 	}
 }
+
+func TestLocalReader(t *testing.T) {
+	comp := kont.AskReader(func(outer int) kont.Eff[[3]int] {
+		return kont.Bind(
+			kont.LocalReader(func(e int) int { return e * 10 }, kont.Perform(kont.Ask[int]{})),
+			func(inner int) kont.Eff[[3]int] {
+				return kont.MapReader(func(after int) [3]int { return [3]int{outer, inner, after} })
+			},
+		)
+	})
+	if got := kont.RunReader(4, comp); got != [3]int{4, 40, 4} {
+		t.Fatalf("got %v, want [4 40 4]", got)
+	}
+}
+
+func TestLocalReaderNested(t *testing.T) {
+	inc := func(e int) int { return e + 1 }
+	comp := kont.LocalReader(inc, kont.LocalReader(inc, kont.Perform(kont.Ask[int]{})))
+	if got := kont.RunReader(0, comp); got != 2 {
+		t.Fatalf("got %d, want 2", got)
+	}
+}
+
+func TestExprLocalReader(t *testing.T) {
+	comp := kont.ExprLocalReader(func(c Config) Config {
+		c.Port = 8080
+		return c
+	}, kont.ExprMap(kont.ExprPerform(kont.Ask[Config]{}), func(c Config) int { return c.Port }))
+	if got := kont.RunReaderExpr[Config, int](Config{Port: 80}, comp); got != 8080 {
+		t.Fatalf("got %d, want 8080", got)
+	}
+}
+
+func TestLocalReaderComposed(t *testing.T) {
+	comp := kont.GetState(func(s int) kont.Eff[int] {
+		return kont.LocalReader(func(e int) int { return e + s }, kont.Perform(kont.Ask[int]{}))
+	})
+	got, _ := kont.RunStateReader[int, int, int](5, 10, comp)
+	if got != 15 {
+		t.Fatalf("got %d, want 15", got)
+	}
+}
+
+func TestAsksReader(t *testing.T) {
+	comp := kont.AsksReader(func(c Config) int { return c.Port })
+	if got := kont.RunReader(Config{Port: 443}, comp); got != 443 {
+		t.Fatalf("got %d, want 443", got)
+	}
+}
+
+func TestAsksDispatchProjects(t *testing.T) {
+	env := Config{Debug: true, Port: 80}
+	v, resume := kont.Asks[Config, bool]{F: func(c Config) bool { return c.Debug }}.DispatchReader(&env)
+	if !resume || v != true {
+		t.Fatalf("got (%v, %v), want (true, true)", v, resume)
+	}
+}
+
+func TestExprAsksReader(t *testing.T) {
+	comp := kont.ExprBind(kont.ExprAsksReader(func(c Config) int { return c.Port }), func(p int) kont.Expr[int] {
+		return kont.ExprReturn(p + 1)
+	})
+	if got := kont.RunReaderExpr[Config, int](Config{Port: 80}, comp); got != 81 {
+		t.Fatalf("got %d, want 81", got)
+	}
+}
+
+func TestAsksReaderComposed(t *testing.T) {
+	comp := kont.Bind(kont.AsksReader(func(e string) int { return len(e) }), func(n int) kont.Eff[int] {
+		return kont.PutState(n, kont.Pure(n))
+	})
+	got, s := kont.RunStateReader[int, string, int](0, "hello", comp)
+	if got != 5 || s != 5 {
+		t.Fatalf("got (%d, %d), want (5, 5)", got, s)
+	}
+}
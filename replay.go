@@ -0,0 +1,115 @@
+// ©Hayabusa Cloud Co., Ltd. 2026. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package kont
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// Record and replay.
+// Record captures the effect stream of a run as a Script. Replay runs the
+// same computation again, answering each operation from the script instead
+// of a real handler, so golden-file tests and bug reproductions need no
+// databases, clocks, or networks. Replay matches operations by type only;
+// payloads may differ between runs.
+
+// Script is a recorded effect stream, in dispatch order.
+type Script []TraceEvent
+
+// ErrReplayDiverged is the sentinel matched by errors.Is when a replayed
+// computation departs from its script.
+var ErrReplayDiverged = errors.New("kont: replay diverged")
+
+// ReplayDivergedError reports the first event at which a replayed
+// computation departed from its script.
+// It matches [ErrReplayDiverged] under errors.Is.
+type ReplayDivergedError struct {
+	// Index is the position in the script.
+	Index int
+	// Want is the recorded operation, or nil if the script was exhausted.
+	Want Operation
+	// Got is the performed operation, or nil if the computation completed
+	// before the script ended.
+	Got Operation
+}
+
+func (e *ReplayDivergedError) Error() string {
+	switch {
+	case e.Want == nil:
+		return fmt.Sprintf("kont: replay diverged at event %d: performed %T past the end of the script", e.Index, e.Got)
+	case e.Got == nil:
+		return fmt.Sprintf("kont: replay diverged at event %d: completed before recorded %T", e.Index, e.Want)
+	}
+	return fmt.Sprintf("kont: replay diverged at event %d: performed %T, recorded %T", e.Index, e.Got, e.Want)
+}
+
+// Unwrap returns ErrReplayDiverged.
+func (e *ReplayDivergedError) Unwrap() error { return ErrReplayDiverged }
+
+// replayHandler answers operations from a script.
+type replayHandler[R any] struct {
+	script Script
+	pos    int
+}
+
+// Dispatch implements Handler by returning the next recorded outcome.
+// Panics with [*ReplayDivergedError] on a mismatch.
+func (h *replayHandler[R]) Dispatch(op Operation) (Resumed, bool) {
+	if h.pos >= len(h.script) {
+		panic(&ReplayDivergedError{Index: h.pos, Got: op})
+	}
+	e := h.script[h.pos]
+	if reflect.TypeOf(op) != reflect.TypeOf(e.Op) {
+		panic(&ReplayDivergedError{Index: h.pos, Want: e.Op, Got: op})
+	}
+	h.pos++
+	return e.Value, !e.ShortCircuit
+}
+
+// finish recovers a divergence raised by Dispatch and reports unused events.
+func (h *replayHandler[R]) finish(err *error) {
+	if r := recover(); r != nil {
+		d, ok := r.(*ReplayDivergedError)
+		if !ok {
+			panic(r)
+		}
+		*err = d
+		return
+	}
+	if h.pos < len(h.script) {
+		*err = &ReplayDivergedError{Index: h.pos, Want: h.script[h.pos].Op}
+	}
+}
+
+// Record runs m with h and returns the result with its effect script.
+func Record[R any, H Handler[H, R]](m Cont[Resumed, R], h H) (R, Script) {
+	result, events := Trace(m, h)
+	return result, Script(events)
+}
+
+// RecordExpr is the Expr counterpart of [Record].
+func RecordExpr[R any, H Handler[H, R]](m Expr[R], h H) (R, Script) {
+	result, events := TraceExpr(m, h)
+	return result, Script(events)
+}
+
+// Replay runs m answering every operation from script. Returns a
+// [*ReplayDivergedError] if m performs an operation of a different type
+// than recorded, performs more operations than recorded, or completes
+// before the script ends.
+func Replay[R any](m Cont[Resumed, R], script Script) (result R, err error) {
+	h := &replayHandler[R]{script: script}
+	defer h.finish(&err)
+	return Handle(m, h), nil
+}
+
+// ReplayExpr is the Expr counterpart of [Replay].
+func ReplayExpr[R any](m Expr[R], script Script) (result R, err error) {
+	h := &replayHandler[R]{script: script}
+	defer h.finish(&err)
+	return HandleExpr(m, h), nil
+}
@@ -0,0 +1,93 @@
+// ©Hayabusa Cloud Co., Ltd. 2026. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package kont_test
+
+import (
+	"errors"
+	"testing"
+
+	"code.hybscloud.com/kont"
+)
+
+func replayWorkflow() kont.Eff[int] {
+	return kont.Bind(kont.Perform(kont.Ask[int]{}), func(base int) kont.Eff[int] {
+		return kont.GetState(func(s int) kont.Eff[int] {
+			return kont.PutState(s+base, kont.Pure(s+base))
+		})
+	})
+}
+
+func TestRecordReplay(t *testing.T) {
+	h := kont.HandleFunc[int](func(op kont.Operation) (kont.Resumed, bool) {
+		switch op.(type) {
+		case kont.Ask[int]:
+			return 10, true
+		case kont.Get[int]:
+			return 5, true
+		case kont.Put[int]:
+			return struct{}{}, true
+		}
+		panic("unexpected op")
+	})
+	got, script := kont.Record(replayWorkflow(), h)
+	if got != 15 || len(script) != 3 {
+		t.Fatalf("got %d with %d events", got, len(script))
+	}
+
+	replayed, err := kont.Replay(replayWorkflow(), script)
+	if err != nil || replayed != 15 {
+		t.Fatalf("replay: got %d, %v", replayed, err)
+	}
+	replayed, err = kont.ReplayExpr(kont.Reify(replayWorkflow()), script)
+	if err != nil || replayed != 15 {
+		t.Fatalf("replay expr: got %d, %v", replayed, err)
+	}
+}
+
+func TestReplayShortCircuit(t *testing.T) {
+	script := kont.Script{{Op: kont.Ask[int]{}, Value: -1, ShortCircuit: true}}
+	got, err := kont.Replay(replayWorkflow(), script)
+	if err != nil || got != -1 {
+		t.Fatalf("got %d, %v", got, err)
+	}
+}
+
+func TestReplayDiverged(t *testing.T) {
+	_, script := kont.RecordExpr(kont.ExprPerform(kont.Get[int]{}), kont.HandleFunc[int](func(kont.Operation) (kont.Resumed, bool) {
+		return 1, true
+	}))
+
+	for name, tc := range map[string]struct {
+		m     kont.Eff[int]
+		index int
+		want  bool
+		got   bool
+	}{
+		"mismatch":  {kont.Perform(kont.Ask[int]{}), 0, true, true},
+		"exhausted": {kont.Then(kont.Perform(kont.Get[int]{}), kont.Perform(kont.Get[int]{})), 1, false, true},
+		"unused":    {kont.Pure(0), 0, true, false},
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := kont.Replay(tc.m, script)
+			var d *kont.ReplayDivergedError
+			if !errors.As(err, &d) || !errors.Is(err, kont.ErrReplayDiverged) {
+				t.Fatalf("got %v", err)
+			}
+			if d.Index != tc.index || (d.Want != nil) != tc.want || (d.Got != nil) != tc.got {
+				t.Fatalf("got %+v (%v)", d, err)
+			}
+		})
+	}
+}
+
+func TestReplayPropagatesOtherPanics(t *testing.T) {
+	defer func() {
+		if r := recover(); r != "boom" {
+			t.Fatalf("recovered %v", r)
+		}
+	}()
+	m := kont.Bind(kont.Perform(kont.Get[int]{}), func(int) kont.Eff[int] { panic("boom") })
+	kont.Replay(m, kont.Script{{Op: kont.Get[int]{}, Value: 1}})
+}
@@ -4,6 +4,11 @@
 
 package kont
 
+import (
+	"errors"
+	"time"
+)
+
 // Resource safety primitives for exception-safe resource management.
 // These provide the minimal interface for bracketed resource handling.
 
@@ -45,3 +50,91 @@ func OnError[E, A any](
 		})
 	})
 }
+
+// ErrReleaseTimeout is reported by [BracketTimeout] when release does not
+// complete before its deadline.
+var ErrReleaseTimeout = errors.New("kont: release timed out")
+
+// BracketTimeout is [Bracket] with a bounded release, so a cleanup that keeps
+// performing effects cannot wedge the evaluation loop. The deadline is read
+// from the Clock effect: BracketTimeout performs [Now] when release starts
+// and again before each operation release performs. Once timeout has elapsed,
+// release is discarded at that operation and reported as [ErrReleaseTimeout].
+// Under a [VirtualClockHandler] the deadline follows virtual time. A single
+// dispatch that never returns is not interrupted; bound it with [TimeoutOps].
+//
+// use is interpreted as in [Bracket]. Effects performed by release stay on
+// the outer Cont path.
+//
+// Returns a Pair of the use result and the release error: nil on a clean
+// release, the error release completed with, or [ErrReleaseTimeout]. The
+// release error never replaces the error produced by use.
+func BracketTimeout[E, R, A any](
+	acquire Cont[Resumed, R],
+	release func(R) Cont[Resumed, error],
+	timeout time.Duration,
+	use func(R) Cont[Resumed, A],
+) Cont[Resumed, Pair[Either[E, A], error]] {
+	return Bind(acquire, func(resource R) Cont[Resumed, Pair[Either[E, A], error]] {
+		result := RunError[E, A](use(resource))
+		return Bind(ClockNow(), func(start time.Time) Cont[Resumed, Pair[Either[E, A], error]] {
+			return Map(releaseBefore(release(resource), start.Add(timeout)), func(err error) Pair[Either[E, A], error] {
+				return Pair[Either[E, A], error]{Fst: result, Snd: err}
+			})
+		})
+	})
+}
+
+// releaseBefore runs m, performing Now before each of its operations, and
+// completes with ErrReleaseTimeout in place of m once the time reported is
+// not before deadline.
+func releaseBefore(m Cont[Resumed, error], deadline time.Time) Cont[Resumed, error] {
+	return func(k func(error) Resumed) Resumed {
+		return releaseResult(m(scopeDoneCont[error]), deadline, k)
+	}
+}
+
+// releaseSuspension wraps a suspension raised inside releaseBefore. While
+// checking, it presents Now in place of the inner operation, which is
+// presented once Now is resumed with a time before the deadline.
+type releaseSuspension struct {
+	inner    effectSuspension
+	deadline time.Time
+	checking bool
+	k        func(error) Resumed
+}
+
+func (s *releaseSuspension) Op() Operation {
+	if s.checking {
+		return Now{}
+	}
+	return s.inner.Op()
+}
+
+func (s *releaseSuspension) Resume(v Resumed) Resumed {
+	if !s.checking {
+		return releaseResult(s.inner.Resume(v), s.deadline, s.k)
+	}
+	if !v.(time.Time).Before(s.deadline) {
+		s.inner.release()
+		return s.k(ErrReleaseTimeout)
+	}
+	return &releaseSuspension{inner: s.inner, deadline: s.deadline, k: s.k}
+}
+
+func (s *releaseSuspension) resumeWithError(throw Operation) Resumed {
+	return releaseResult(s.inner.resumeWithError(throw), s.deadline, s.k)
+}
+
+func (s *releaseSuspension) release()         { s.inner.release() }
+func (s *releaseSuspension) site() provenance { return s.inner.site() }
+
+func releaseResult(r Resumed, deadline time.Time, k func(error) Resumed) Resumed {
+	switch r := r.(type) {
+	case scopeDone[error]:
+		return k(r.value)
+	case effectSuspension:
+		return &releaseSuspension{inner: r, deadline: deadline, checking: true, k: k}
+	}
+	return r
+}
@@ -5,7 +5,9 @@
 package kont_test
 
 import (
+	"errors"
 	"testing"
+	"time"
 
 	"code.hybscloud.com/kont"
 )
@@ -136,3 +138,91 @@ func TestOnErrorSkippedOnSuccess(t *testing.T) {
 		t.Fatal("cleanup should not be called on success")
 	}
 }
+
+// releaseTimeoutResult is the result type of the BracketTimeout tests.
+type releaseTimeoutResult = kont.Pair[kont.Either[string, int], error]
+
+func TestBracketTimeoutCleanRelease(t *testing.T) {
+	clock := kont.NewVirtualClock(time.Unix(0, 0))
+	var released bool
+	comp := kont.BracketTimeout[string, int, int](
+		kont.Pure(21),
+		func(r int) kont.Eff[error] {
+			return kont.Then(kont.ClockSleep(time.Second), kont.Bind(kont.ClockNow(), func(time.Time) kont.Eff[error] {
+				released = true
+				return kont.Pure[error](nil)
+			}))
+		},
+		time.Minute,
+		func(r int) kont.Eff[int] { return kont.Pure(r * 2) },
+	)
+	got := kont.Handle(comp, kont.VirtualClockHandler[releaseTimeoutResult](clock))
+	if v, ok := got.Fst.GetRight(); !ok || v != 42 || got.Snd != nil || !released {
+		t.Fatalf("got %+v, released %v", got, released)
+	}
+}
+
+func TestBracketTimeoutReleaseError(t *testing.T) {
+	clock := kont.NewVirtualClock(time.Unix(0, 0))
+	releaseErr := errors.New("close failed")
+	comp := kont.BracketTimeout[string, int, int](
+		kont.Pure(1),
+		func(int) kont.Eff[error] { return kont.Pure(releaseErr) },
+		time.Second,
+		func(int) kont.Eff[int] { return kont.ThrowError[string, int]("use failed") },
+	)
+	got := kont.Handle(comp, kont.VirtualClockHandler[releaseTimeoutResult](clock))
+	if e, ok := got.Fst.GetLeft(); !ok || e != "use failed" {
+		t.Fatalf("primary error replaced: %+v", got.Fst)
+	}
+	if got.Snd != releaseErr {
+		t.Fatalf("got release error %v, want %v", got.Snd, releaseErr)
+	}
+}
+
+func TestBracketTimeoutSlowRelease(t *testing.T) {
+	clock := kont.NewVirtualClock(time.Unix(0, 0))
+	sleeps := 0
+	var retry func() kont.Eff[error]
+	retry = func() kont.Eff[error] {
+		return kont.Bind(kont.ClockSleep(time.Second), func(struct{}) kont.Eff[error] {
+			sleeps++
+			return retry() // never completes on its own
+		})
+	}
+	comp := kont.BracketTimeout[string, int, int](
+		kont.Pure(1),
+		func(int) kont.Eff[error] { return retry() },
+		3*time.Second,
+		func(r int) kont.Eff[int] { return kont.Pure(r) },
+	)
+	got := kont.Handle(comp, kont.VirtualClockHandler[releaseTimeoutResult](clock))
+	if v, ok := got.Fst.GetRight(); !ok || v != 1 {
+		t.Fatalf("got %+v", got.Fst)
+	}
+	if !errors.Is(got.Snd, kont.ErrReleaseTimeout) {
+		t.Fatalf("got %v, want ErrReleaseTimeout", got.Snd)
+	}
+	if sleeps != 3 || !clock.Now().Equal(time.Unix(3, 0)) {
+		t.Fatalf("slept %d times, clock at %v", sleeps, clock.Now())
+	}
+}
+
+func TestBracketTimeoutReleasePanic(t *testing.T) {
+	clock := kont.NewVirtualClock(time.Unix(0, 0))
+	comp := kont.BracketTimeout[string, int, int](
+		kont.Pure(1),
+		func(int) kont.Eff[error] {
+			return kont.Bind(kont.ClockNow(), func(time.Time) kont.Eff[error] { panic("close panicked") })
+		},
+		time.Second,
+		func(r int) kont.Eff[int] { return kont.Pure(r) },
+	)
+	defer func() {
+		if r := recover(); r != "close panicked" {
+			t.Fatalf("got panic %v, want close panicked", r)
+		}
+	}()
+	kont.Handle(comp, kont.VirtualClockHandler[releaseTimeoutResult](clock))
+	t.Fatal("release panic not re-raised")
+}
@@ -0,0 +1,76 @@
+// ©Hayabusa Cloud Co., Ltd. 2026. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package kont
+
+// Failure injection.
+// An external runtime that cannot satisfy a pending operation (socket
+// closed, timeout) resumes the suspension with an error instead of a value.
+// The computation then performs Throw at the suspension point, so scopes
+// around it such as MapError see the error as they would any Throw, and
+// the driver's Error handler decides the outcome.
+
+// injectedSuspension is an innermost suspension re-suspended on an injected
+// operation. Resuming it resumes the original suspension.
+type injectedSuspension struct {
+	op    Operation
+	inner effectSuspension
+}
+
+func (s *injectedSuspension) Op() Operation            { return s.op }
+func (s *injectedSuspension) Resume(v Resumed) Resumed { return s.inner.Resume(v) }
+func (s *injectedSuspension) release()                 { s.inner.release() }
+func (s *injectedSuspension) site() provenance         { return s.inner.site() }
+
+func (s *injectedSuspension) resumeWithError(throw Operation) Resumed {
+	return s.inner.resumeWithError(throw)
+}
+
+// ResumeWithError resumes the computation by performing Throw[error]{Err: err}
+// at the suspension point in place of the pending operation. Returns the
+// suspension on the Throw, or on whatever an enclosing scope made of it, for
+// the driver's Error handler. Panics if the suspension has already been
+// resumed or discarded.
+func (s *Suspension[A]) ResumeWithError(err error) (A, *Suspension[A]) {
+	return ResumeThrow(s, err)
+}
+
+// ResumeThrow is [Suspension.ResumeWithError] for an error type E: it
+// resumes s by performing Throw[E]{Err: err} at the suspension point.
+// If the Throw is later resumed with a value, the computation continues as
+// if the original operation had returned it.
+func ResumeThrow[E, A any](s *Suspension[A], err E) (A, *Suspension[A]) {
+	return s.resumeWithError(Throw[E]{Err: err})
+}
+
+// resumeWithError resumes s by performing throw in place of its pending
+// operation.
+func (s *Suspension[A]) resumeWithError(throw Operation) (A, *Suspension[A]) {
+	if s.used.Add(1) != 1 {
+		panic("kont: suspension resumed twice")
+	}
+	s.ended()
+	if s.cont != nil {
+		cont := s.cont
+		s.cont = nil
+		return s.complete(classifyResumed[A](cont.resumeWithError(throw)))
+	}
+	if s.ef.forwards {
+		ef, rest := s.ef, s.rest
+		s.ef, s.rest = nil, nil
+		return s.complete(s.evalExpr(ef, rest, ef.resumeWithError(throw)))
+	}
+	// An Expr operation has no scope between it and the driver: suspend on
+	// the Throw with the original frames, so resuming it resumes them.
+	next := &Suspension[A]{
+		op:     throw,
+		ef:     s.ef,
+		rest:   s.rest,
+		shared: s.shared,
+		leak:   trackSuspension(throw),
+	}
+	s.ef, s.rest = nil, nil
+	var zero A
+	return s.complete(zero, next)
+}
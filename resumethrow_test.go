@@ -0,0 +1,85 @@
+// ©Hayabusa Cloud Co., Ltd. 2026. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package kont_test
+
+import (
+	"errors"
+	"io"
+	"testing"
+
+	"code.hybscloud.com/kont"
+)
+
+func TestResumeWithError(t *testing.T) {
+	for name, step := range map[string]func() (int, *kont.Suspension[int]){
+		"Cont": func() (int, *kont.Suspension[int]) {
+			return kont.Step(kont.Map(kont.Perform(kont.Ask[int]{}), func(n int) int { return n + 1 }))
+		},
+		"Expr": func() (int, *kont.Suspension[int]) {
+			return kont.StepExpr(kont.ExprMap(kont.ExprPerform(kont.Ask[int]{}), func(n int) int { return n + 1 }))
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, s := step()
+			_, s = s.ResumeWithError(io.EOF)
+			if s == nil {
+				t.Fatal("expected a suspension on Throw")
+			}
+			op, ok := s.Op().(kont.Throw[error])
+			if !ok || !errors.Is(op.Err, io.EOF) {
+				t.Fatalf("pending %T %v", s.Op(), s.Op())
+			}
+			// Resuming the Throw resumes the original operation.
+			if got, next := s.Resume(4); got != 5 || next != nil {
+				t.Fatalf("got %d", got)
+			}
+		})
+	}
+}
+
+func TestResumeThrowThroughScope(t *testing.T) {
+	wrap := func(err error) string { return "wrapped: " + err.Error() }
+	for name, step := range map[string]func() (int, *kont.Suspension[int]){
+		"Cont": func() (int, *kont.Suspension[int]) {
+			return kont.Step(kont.MapError(kont.Perform(kont.Ask[int]{}), wrap))
+		},
+		"Expr": func() (int, *kont.Suspension[int]) {
+			return kont.StepExpr(kont.ExprMapError(kont.ExprPerform(kont.Ask[int]{}), wrap))
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, s := step()
+			_, s = kont.ResumeThrow[error](s, io.EOF)
+			op, ok := s.Op().(kont.Throw[string])
+			if !ok || op.Err != "wrapped: EOF" {
+				t.Fatalf("pending %T %v", s.Op(), s.Op())
+			}
+			s.Discard()
+		})
+	}
+}
+
+func TestResumeWithErrorConsumes(t *testing.T) {
+	_, s := kont.Step(kont.Perform(kont.Ask[int]{}))
+	_, next := s.ResumeWithError(io.EOF)
+	next.Discard()
+	defer func() {
+		if r := recover(); r != "kont: suspension resumed twice" {
+			t.Fatalf("recovered %v", r)
+		}
+	}()
+	s.ResumeWithError(io.EOF)
+}
+
+func TestResumeWithErrorZip(t *testing.T) {
+	_, s := kont.Zip(kont.Pure(1), kont.Perform(kont.Ask[int]{}))
+	_, s = s.ResumeWithError(io.EOF)
+	if op, ok := s.Op().(kont.Throw[error]); !ok || !errors.Is(op.Err, io.EOF) {
+		t.Fatalf("pending %T %v", s.Op(), s.Op())
+	}
+	if got, next := s.Resume(2); got != (kont.Pair[int, int]{Fst: 1, Snd: 2}) || next != nil {
+		t.Fatalf("got %v", got)
+	}
+}
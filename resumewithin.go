@@ -0,0 +1,103 @@
+// ©Hayabusa Cloud Co., Ltd. 2026. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package kont
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Resume deadlines.
+// ResumeWithin bounds the work a resumption may do before it suspends or
+// completes, so a runaway Bind chain cannot hang an event loop thread. The
+// evaluator checks the deadline between frames; Go code inside a single
+// frame is not preempted.
+
+// ErrResumeTimeout is the sentinel matched by errors.Is when a resumption
+// exceeds its deadline.
+var ErrResumeTimeout = errors.New("kont: resume timed out")
+
+// ResumeTimeoutError reports a resumption abandoned at its deadline.
+// It matches [ErrResumeTimeout] under errors.Is.
+type ResumeTimeoutError struct {
+	Timeout time.Duration
+}
+
+func (e *ResumeTimeoutError) Error() string {
+	return fmt.Sprintf("kont: resume timed out after %v", e.Timeout)
+}
+
+// Unwrap returns ErrResumeTimeout.
+func (e *ResumeTimeoutError) Unwrap() error { return ErrResumeTimeout }
+
+// deadlineCheckEvery is the number of frames evaluated between clock reads.
+const deadlineCheckEvery = 64
+
+// resumeDeadline is the panic value that unwinds an overdue evaluation.
+type resumeDeadline struct{}
+
+// deadlineStepProcessor yields like stepProcessor, or like
+// sharedStepProcessor when shared. The deadline is checked through hooks.
+type deadlineStepProcessor[A any] struct {
+	shared bool
+}
+
+func (p deadlineStepProcessor[A]) processEffect(f *EffectFrame[Erased], rest Frame) (Erased, Frame, Erased, bool) {
+	if p.shared {
+		return sharedStepProcessor[A]{}.processEffect(f, rest)
+	}
+	return stepProcessor[A]{}.processEffect(f, rest)
+}
+
+func (deadlineStepProcessor[A]) processReturn(current Erased) Erased { return current }
+func (p deadlineStepProcessor[A]) ownsFrames() bool                  { return !p.shared }
+
+// ResumeWithin is [Suspension.Resume] bounded by d: if the computation
+// neither suspends nor completes within d, evaluation stops and a
+// [*ResumeTimeoutError] is returned with no suspension; the computation is
+// abandoned and its completion callbacks are not called.
+//
+// The deadline is checked between frames of suspensions from StepExpr.
+// Suspensions from Step run native Go calls between effects, which cannot
+// be interrupted; they resume as with Resume and never time out.
+// Panics if the suspension has already been resumed or discarded.
+func (s *Suspension[A]) ResumeWithin(v Resumed, d time.Duration) (a A, next *Suspension[A], err error) {
+	if s.ef == nil || s.ef.forwards {
+		a, next = s.Resume(v)
+		return a, next, nil
+	}
+	if s.used.Add(1) != 1 {
+		panic("kont: suspension resumed twice")
+	}
+	s.ended()
+	ef, rest, shared := s.ef, s.rest, s.shared
+	s.ef, s.rest = nil, nil
+	deadline := time.Now().Add(d)
+	frames := 0
+	hk := &EvalHooks{Before: func(FrameKind, Erased) {
+		if frames++; frames%deadlineCheckEvery == 0 && time.Now().After(deadline) {
+			panic(resumeDeadline{})
+		}
+	}}
+	defer func() {
+		if r := recover(); r != nil {
+			if _, ok := r.(resumeDeadline); !ok {
+				panic(r)
+			}
+			s.opt = nil
+			var zero A
+			a, next, err = zero, nil, &ResumeTimeoutError{Timeout: d}
+		}
+	}()
+	resumed := ef.Resume(v)
+	if !shared {
+		releaseEffectFrame(ef)
+	}
+	a, next = s.complete(classifyStepResult[A](
+		evalFramesHooked[deadlineStepProcessor[A], Erased](resumed, rest, deadlineStepProcessor[A]{shared: shared}, hk),
+	))
+	return a, next, nil
+}
@@ -0,0 +1,64 @@
+// ©Hayabusa Cloud Co., Ltd. 2026. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package kont_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"code.hybscloud.com/kont"
+)
+
+// spinForever returns an Expr that binds forever without performing an effect.
+func spinForever() kont.Expr[int] {
+	var loop *kont.BindFrame[kont.Erased, kont.Erased]
+	loop = &kont.BindFrame[kont.Erased, kont.Erased]{
+		F: func(v kont.Erased) kont.Expr[kont.Erased] {
+			return kont.Expr[kont.Erased]{Value: v, Frame: loop}
+		},
+		Next: kont.ReturnFrame{},
+	}
+	return kont.Expr[int]{Frame: loop}
+}
+
+func TestResumeWithinTimesOut(t *testing.T) {
+	comp := kont.ExprBind(kont.ExprPerform(kont.Get[int]{}), func(int) kont.Expr[int] { return spinForever() })
+	_, s := kont.StepExpr(comp)
+	called := false
+	s.OnComplete(func(int) { called = true })
+	_, next, err := s.ResumeWithin(1, 10*time.Millisecond)
+	var te *kont.ResumeTimeoutError
+	if !errors.As(err, &te) || !errors.Is(err, kont.ErrResumeTimeout) || te.Timeout != 10*time.Millisecond {
+		t.Fatalf("got %v", err)
+	}
+	if next != nil || called {
+		t.Fatalf("abandoned computation continued: next %v, called %v", next, called)
+	}
+}
+
+func TestResumeWithinCompletes(t *testing.T) {
+	comp := kont.ExprBind(kont.ExprPerform(kont.Get[int]{}), func(n int) kont.Expr[int] {
+		return kont.ExprMap(kont.ExprPerform(kont.Ask[int]{}), func(m int) int { return n + m })
+	})
+	_, s := kont.StepExpr(comp)
+	_, s, err := s.ResumeWithin(1, time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := s.Op().(kont.Ask[int]); !ok {
+		t.Fatalf("pending %T", s.Op())
+	}
+	if got, _, err := s.ResumeWithin(2, time.Second); err != nil || got != 3 {
+		t.Fatalf("got %d, %v", got, err)
+	}
+}
+
+func TestResumeWithinCont(t *testing.T) {
+	_, s := kont.Step(kont.Map(kont.Perform(kont.Get[int]{}), func(n int) int { return n * 2 }))
+	if got, next, err := s.ResumeWithin(4, time.Nanosecond); got != 8 || next != nil || err != nil {
+		t.Fatalf("got %d, %v, %v", got, next, err)
+	}
+}
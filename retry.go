@@ -0,0 +1,69 @@
+// ©Hayabusa Cloud Co., Ltd. 2026. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package kont
+
+import "time"
+
+// Op-level retries.
+// Dispatch has no error result, so a handler reports a failed dispatch by
+// panicking with an error value. The retrying wrapper recovers such panics
+// and re-dispatches the same operation, invisibly to the program.
+
+// RetryPolicy bounds op-level retries.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of dispatch attempts, including the
+	// first. Values below 1 are treated as 1.
+	MaxAttempts int
+	// Backoff returns the delay before retry n (1-based). Nil means no delay.
+	Backoff func(retry int) time.Duration
+}
+
+// retryOpsHandler wraps a handler and retries its failed dispatches.
+type retryOpsHandler[H Handler[H, R], R any] struct {
+	inner    H
+	policy   RetryPolicy
+	classify func(Operation, error) bool
+}
+
+// Dispatch implements Handler by delegating to the inner handler, retrying
+// while classify accepts the failure and attempts remain. The last failure
+// is re-panicked; non-error panics propagate unchanged.
+func (h *retryOpsHandler[H, R]) Dispatch(op Operation) (Resumed, bool) {
+	for attempt := 1; ; attempt++ {
+		v, shouldResume, err := h.try(op)
+		if err == nil {
+			return v, shouldResume
+		}
+		if attempt >= h.policy.MaxAttempts || !h.classify(op, err) {
+			panic(err)
+		}
+		if h.policy.Backoff != nil {
+			time.Sleep(h.policy.Backoff(attempt))
+		}
+	}
+}
+
+func (h *retryOpsHandler[H, R]) try(op Operation) (v Resumed, shouldResume bool, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			e, ok := r.(error)
+			if !ok {
+				panic(r)
+			}
+			err = e
+		}
+	}()
+	v, shouldResume = h.inner.Dispatch(op)
+	return v, shouldResume, nil
+}
+
+// RetryOps wraps inner so that dispatches failing with an error panic are
+// retried according to policy when classify(op, err) reports the failure as
+// transient. The inner handler must be safe to re-dispatch for every
+// operation classify accepts.
+// Returns a concrete handler.
+func RetryOps[R any, H Handler[H, R]](inner H, policy RetryPolicy, classify func(Operation, error) bool) *retryOpsHandler[H, R] {
+	return &retryOpsHandler[H, R]{inner: inner, policy: policy, classify: classify}
+}
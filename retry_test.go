@@ -0,0 +1,94 @@
+// ©Hayabusa Cloud Co., Ltd. 2026. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package kont_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"code.hybscloud.com/kont"
+)
+
+var errTransient = errors.New("transient")
+
+// flakyGet fails the first n dispatches of Get[int] with err, then resumes with 7.
+func flakyGet(n int, err error, calls *int) func(kont.Operation) (kont.Resumed, bool) {
+	return func(op kont.Operation) (kont.Resumed, bool) {
+		*calls++
+		if *calls <= n {
+			panic(err)
+		}
+		return 7, true
+	}
+}
+
+func isTransient(_ kont.Operation, err error) bool { return errors.Is(err, errTransient) }
+
+func TestRetryOpsRecovers(t *testing.T) {
+	var calls int
+	h := kont.RetryOps[int](kont.HandleFunc[int](flakyGet(2, errTransient, &calls)),
+		kont.RetryPolicy{MaxAttempts: 3}, isTransient)
+	if got := kont.Handle(kont.Perform(kont.Get[int]{}), h); got != 7 {
+		t.Fatalf("got %d, want 7", got)
+	}
+	if calls != 3 {
+		t.Fatalf("got %d calls, want 3", calls)
+	}
+}
+
+func TestRetryOpsExhausted(t *testing.T) {
+	var calls int
+	h := kont.RetryOps[int](kont.HandleFunc[int](flakyGet(5, errTransient, &calls)),
+		kont.RetryPolicy{MaxAttempts: 2}, isTransient)
+	defer func() {
+		if r := recover(); r != errTransient {
+			t.Fatalf("got panic %v, want last failure", r)
+		}
+		if calls != 2 {
+			t.Fatalf("got %d calls, want 2", calls)
+		}
+	}()
+	kont.Handle(kont.Perform(kont.Get[int]{}), h)
+}
+
+func TestRetryOpsNotClassified(t *testing.T) {
+	var calls int
+	fatal := errors.New("fatal")
+	h := kont.RetryOps[int](kont.HandleFunc[int](flakyGet(1, fatal, &calls)),
+		kont.RetryPolicy{MaxAttempts: 5}, isTransient)
+	defer func() {
+		if r := recover(); r != fatal || calls != 1 {
+			t.Fatalf("got panic %v after %d calls", r, calls)
+		}
+	}()
+	kont.Handle(kont.Perform(kont.Get[int]{}), h)
+}
+
+func TestRetryOpsBackoff(t *testing.T) {
+	var calls int
+	var delays []int
+	h := kont.RetryOps[int](kont.HandleFunc[int](flakyGet(2, errTransient, &calls)),
+		kont.RetryPolicy{MaxAttempts: 3, Backoff: func(n int) time.Duration {
+			delays = append(delays, n)
+			return 0
+		}}, isTransient)
+	kont.Handle(kont.Perform(kont.Get[int]{}), h)
+	if len(delays) != 2 || delays[0] != 1 || delays[1] != 2 {
+		t.Fatalf("got backoff calls %v, want [1 2]", delays)
+	}
+}
+
+func TestRetryOpsNonErrorPanic(t *testing.T) {
+	h := kont.RetryOps[int](kont.HandleFunc[int](func(kont.Operation) (kont.Resumed, bool) {
+		panic("not an error")
+	}), kont.RetryPolicy{MaxAttempts: 3}, func(kont.Operation, error) bool { return true })
+	defer func() {
+		if r := recover(); r != "not an error" {
+			t.Fatalf("got panic %v", r)
+		}
+	}()
+	kont.Handle(kont.Perform(kont.Get[int]{}), h)
+}
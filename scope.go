@@ -0,0 +1,365 @@
+// ©Hayabusa Cloud Co., Ltd. 2026. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package kont
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Structured concurrency.
+// RunScope runs a computation that may Spawn children, each stepped on its
+// own goroutine, with errgroup semantics: the first child error cancels the
+// rest, Join waits for every child spawned so far, and the scope does not
+// return until all children have completed or been cancelled. Cancellation
+// is cooperative: a cancelled child is discarded at its next operation.
+//
+// Every computation in a scope runs under a context. A child's context
+// descends from the scope's, so cancelling the scope cancels it, and
+// inherits the deadline of the computation that spawned it, including one
+// set by WithTimeout; Spawn.Timeout can shorten it further. A child whose
+// deadline passes is discarded at its next operation and fails with a
+// [*CancelledError]. Under RunScopeCtx the Context effect is answered from
+// these contexts.
+
+// Spawn is the effect operation for starting Body as a child of the
+// enclosing scope. Resumes immediately.
+type Spawn struct {
+	Body Cont[Resumed, error]
+	// Timeout, if positive, bounds the child's deadline; the earlier of it
+	// and the inherited deadline applies.
+	Timeout time.Duration
+
+	// ctx is the context of the innermost WithTimeout scope, or nil for
+	// the performing computation's context.
+	ctx context.Context
+	// member is the Group child this Spawn starts in place of Body, or nil.
+	member groupMember
+}
+
+func (Spawn) OpResult() struct{} { panic("phantom") }
+
+func (o Spawn) spawn(g *scopeGroup, ctx context.Context) Resumed {
+	if o.ctx != nil {
+		ctx = o.ctx
+	}
+	if o.member != nil {
+		body, report, failFast := o.member.start()
+		g.spawn(body, ctx, o.Timeout, report, failFast)
+	} else {
+		g.spawn(o.Body, ctx, o.Timeout, nil, true)
+	}
+	return struct{}{}
+}
+
+// groupMember is a child spawned through a Group.
+type groupMember interface {
+	// start reserves the child's result slot and returns its body, the
+	// function recording a failure, and whether a failure fails the scope.
+	start() (body Cont[Resumed, error], report func(error), failFast bool)
+}
+
+// Join is the effect operation for waiting until every child spawned so far
+// has completed or been cancelled. [JoinScope] resumes with the first child
+// error, or nil. Only the scope's own computation may Join; a child that
+// performs it panics.
+type Join struct{}
+
+func (Join) OpResult() joined { panic("phantom") }
+
+func (Join) join(g *scopeGroup) Resumed { return joined{err: g.join()} }
+
+// joined carries the error Join resumes with, so that the resume value is
+// non-nil even when no child failed.
+type joined struct{ err error }
+
+func (j joined) error() error { return j.err }
+
+// CancelAll is the effect operation for cancelling every running child and
+// every child spawned later. Resumes immediately.
+type CancelAll struct{}
+
+func (CancelAll) OpResult() struct{} { panic("phantom") }
+
+func (CancelAll) cancelAll(g *scopeGroup) Resumed {
+	g.stop()
+	return struct{}{}
+}
+
+// SpawnScope performs Spawn.
+func SpawnScope(body Cont[Resumed, error]) Cont[Resumed, struct{}] {
+	return Perform(Spawn{Body: body})
+}
+
+// JoinScope performs Join.
+func JoinScope() Cont[Resumed, error] {
+	return Map(Perform(Join{}), joined.error)
+}
+
+// SpawnScopeWithin performs Spawn with a timeout for the child.
+func SpawnScopeWithin(d time.Duration, body Cont[Resumed, error]) Cont[Resumed, struct{}] {
+	return Perform(Spawn{Body: body, Timeout: d})
+}
+
+// CancelAllScope performs CancelAll.
+func CancelAllScope() Cont[Resumed, struct{}] {
+	return Perform(CancelAll{})
+}
+
+// ExprSpawnScope performs Spawn (Expr).
+func ExprSpawnScope(body Cont[Resumed, error]) Expr[struct{}] {
+	return ExprPerform(Spawn{Body: body})
+}
+
+// ExprSpawnScopeWithin performs Spawn with a timeout for the child (Expr).
+func ExprSpawnScopeWithin(d time.Duration, body Cont[Resumed, error]) Expr[struct{}] {
+	return ExprPerform(Spawn{Body: body, Timeout: d})
+}
+
+// ExprJoinScope performs Join (Expr).
+func ExprJoinScope() Expr[error] {
+	return ExprMap(ExprPerform(Join{}), joined.error)
+}
+
+// ExprCancelAllScope performs CancelAll (Expr).
+func ExprCancelAllScope() Expr[struct{}] {
+	return ExprPerform(CancelAll{})
+}
+
+// scopeGroup tracks the children of one RunScope.
+type scopeGroup struct {
+	dispatch  func(Operation) (Resumed, bool)
+	ctx       context.Context
+	cancel    context.CancelFunc
+	answerCtx bool
+	wg        sync.WaitGroup
+	cancelled atomic.Bool
+	once      sync.Once
+	err       error
+	panicked  any
+	didPanic  bool
+}
+
+// errScopeCancelled is the error of a child discarded because its scope was
+// cancelled. It does not fail the scope.
+var errScopeCancelled error = &CancelledError{Cause: context.Canceled}
+
+// spawn starts body under a context derived from parent. A non-nil error
+// is passed to report, if any, and fails the scope if failFast.
+func (g *scopeGroup) spawn(body Cont[Resumed, error], parent context.Context, timeout time.Duration, report func(error), failFast bool) {
+	ctx, cancel := g.childContext(parent, timeout)
+	g.wg.Go(func() {
+		defer cancel()
+		defer func() {
+			if r := recover(); r != nil {
+				g.fail(nil, r, true)
+			}
+		}()
+		err := g.runChild(body, ctx)
+		if err == nil {
+			return
+		}
+		if report != nil {
+			report(err)
+		}
+		if failFast && err != errScopeCancelled {
+			g.fail(err, nil, false)
+		}
+	})
+}
+
+// childContext derives a child's context from the scope's, with the
+// earlier of parent's deadline and timeout.
+func (g *scopeGroup) childContext(parent context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+	deadline, ok := parent.Deadline()
+	if timeout > 0 {
+		if d := time.Now().Add(timeout); !ok || d.Before(deadline) {
+			deadline, ok = d, true
+		}
+	}
+	if ok {
+		return context.WithDeadline(g.ctx, deadline)
+	}
+	return context.WithCancel(g.ctx)
+}
+
+// stop cancels every running child and every child spawned later.
+func (g *scopeGroup) stop() {
+	g.cancelled.Store(true)
+	g.cancel()
+}
+
+// fail records the first child failure and cancels the other children.
+func (g *scopeGroup) fail(err error, panicked any, didPanic bool) {
+	g.once.Do(func() { g.err, g.panicked, g.didPanic = err, panicked, didPanic })
+	g.stop()
+}
+
+// runChild steps body under ctx until it completes, is cancelled, or
+// short-circuits. A short-circuit value that is an error becomes the
+// child's error.
+func (g *scopeGroup) runChild(body Cont[Resumed, error], ctx context.Context) error {
+	err, susp := Step(body)
+	for susp != nil {
+		if g.cancelled.Load() {
+			susp.Discard()
+			return errScopeCancelled
+		}
+		if ctx.Err() != nil {
+			susp.Discard()
+			return cancelledBy(ctx)
+		}
+		op := susp.Op()
+		if _, ok := op.(interface{ join(*scopeGroup) Resumed }); ok {
+			susp.Discard()
+			panic("kont: Join performed by a scope child")
+		}
+		if err := g.checkFailed(op, ctx); err != nil {
+			susp.Discard()
+			return err
+		}
+		v, shouldResume := g.handle(op, ctx)
+		if !shouldResume {
+			susp.Discard()
+			err, _ = v.(error)
+			return err
+		}
+		err, susp = susp.Resume(v)
+	}
+	return err
+}
+
+// checkFailed returns the error for a CheckCancelled answered by the scope
+// whose context is done, or nil.
+func (g *scopeGroup) checkFailed(op Operation, ctx context.Context) error {
+	c, ok := op.(CheckCancelled)
+	if !ok || !g.answerCtx {
+		return nil
+	}
+	if c.ctx != nil {
+		ctx = c.ctx
+	}
+	if ctx.Err() != nil {
+		return cancelledBy(ctx)
+	}
+	return nil
+}
+
+// handle answers the scope operations, and the Context effect under
+// RunScopeCtx, for a computation running under ctx and dispatches the rest.
+func (g *scopeGroup) handle(op Operation, ctx context.Context) (Resumed, bool) {
+	switch o := op.(type) {
+	case interface {
+		spawn(*scopeGroup, context.Context) Resumed
+	}:
+		return o.spawn(g, ctx), true
+	case interface{ join(*scopeGroup) Resumed }:
+		return o.join(g), true
+	case interface{ cancelAll(*scopeGroup) Resumed }:
+		return o.cancelAll(g), true
+	}
+	if g.answerCtx {
+		switch op.(type) {
+		case AskCtx:
+			return ctx, true
+		case CheckCancelled:
+			return struct{}{}, true
+		}
+	}
+	if g.dispatch == nil {
+		unhandledEffect("RunScope", op)
+	}
+	return g.dispatch(op)
+}
+
+// join waits for the children and re-raises the first child panic.
+func (g *scopeGroup) join() error {
+	g.wg.Wait()
+	if g.didPanic {
+		panic(g.panicked)
+	}
+	return g.err
+}
+
+// RunScope runs m as the computation of a scope and returns its result with
+// the first child error. dispatch handles operations other than Spawn, Join
+// and CancelAll for m and every child; it is called from several goroutines
+// and must be safe for concurrent use. It may be nil.
+//
+// When m completes, RunScope waits for the remaining children. When m
+// short-circuits or panics, the children are cancelled first. A child whose
+// operation dispatch short-circuits ends with the short-circuit value as its
+// error if it is one. A child panic cancels the other children and is
+// re-raised on the calling goroutine by the next Join, or once all children
+// have finished.
+func RunScope[A any](m Cont[Resumed, A], dispatch func(Operation) (Resumed, bool)) (A, error) {
+	a, s := Step(m)
+	return runScope(context.Background(), false, a, s, dispatch)
+}
+
+// RunScopeExpr is the Expr counterpart of [RunScope].
+func RunScopeExpr[A any](m Expr[A], dispatch func(Operation) (Resumed, bool)) (A, error) {
+	a, s := StepExpr(m)
+	return runScope(context.Background(), false, a, s, dispatch)
+}
+
+// RunScopeCtx is [RunScope] under ctx. The scope's context descends from
+// ctx and is cancelled with the scope; AskCtx and CheckCancelled are
+// answered from the context of the computation that performs them. ctx is
+// polled before every dispatch for m; once it is done, or a CheckCancelled
+// in m fails, m is abandoned, the children are cancelled, and the first
+// child error or else a [*CancelledError] is returned.
+func RunScopeCtx[A any](ctx context.Context, m Cont[Resumed, A], dispatch func(Operation) (Resumed, bool)) (A, error) {
+	a, s := Step(m)
+	return runScope(ctx, true, a, s, dispatch)
+}
+
+// RunScopeCtxExpr is the Expr counterpart of [RunScopeCtx].
+func RunScopeCtxExpr[A any](ctx context.Context, m Expr[A], dispatch func(Operation) (Resumed, bool)) (A, error) {
+	a, s := StepExpr(m)
+	return runScope(ctx, true, a, s, dispatch)
+}
+
+func runScope[A any](ctx context.Context, answerCtx bool, a A, s *Suspension[A], dispatch func(Operation) (Resumed, bool)) (A, error) {
+	g := &scopeGroup{dispatch: dispatch, answerCtx: answerCtx}
+	g.ctx, g.cancel = context.WithCancel(ctx)
+	completed := false
+	defer func() {
+		if !completed {
+			g.stop()
+			g.wg.Wait()
+		}
+		g.cancel()
+	}()
+	for s != nil {
+		op := s.Op()
+		err := g.checkFailed(op, g.ctx)
+		if err == nil && ctx.Err() != nil {
+			err = cancelledBy(ctx)
+		}
+		if err != nil {
+			s.Discard()
+			g.stop()
+			completed = true
+			if jerr := g.join(); jerr != nil {
+				err = jerr
+			}
+			var zero A
+			return zero, err
+		}
+		v, shouldResume := g.handle(op, g.ctx)
+		if !shouldResume {
+			s.Discard()
+			a = valueOrZero[A](v)
+			g.stop()
+			break
+		}
+		a, s = s.Resume(v)
+	}
+	completed = true
+	return a, g.join()
+}
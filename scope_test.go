@@ -0,0 +1,203 @@
+// ©Hayabusa Cloud Co., Ltd. 2026. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package kont_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"code.hybscloud.com/kont"
+)
+
+// block is answered only after release is closed, so scope tests can hold a
+// child at an operation.
+type block struct{}
+
+func (block) OpResult() struct{} { panic("phantom") }
+
+func blockingDispatch(release <-chan struct{}) func(kont.Operation) (kont.Resumed, bool) {
+	return func(op kont.Operation) (kont.Resumed, bool) {
+		if _, ok := op.(block); ok {
+			<-release
+			return struct{}{}, true
+		}
+		panic(fmt.Sprintf("unexpected %T", op))
+	}
+}
+
+func TestRunScopeJoinsChildren(t *testing.T) {
+	var done atomic.Int32
+	child := kont.Map(kont.Perform(kont.Ask[int]{}), func(n int) error {
+		done.Add(int32(n))
+		return nil
+	})
+	comp := kont.Bind(kont.SpawnScope(child), func(struct{}) kont.Eff[int] {
+		return kont.Then(kont.SpawnScope(child), kont.Pure(7))
+	})
+	got, err := kont.RunScope(comp, func(op kont.Operation) (kont.Resumed, bool) { return 2, true })
+	if got != 7 || err != nil || done.Load() != 4 {
+		t.Fatalf("got %d, %v, done %d", got, err, done.Load())
+	}
+}
+
+func TestRunScopeFirstErrorCancels(t *testing.T) {
+	release := make(chan struct{})
+	close(release)
+	var finished atomic.Bool
+	slow := kont.Map(kont.Perform(block{}), func(struct{}) error {
+		finished.Store(true)
+		return nil
+	})
+	comp := kont.Bind(kont.SpawnScope(kont.Pure[error](io.EOF)), func(struct{}) kont.Eff[error] {
+		return kont.Bind(kont.JoinScope(), func(err error) kont.Eff[error] {
+			// The failure has cancelled the scope, so later children
+			// stop at their first operation.
+			return kont.Then(kont.SpawnScope(slow), kont.Pure(err))
+		})
+	})
+	joined, err := kont.RunScopeExpr(kont.Reify(comp), blockingDispatch(release))
+	if !errors.Is(joined, io.EOF) || !errors.Is(err, io.EOF) {
+		t.Fatalf("joined %v, err %v", joined, err)
+	}
+	if finished.Load() {
+		t.Fatal("slow child was not cancelled")
+	}
+}
+
+func TestRunScopeCancelAll(t *testing.T) {
+	release := make(chan struct{})
+	var finished atomic.Bool
+	slow := kont.Map(kont.Then(kont.Perform(block{}), kont.Perform(block{})), func(struct{}) error {
+		finished.Store(true)
+		return nil
+	})
+	comp := kont.ExprThen(kont.ExprSpawnScope(slow), kont.ExprBind(kont.ExprCancelAllScope(), func(struct{}) kont.Expr[error] {
+		close(release)
+		return kont.ExprJoinScope()
+	}))
+	joined, err := kont.RunScopeExpr(comp, blockingDispatch(release))
+	if joined != nil || err != nil || finished.Load() {
+		t.Fatalf("joined %v, err %v, finished %v", joined, err, finished.Load())
+	}
+}
+
+func TestRunScopeWaitsOnReturn(t *testing.T) {
+	release := make(chan struct{})
+	var finished atomic.Bool
+	slow := kont.Map(kont.Perform(block{}), func(struct{}) error {
+		finished.Store(true)
+		return nil
+	})
+	comp := kont.Bind(kont.SpawnScope(slow), func(struct{}) kont.Eff[int] {
+		close(release)
+		return kont.Pure(1)
+	})
+	if got, err := kont.RunScope(comp, blockingDispatch(release)); got != 1 || err != nil || !finished.Load() {
+		t.Fatalf("got %d, %v, finished %v", got, err, finished.Load())
+	}
+}
+
+func TestRunScopeChildPanic(t *testing.T) {
+	defer func() {
+		if r := recover(); r != "kont: Join performed by a scope child" {
+			t.Fatalf("recovered %v", r)
+		}
+	}()
+	kont.RunScope(kont.Then(kont.SpawnScope(kont.Then(kont.JoinScope(), kont.Pure[error](nil))), kont.JoinScope()), nil)
+	t.Fatal("expected panic")
+}
+
+func TestRunScopeUnhandled(t *testing.T) {
+	defer func() {
+		if r := recover(); unhandledIn(r) != "RunScope" {
+			t.Fatalf("recovered %v", r)
+		}
+	}()
+	kont.RunScope(kont.Perform(kont.Ask[int]{}), nil)
+	t.Fatal("expected panic")
+}
+
+func TestRunScopeCtxInheritsDeadline(t *testing.T) {
+	parent, cancel := context.WithTimeout(context.Background(), time.Hour)
+	defer cancel()
+	want, _ := parent.Deadline()
+	ctxs := make(chan context.Context, 3)
+	report := kont.Map(kont.GetCtx(), func(ctx context.Context) error {
+		ctxs <- ctx
+		return nil
+	})
+	comp := kont.Then(kont.SpawnScope(report),
+		kont.Then(kont.SpawnScopeWithin(time.Minute, report),
+			kont.Then(kont.WithTimeout(time.Second, kont.SpawnScope(report)),
+				kont.Then(kont.JoinScope(), kont.CancelAllScope()))))
+	if _, err := kont.RunScopeCtx(parent, comp, nil); err != nil {
+		t.Fatal(err)
+	}
+	close(ctxs)
+	var left []time.Duration
+	for ctx := range ctxs {
+		d, ok := ctx.Deadline()
+		if !ok || d.After(want) {
+			t.Fatalf("deadline %v, %v beyond %v", d, ok, want)
+		}
+		if ctx.Err() == nil {
+			t.Fatal("child context outlived the scope")
+		}
+		left = append(left, time.Until(d))
+	}
+	// The WithTimeout child, the SpawnScopeWithin child, and the child
+	// inheriting the hour from parent.
+	slices.Sort(left)
+	if len(left) != 3 || left[0] > time.Second || left[1] <= time.Second || left[1] > time.Minute || left[2] <= time.Minute {
+		t.Fatalf("remaining %v", left)
+	}
+}
+
+func TestRunScopeChildTimeout(t *testing.T) {
+	slow := func(op kont.Operation) (kont.Resumed, bool) {
+		time.Sleep(5 * time.Millisecond)
+		return struct{}{}, true
+	}
+	child := kont.Then(kont.Then(kont.Perform(block{}), kont.Perform(block{})), kont.Pure[error](nil))
+	_, err := kont.RunScope(kont.Then(kont.SpawnScopeWithin(time.Millisecond, child), kont.JoinScope()), slow)
+	var ce *kont.CancelledError
+	if !errors.As(err, &ce) || !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("got %v, want CancelledError for the deadline", err)
+	}
+}
+
+func TestRunScopeCtxCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	comp := kont.Then(kont.Perform(block{}), kont.Then(kont.Perform(block{}), kont.Pure(1)))
+	_, err := kont.RunScopeCtx(ctx, comp, func(kont.Operation) (kont.Resumed, bool) {
+		cancel()
+		return struct{}{}, true
+	})
+	if !errors.Is(err, kont.ErrCancelled) || !errors.Is(err, context.Canceled) {
+		t.Fatalf("got %v", err)
+	}
+}
+
+func TestJoinScopeNilError(t *testing.T) {
+	ok := kont.Pure[error](nil)
+	comp := kont.Bind(kont.SpawnScope(ok), func(struct{}) kont.Eff[bool] {
+		return kont.Map(kont.JoinScope(), func(err error) bool { return err == nil })
+	})
+	if got, err := kont.RunScope(comp, nil); !got || err != nil {
+		t.Fatalf("Cont: got %v, %v", got, err)
+	}
+	expr := kont.ExprThen(kont.ExprSpawnScope(ok), kont.ExprBind(kont.ExprJoinScope(), func(err error) kont.Expr[bool] {
+		return kont.ExprReturn(err == nil)
+	}))
+	if got, err := kont.RunScopeExpr(expr, nil); !got || err != nil {
+		t.Fatalf("Expr: got %v, %v", got, err)
+	}
+}
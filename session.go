@@ -0,0 +1,131 @@
+// ©Hayabusa Cloud Co., Ltd. 2026. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package kont
+
+import "fmt"
+
+// Session-typed protocols.
+// A protocol is a type built from ProtoSend, ProtoRecv, and ProtoEnd. A
+// ProtoSession[P] token permits exactly the next step of P, and each step
+// returns the token for the rest of the protocol, so sending or receiving
+// out of order does not type-check. RunSession wires two computations
+// together as peers.
+
+// ProtoSend is the protocol step that sends a T, then continues as Next.
+type ProtoSend[T, Next any] struct{}
+
+// ProtoRecv is the protocol step that receives a T, then continues as Next.
+type ProtoRecv[T, Next any] struct{}
+
+// ProtoEnd is the completed protocol.
+type ProtoEnd struct{}
+
+// ProtoSession is the capability to perform the next step of protocol P.
+// Tokens are zero-size; use each one once.
+type ProtoSession[P any] struct{}
+
+// SessionSend is the effect operation for sending a message to the peer.
+type SessionSend struct{ Value any }
+
+func (SessionSend) OpResult() struct{} { panic("phantom") }
+
+// SessionRecv is the effect operation for receiving a T from the peer.
+type SessionRecv[T any] struct{}
+
+func (SessionRecv[T]) OpResult() T { panic("phantom") }
+
+func (SessionRecv[T]) accepts(v any) bool {
+	_, ok := v.(T)
+	return ok
+}
+
+// SendMsg sends v and returns the session for the rest of the protocol.
+func SendMsg[T, Next any](_ ProtoSession[ProtoSend[T, Next]], v T) Cont[Resumed, ProtoSession[Next]] {
+	return Map(Perform(SessionSend{Value: v}), func(struct{}) ProtoSession[Next] { return ProtoSession[Next]{} })
+}
+
+// RecvMsg receives a message and returns it with the session for the rest
+// of the protocol.
+func RecvMsg[T, Next any](_ ProtoSession[ProtoRecv[T, Next]]) Cont[Resumed, Pair[T, ProtoSession[Next]]] {
+	return Map(Perform(SessionRecv[T]{}), func(v T) Pair[T, ProtoSession[Next]] {
+		return Pair[T, ProtoSession[Next]]{Fst: v}
+	})
+}
+
+// RunSession runs client and server as peers until both complete: each
+// SendMsg is delivered to the other side's next RecvMsg, in order. P and Q
+// should be dual (every ProtoSend in one matched by a ProtoRecv of the same
+// type in the other); the pairing is checked as messages flow. dispatch
+// handles any other operation and may be nil.
+//
+// Panics if a received message has the wrong type, if both peers wait to
+// receive, or if a peer completes with messages left unread.
+func RunSession[P, Q, A, B any](
+	client func(ProtoSession[P]) Cont[Resumed, A],
+	server func(ProtoSession[Q]) Cont[Resumed, B],
+	dispatch func(Operation) (Resumed, bool),
+) (A, B) {
+	var c sessionPeer[A]
+	var s sessionPeer[B]
+	c.result, c.susp = Step(client(ProtoSession[P]{}))
+	s.result, s.susp = Step(server(ProtoSession[Q]{}))
+	for c.susp != nil || s.susp != nil {
+		cp := c.advance(&s.inbox, dispatch)
+		sp := s.advance(&c.inbox, dispatch)
+		if !cp && !sp {
+			panic("kont: session deadlock: both peers are waiting to receive")
+		}
+	}
+	if len(c.inbox) != 0 || len(s.inbox) != 0 {
+		panic("kont: session ended with unread messages")
+	}
+	return c.result, s.result
+}
+
+// sessionPeer is one side of a RunSession.
+type sessionPeer[R any] struct {
+	result R
+	susp   *Suspension[R]
+	inbox  []any
+}
+
+// advance runs the peer until it completes or waits on an empty inbox,
+// appending its sends to out. Reports whether it made progress.
+func (p *sessionPeer[R]) advance(out *[]any, dispatch func(Operation) (Resumed, bool)) bool {
+	progressed := false
+	for p.susp != nil {
+		var v Resumed
+		switch op := p.susp.Op().(type) {
+		case SessionSend:
+			*out = append(*out, op.Value)
+			v = struct{}{}
+		case interface{ accepts(any) bool }:
+			if len(p.inbox) == 0 {
+				return progressed
+			}
+			v = p.inbox[0]
+			if !op.accepts(v) {
+				panic(fmt.Sprintf("kont: session protocol mismatch: received %T for %T", v, op))
+			}
+			p.inbox[0] = nil
+			p.inbox = p.inbox[1:]
+		default:
+			if dispatch == nil {
+				unhandledEffect("RunSession", op)
+			}
+			var shouldResume bool
+			v, shouldResume = dispatch(op)
+			if !shouldResume {
+				p.susp.Discard()
+				p.susp = nil
+				p.result = valueOrZero[R](v)
+				return true
+			}
+		}
+		p.result, p.susp = p.susp.Resume(v)
+		progressed = true
+	}
+	return progressed
+}
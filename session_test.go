@@ -0,0 +1,93 @@
+// ©Hayabusa Cloud Co., Ltd. 2026. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package kont_test
+
+import (
+	"strings"
+	"testing"
+
+	"code.hybscloud.com/kont"
+)
+
+// The client sends a name and receives a greeting; the server is its dual.
+type (
+	greetClient = kont.ProtoSend[string, kont.ProtoRecv[string, kont.ProtoEnd]]
+	greetServer = kont.ProtoRecv[string, kont.ProtoSend[string, kont.ProtoEnd]]
+)
+
+func greetingClient(s kont.ProtoSession[greetClient]) kont.Eff[string] {
+	return kont.Bind(kont.SendMsg(s, "ada"), func(s kont.ProtoSession[kont.ProtoRecv[string, kont.ProtoEnd]]) kont.Eff[string] {
+		return kont.Map(kont.RecvMsg(s), func(p kont.Pair[string, kont.ProtoSession[kont.ProtoEnd]]) string { return p.Fst })
+	})
+}
+
+func greetingServer(s kont.ProtoSession[greetServer]) kont.Eff[int] {
+	return kont.Bind(kont.RecvMsg(s), func(p kont.Pair[string, kont.ProtoSession[kont.ProtoSend[string, kont.ProtoEnd]]]) kont.Eff[int] {
+		return kont.Map(kont.SendMsg(p.Snd, "hello, "+p.Fst), func(kont.ProtoSession[kont.ProtoEnd]) int { return len(p.Fst) })
+	})
+}
+
+func TestRunSession(t *testing.T) {
+	got, n := kont.RunSession(greetingClient, greetingServer, nil)
+	if got != "hello, ada" || n != 3 {
+		t.Fatalf("got %q, %d", got, n)
+	}
+}
+
+func TestRunSessionOtherEffects(t *testing.T) {
+	server := func(s kont.ProtoSession[greetServer]) kont.Eff[int] {
+		return kont.Bind(kont.RecvMsg(s), func(p kont.Pair[string, kont.ProtoSession[kont.ProtoSend[string, kont.ProtoEnd]]]) kont.Eff[int] {
+			return kont.AskReader(func(greeting string) kont.Eff[int] {
+				return kont.Then(kont.SendMsg(p.Snd, greeting+p.Fst), kont.Pure(0))
+			})
+		})
+	}
+	got, _ := kont.RunSession(greetingClient, server, func(op kont.Operation) (kont.Resumed, bool) {
+		return "hi ", true
+	})
+	if got != "hi ada" {
+		t.Fatalf("got %q", got)
+	}
+}
+
+func TestRunSessionDeadlock(t *testing.T) {
+	recvFirst := func(s kont.ProtoSession[kont.ProtoRecv[string, kont.ProtoEnd]]) kont.Eff[string] {
+		return kont.Map(kont.RecvMsg(s), func(p kont.Pair[string, kont.ProtoSession[kont.ProtoEnd]]) string { return p.Fst })
+	}
+	defer func() {
+		if r := recover(); r != "kont: session deadlock: both peers are waiting to receive" {
+			t.Fatalf("got panic %v", r)
+		}
+	}()
+	kont.RunSession(recvFirst, recvFirst, nil)
+}
+
+func TestRunSessionMismatch(t *testing.T) {
+	sendInt := func(s kont.ProtoSession[kont.ProtoSend[int, kont.ProtoEnd]]) kont.Eff[struct{}] {
+		return kont.Map(kont.SendMsg(s, 1), func(kont.ProtoSession[kont.ProtoEnd]) struct{} { return struct{}{} })
+	}
+	recvString := func(s kont.ProtoSession[kont.ProtoRecv[string, kont.ProtoEnd]]) kont.Eff[string] {
+		return kont.Map(kont.RecvMsg(s), func(p kont.Pair[string, kont.ProtoSession[kont.ProtoEnd]]) string { return p.Fst })
+	}
+	defer func() {
+		if r, _ := recover().(string); !strings.HasPrefix(r, "kont: session protocol mismatch: received int") {
+			t.Fatalf("got panic %q", r)
+		}
+	}()
+	kont.RunSession(sendInt, recvString, nil)
+}
+
+func TestRunSessionUnread(t *testing.T) {
+	send := func(s kont.ProtoSession[kont.ProtoSend[int, kont.ProtoEnd]]) kont.Eff[int] {
+		return kont.Then(kont.SendMsg(s, 1), kont.Pure(0))
+	}
+	ignore := func(kont.ProtoSession[kont.ProtoRecv[int, kont.ProtoEnd]]) kont.Eff[int] { return kont.Pure(0) }
+	defer func() {
+		if r := recover(); r != "kont: session ended with unread messages" {
+			t.Fatalf("got panic %v", r)
+		}
+	}()
+	kont.RunSession(send, ignore, nil)
+}
@@ -0,0 +1,204 @@
+// ©Hayabusa Cloud Co., Ltd. 2026. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package kont
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"io"
+)
+
+// SinkOptions configures a [RecordSink].
+type SinkOptions struct {
+	// FlushEvery flushes buffered output after every N records.
+	// Zero flushes only on explicit Flush and on rotation.
+	FlushEvery int
+	// RotateEvery rotates the destination after every N records.
+	// Zero disables rotation.
+	RotateEvery int
+	// Rotate is called on rotation with the current destination, after it
+	// has been flushed, and returns the next one. It may close or rename
+	// the old destination. Required when RotateEvery is set: the sink
+	// constructors panic without it.
+	Rotate func(old io.Writer) (io.Writer, error)
+}
+
+// recordEncoder encodes records onto one destination.
+type recordEncoder[W any] interface {
+	encode(W) error
+	flush() error
+}
+
+// RecordSink encodes Writer output as structured records on an io.Writer,
+// keeping memory bounded to the encoder's buffer.
+// A RecordSink is not safe for concurrent use.
+type RecordSink[W any] struct {
+	dst         io.Writer
+	newEncoder  func(io.Writer) recordEncoder[W]
+	enc         recordEncoder[W]
+	opts        SinkOptions
+	records     int
+	sinceFlush  int
+	sinceRotate int
+	err         error
+}
+
+func newRecordSink[W any](w io.Writer, opts SinkOptions, newEncoder func(io.Writer) recordEncoder[W]) *RecordSink[W] {
+	if opts.RotateEvery > 0 && opts.Rotate == nil {
+		panic("kont: RecordSink requires Rotate when RotateEvery is set")
+	}
+	return &RecordSink[W]{dst: w, newEncoder: newEncoder, enc: newEncoder(w), opts: opts}
+}
+
+// NewJSONLinesSink creates a RecordSink writing one JSON document per line.
+// Panics if opts sets RotateEvery without Rotate.
+func NewJSONLinesSink[W any](w io.Writer, opts SinkOptions) *RecordSink[W] {
+	return newRecordSink(w, opts, func(w io.Writer) recordEncoder[W] {
+		bw := bufio.NewWriter(w)
+		return &jsonLinesEncoder[W]{bw: bw, enc: json.NewEncoder(bw)}
+	})
+}
+
+// NewCSVSink creates a RecordSink writing one CSV row per record, as
+// produced by toRecord. A non-nil header is written at the start of every
+// destination, including each one obtained by rotation.
+// Panics if opts sets RotateEvery without Rotate.
+func NewCSVSink[W any](w io.Writer, header []string, toRecord func(W) []string, opts SinkOptions) *RecordSink[W] {
+	return newRecordSink(w, opts, func(w io.Writer) recordEncoder[W] {
+		return &csvEncoder[W]{cw: csv.NewWriter(w), header: header, toRecord: toRecord}
+	})
+}
+
+// Write encodes w, flushing and rotating as configured.
+// After the first error, Write is a no-op returning that error.
+func (s *RecordSink[W]) Write(w W) error {
+	if s.err != nil {
+		return s.err
+	}
+	if err := s.enc.encode(w); err != nil {
+		s.err = err
+		return err
+	}
+	s.records++
+	s.sinceFlush++
+	s.sinceRotate++
+	if s.opts.RotateEvery > 0 && s.sinceRotate >= s.opts.RotateEvery {
+		return s.rotate()
+	}
+	if s.opts.FlushEvery > 0 && s.sinceFlush >= s.opts.FlushEvery {
+		return s.Flush()
+	}
+	return nil
+}
+
+func (s *RecordSink[W]) rotate() error {
+	if err := s.Flush(); err != nil {
+		return err
+	}
+	next, err := s.opts.Rotate(s.dst)
+	if err != nil {
+		s.err = err
+		return err
+	}
+	s.dst = next
+	s.enc = s.newEncoder(next)
+	s.sinceRotate = 0
+	return nil
+}
+
+// Flush writes any buffered records to the destination.
+func (s *RecordSink[W]) Flush() error {
+	if s.err != nil {
+		return s.err
+	}
+	if err := s.enc.flush(); err != nil {
+		s.err = err
+		return err
+	}
+	s.sinceFlush = 0
+	return nil
+}
+
+// Records returns the number of records written.
+func (s *RecordSink[W]) Records() int { return s.records }
+
+// Err returns the first error encountered while encoding, flushing, or rotating.
+func (s *RecordSink[W]) Err() error { return s.err }
+
+// jsonLinesEncoder encodes records as JSON lines.
+type jsonLinesEncoder[W any] struct {
+	bw  *bufio.Writer
+	enc *json.Encoder
+}
+
+func (e *jsonLinesEncoder[W]) encode(w W) error { return e.enc.Encode(w) }
+func (e *jsonLinesEncoder[W]) flush() error     { return e.bw.Flush() }
+
+// csvEncoder encodes records as CSV rows, writing the header lazily.
+type csvEncoder[W any] struct {
+	cw       *csv.Writer
+	header   []string
+	toRecord func(W) []string
+	started  bool
+}
+
+func (e *csvEncoder[W]) encode(w W) error {
+	if !e.started && e.header != nil {
+		if err := e.cw.Write(e.header); err != nil {
+			return err
+		}
+	}
+	e.started = true
+	return e.cw.Write(e.toRecord(w))
+}
+
+func (e *csvEncoder[W]) flush() error {
+	e.cw.Flush()
+	return e.cw.Error()
+}
+
+// sinkWriterHandler implements Handler for record-sink writer handling.
+type sinkWriterHandler[W, R any] struct {
+	sink *RecordSink[W]
+}
+
+// Dispatch implements Handler for record-sink writer handling.
+func (h *sinkWriterHandler[W, R]) Dispatch(op Operation) (Resumed, bool) {
+	if v, shouldResume, ok := dispatchWriterTo(op, h.write); ok {
+		return v, shouldResume
+	}
+	unhandledEffect("SinkWriterHandler", op)
+	return nil, false
+}
+
+func (h *sinkWriterHandler[W, R]) write(w W) { h.sink.Write(w) }
+
+// SinkWriterHandler creates a Writer handler that encodes output to sink.
+// The computation is not told about sink errors: after the first one,
+// further output is dropped and the error is reported by sink.Err.
+// Returns a concrete handler.
+func SinkWriterHandler[W, R any](sink *RecordSink[W]) *sinkWriterHandler[W, R] {
+	return &sinkWriterHandler[W, R]{sink: sink}
+}
+
+// RunWriterSink runs a writer computation with output encoded to sink and
+// flushes the sink on completion. Errors are reported by sink.Err: after
+// the first one the computation still runs to completion, but its output
+// in the sink is incomplete.
+func RunWriterSink[W, A any](sink *RecordSink[W], m Cont[Resumed, A]) A {
+	result := Handle(m, SinkWriterHandler[W, A](sink))
+	sink.Flush()
+	return result
+}
+
+// RunWriterSinkExpr runs an Expr writer computation with output encoded to
+// sink and flushes the sink on completion. Errors are reported as by
+// [RunWriterSink].
+func RunWriterSinkExpr[W, A any](sink *RecordSink[W], m Expr[A]) A {
+	result := HandleExpr(m, SinkWriterHandler[W, A](sink))
+	sink.Flush()
+	return result
+}
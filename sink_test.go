@@ -0,0 +1,138 @@
+// ©Hayabusa Cloud Co., Ltd. 2026. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package kont_test
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strconv"
+	"testing"
+
+	"code.hybscloud.com/kont"
+)
+
+type metric struct {
+	Name  string `json:"name"`
+	Value int    `json:"value"`
+}
+
+func metricRecord(m metric) []string { return []string{m.Name, strconv.Itoa(m.Value)} }
+
+func tellMetrics(n int) kont.Eff[int] {
+	comp := kont.Pure(n)
+	for i := n - 1; i >= 0; i-- {
+		comp = kont.TellWriter(metric{Name: "m" + strconv.Itoa(i), Value: i}, comp)
+	}
+	return comp
+}
+
+func TestJSONLinesSink(t *testing.T) {
+	var buf bytes.Buffer
+	sink := kont.NewJSONLinesSink[metric](&buf, kont.SinkOptions{})
+	if got := kont.RunWriterSink(sink, tellMetrics(2)); got != 2 {
+		t.Fatalf("got %d, want 2", got)
+	}
+	want := "{\"name\":\"m0\",\"value\":0}\n{\"name\":\"m1\",\"value\":1}\n"
+	if buf.String() != want || sink.Records() != 2 || sink.Err() != nil {
+		t.Fatalf("got %q (records %d, err %v), want %q", buf.String(), sink.Records(), sink.Err(), want)
+	}
+}
+
+func TestCSVSinkHeader(t *testing.T) {
+	var buf bytes.Buffer
+	sink := kont.NewCSVSink(&buf, []string{"name", "value"}, metricRecord, kont.SinkOptions{})
+	kont.RunWriterSink(sink, tellMetrics(2))
+	if want := "name,value\nm0,0\nm1,1\n"; buf.String() != want {
+		t.Fatalf("got %q, want %q", buf.String(), want)
+	}
+}
+
+func TestSinkFlushEvery(t *testing.T) {
+	var buf bytes.Buffer
+	sink := kont.NewCSVSink(&buf, nil, metricRecord, kont.SinkOptions{FlushEvery: 2})
+	sink.Write(metric{"a", 1})
+	if buf.Len() != 0 {
+		t.Fatalf("flushed before FlushEvery: %q", buf.String())
+	}
+	sink.Write(metric{"b", 2})
+	if want := "a,1\nb,2\n"; buf.String() != want {
+		t.Fatalf("got %q, want %q", buf.String(), want)
+	}
+}
+
+func TestSinkRotation(t *testing.T) {
+	files := []*bytes.Buffer{{}}
+	sink := kont.NewCSVSink(files[0], []string{"name", "value"}, metricRecord, kont.SinkOptions{
+		RotateEvery: 2,
+		Rotate: func(old io.Writer) (io.Writer, error) {
+			if old != files[len(files)-1] {
+				t.Fatal("Rotate must receive the current destination")
+			}
+			files = append(files, &bytes.Buffer{})
+			return files[len(files)-1], nil
+		},
+	})
+	kont.RunWriterSink(sink, tellMetrics(5))
+	want := []string{"name,value\nm0,0\nm1,1\n", "name,value\nm2,2\nm3,3\n", "name,value\nm4,4\n"}
+	if len(files) != len(want) {
+		t.Fatalf("got %d files, want %d", len(files), len(want))
+	}
+	for i, f := range files {
+		if f.String() != want[i] {
+			t.Fatalf("file %d: got %q, want %q", i, f.String(), want[i])
+		}
+	}
+}
+
+func TestSinkRotateError(t *testing.T) {
+	rotErr := errors.New("disk full")
+	sink := kont.NewJSONLinesSink[int](io.Discard, kont.SinkOptions{
+		RotateEvery: 1,
+		Rotate:      func(io.Writer) (io.Writer, error) { return nil, rotErr },
+	})
+	if err := sink.Write(1); err != rotErr {
+		t.Fatalf("got %v, want %v", err, rotErr)
+	}
+	if err := sink.Write(2); err != rotErr || sink.Records() != 1 {
+		t.Fatalf("Write after failure: err %v, records %d", err, sink.Records())
+	}
+}
+
+func TestSinkRequiresRotate(t *testing.T) {
+	for name, newSink := range map[string]func(kont.SinkOptions){
+		"JSONLines": func(o kont.SinkOptions) { kont.NewJSONLinesSink[int](io.Discard, o) },
+		"CSV":       func(o kont.SinkOptions) { kont.NewCSVSink[int](io.Discard, nil, nil, o) },
+	} {
+		t.Run(name, func(t *testing.T) {
+			defer func() {
+				if r := recover(); r != "kont: RecordSink requires Rotate when RotateEvery is set" {
+					t.Fatalf("got panic %v", r)
+				}
+			}()
+			newSink(kont.SinkOptions{RotateEvery: 1})
+		})
+	}
+}
+
+func TestSinkEncodeError(t *testing.T) {
+	sink := kont.NewJSONLinesSink[func()](io.Discard, kont.SinkOptions{})
+	if err := sink.Write(func() {}); err == nil || sink.Err() != err {
+		t.Fatalf("got %v, want sticky encode error", err)
+	}
+}
+
+func TestRunWriterSinkExprListen(t *testing.T) {
+	var buf bytes.Buffer
+	sink := kont.NewJSONLinesSink[int](&buf, kont.SinkOptions{})
+	comp := kont.ExprMap(kont.ExprPerform(kont.Listen[int, int]{Body: kont.TellWriter(7, kont.Pure(1))}),
+		func(p kont.Pair[int, []int]) int { return len(p.Snd) })
+	if got := kont.RunWriterSinkExpr(sink, comp); got != 1 {
+		t.Fatalf("got %d, want 1", got)
+	}
+	if buf.String() != "7\n" {
+		t.Fatalf("got %q, want %q", buf.String(), "7\n")
+	}
+}
@@ -0,0 +1,185 @@
+// ©Hayabusa Cloud Co., Ltd. 2026. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package kont
+
+import (
+	"bufio"
+	"encoding/gob"
+	"io"
+	"iter"
+	"os"
+)
+
+// SpillWriter is bounded-memory Writer output.
+// The most recent entries stay in memory; older entries are gob-encoded to a
+// temporary file, so W must be gob-encodable (interface values registered
+// with gob.Register). A SpillWriter is not safe for concurrent use.
+type SpillWriter[W any] struct {
+	limit   int
+	dir     string
+	ring    []W
+	head    int
+	file    *os.File
+	buf     *bufio.Writer
+	enc     *gob.Encoder
+	spilled int
+	err     error
+}
+
+// NewSpillWriter creates a SpillWriter keeping up to limit entries in memory.
+// Spilled entries go to a file created in dir (os.TempDir when empty) on first
+// spill. Call Close to remove the file.
+func NewSpillWriter[W any](limit int, dir string) *SpillWriter[W] {
+	return &SpillWriter[W]{limit: max(limit, 0), dir: dir}
+}
+
+// Add appends w, spilling the oldest in-memory entry when the limit is reached.
+// After the first error, Add is a no-op returning that error.
+func (s *SpillWriter[W]) Add(w W) error {
+	if s.err != nil {
+		return s.err
+	}
+	if len(s.ring) < s.limit {
+		s.ring = append(s.ring, w)
+		return nil
+	}
+	if s.limit == 0 {
+		return s.spill(w)
+	}
+	if err := s.spill(s.ring[s.head]); err != nil {
+		return err
+	}
+	s.ring[s.head] = w
+	s.head = (s.head + 1) % s.limit
+	return nil
+}
+
+func (s *SpillWriter[W]) spill(w W) error {
+	if s.file == nil {
+		f, err := os.CreateTemp(s.dir, "kont-writer-*")
+		if err != nil {
+			s.err = err
+			return err
+		}
+		s.file = f
+		s.buf = bufio.NewWriter(f)
+		s.enc = gob.NewEncoder(s.buf)
+	}
+	if err := s.enc.Encode(&w); err != nil {
+		s.err = err
+		return err
+	}
+	s.spilled++
+	return nil
+}
+
+// Len returns the total number of entries, spilled and in memory.
+func (s *SpillWriter[W]) Len() int { return s.spilled + len(s.ring) }
+
+// Spilled returns the number of entries written to the spill file.
+func (s *SpillWriter[W]) Spilled() int { return s.spilled }
+
+// Recent returns a copy of the in-memory entries, oldest first.
+func (s *SpillWriter[W]) Recent() []W {
+	out := make([]W, 0, len(s.ring))
+	out = append(out, s.ring[s.head:]...)
+	return append(out, s.ring[:s.head]...)
+}
+
+// Err returns the first error encountered while spilling.
+func (s *SpillWriter[W]) Err() error { return s.err }
+
+// All returns an iterator over every entry in output order: spilled entries
+// are decoded from the spill file, followed by the in-memory entries.
+// A decoding error is yielded once with the zero value, ending the iteration.
+func (s *SpillWriter[W]) All() iter.Seq2[W, error] {
+	return func(yield func(W, error) bool) {
+		var zero W
+		if s.err != nil {
+			yield(zero, s.err)
+			return
+		}
+		if s.spilled > 0 {
+			if err := s.buf.Flush(); err != nil {
+				yield(zero, err)
+				return
+			}
+			f, err := os.Open(s.file.Name())
+			if err != nil {
+				yield(zero, err)
+				return
+			}
+			defer f.Close()
+			dec := gob.NewDecoder(bufio.NewReader(f))
+			for range s.spilled {
+				var w W
+				if err := dec.Decode(&w); err != nil {
+					if err == io.EOF {
+						err = io.ErrUnexpectedEOF
+					}
+					yield(zero, err)
+					return
+				}
+				if !yield(w, nil) {
+					return
+				}
+			}
+		}
+		for _, w := range s.Recent() {
+			if !yield(w, nil) {
+				return
+			}
+		}
+	}
+}
+
+// Close removes the spill file. The SpillWriter must not be used afterwards.
+func (s *SpillWriter[W]) Close() error {
+	if s.file == nil {
+		return nil
+	}
+	name := s.file.Name()
+	err := s.file.Close()
+	if rmErr := os.Remove(name); err == nil {
+		err = rmErr
+	}
+	s.file = nil
+	return err
+}
+
+// spillWriterHandler implements Handler for bounded-memory writer handling.
+type spillWriterHandler[W, R any] struct {
+	out *SpillWriter[W]
+}
+
+// Dispatch implements Handler for bounded-memory writer handling.
+// Tell is added directly; Listen and Censor run against a temporary
+// in-memory buffer whose final contents are then added.
+func (h *spillWriterHandler[W, R]) Dispatch(op Operation) (Resumed, bool) {
+	if v, shouldResume, ok := dispatchWriterTo(op, h.add); ok {
+		return v, shouldResume
+	}
+	unhandledEffect("SpillWriterHandler", op)
+	return nil, false
+}
+
+func (h *spillWriterHandler[W, R]) add(w W) { h.out.Add(w) }
+
+// SpillWriterHandler creates a Writer handler that appends output to out.
+// Returns a concrete handler.
+func SpillWriterHandler[W, R any](out *SpillWriter[W]) *spillWriterHandler[W, R] {
+	return &spillWriterHandler[W, R]{out: out}
+}
+
+// RunWriterSpill runs a writer computation with output appended to out.
+// Spill errors are reported by out.Err.
+func RunWriterSpill[W, A any](out *SpillWriter[W], m Cont[Resumed, A]) A {
+	return Handle(m, SpillWriterHandler[W, A](out))
+}
+
+// RunWriterSpillExpr runs an Expr writer computation with output appended to out.
+func RunWriterSpillExpr[W, A any](out *SpillWriter[W], m Expr[A]) A {
+	return HandleExpr(m, SpillWriterHandler[W, A](out))
+}
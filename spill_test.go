@@ -0,0 +1,159 @@
+// ©Hayabusa Cloud Co., Ltd. 2026. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package kont_test
+
+import (
+	"os"
+	"slices"
+	"testing"
+
+	"code.hybscloud.com/kont"
+)
+
+func tellRange(n int) kont.Eff[int] {
+	comp := kont.Pure(n)
+	for i := n - 1; i >= 0; i-- {
+		comp = kont.TellWriter(i, comp)
+	}
+	return comp
+}
+
+func collectSpill[W any](t *testing.T, s *kont.SpillWriter[W]) []W {
+	t.Helper()
+	var out []W
+	for w, err := range s.All() {
+		if err != nil {
+			t.Fatal(err)
+		}
+		out = append(out, w)
+	}
+	return out
+}
+
+func TestSpillWriterSpillsOldest(t *testing.T) {
+	s := kont.NewSpillWriter[int](3, t.TempDir())
+	defer s.Close()
+	if got := kont.RunWriterSpill(s, tellRange(10)); got != 10 {
+		t.Fatalf("got %d, want 10", got)
+	}
+	if s.Len() != 10 || s.Spilled() != 7 {
+		t.Fatalf("len %d spilled %d, want 10, 7", s.Len(), s.Spilled())
+	}
+	if !slices.Equal(s.Recent(), []int{7, 8, 9}) {
+		t.Fatalf("got recent %v", s.Recent())
+	}
+	if got := collectSpill(t, s); !slices.Equal(got, []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}) {
+		t.Fatalf("got all %v", got)
+	}
+}
+
+func TestSpillWriterInMemoryOnly(t *testing.T) {
+	s := kont.NewSpillWriter[string](8, t.TempDir())
+	kont.RunWriterSpill(s, kont.TellWriter("a", kont.TellWriter("b", kont.Pure(0))))
+	if s.Spilled() != 0 || !slices.Equal(collectSpill(t, s), []string{"a", "b"}) {
+		t.Fatalf("spilled %d, all %v", s.Spilled(), collectSpill(t, s))
+	}
+	if err := s.Close(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestSpillWriterZeroLimit(t *testing.T) {
+	s := kont.NewSpillWriter[int](0, t.TempDir())
+	defer s.Close()
+	kont.RunWriterSpill(s, tellRange(3))
+	if s.Spilled() != 3 || len(s.Recent()) != 0 {
+		t.Fatalf("spilled %d, recent %v", s.Spilled(), s.Recent())
+	}
+	if got := collectSpill(t, s); !slices.Equal(got, []int{0, 1, 2}) {
+		t.Fatalf("got %v", got)
+	}
+}
+
+func TestSpillWriterCloseRemovesFile(t *testing.T) {
+	dir := t.TempDir()
+	s := kont.NewSpillWriter[int](1, dir)
+	kont.RunWriterSpill(s, tellRange(4))
+	if entries, _ := os.ReadDir(dir); len(entries) != 1 {
+		t.Fatalf("got %d files, want 1 spill file", len(entries))
+	}
+	if err := s.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if entries, _ := os.ReadDir(dir); len(entries) != 0 {
+		t.Fatalf("spill file not removed: %v", entries)
+	}
+}
+
+func TestSpillWriterEarlyBreak(t *testing.T) {
+	s := kont.NewSpillWriter[int](2, t.TempDir())
+	defer s.Close()
+	kont.RunWriterSpill(s, tellRange(6))
+	var got []int
+	for w := range s.All() {
+		got = append(got, w)
+		if len(got) == 3 {
+			break
+		}
+	}
+	if !slices.Equal(got, []int{0, 1, 2}) {
+		t.Fatalf("got %v", got)
+	}
+}
+
+func TestSpillWriterCreateError(t *testing.T) {
+	s := kont.NewSpillWriter[int](0, "/nonexistent/kont-spill-dir")
+	if err := s.Add(1); err == nil {
+		t.Fatal("expected create error")
+	}
+	if err := s.Add(2); err != s.Err() {
+		t.Fatal("Add after failure must return the first error")
+	}
+	for _, err := range s.All() {
+		if err == nil {
+			t.Fatal("All must report the spill error")
+		}
+	}
+}
+
+func TestSpillWriterListen(t *testing.T) {
+	s := kont.NewSpillWriter[string](1, t.TempDir())
+	defer s.Close()
+	comp := kont.TellWriter("before", kont.Bind(
+		kont.ListenWriter[string](kont.TellWriter("inner", kont.Pure(1))),
+		func(p kont.Pair[int, []string]) kont.Eff[int] {
+			return kont.Pure(len(p.Snd))
+		},
+	))
+	if got := kont.RunWriterSpill(s, comp); got != 1 {
+		t.Fatalf("got %d, want 1 listened entry", got)
+	}
+	if got := collectSpill(t, s); !slices.Equal(got, []string{"before", "inner"}) {
+		t.Fatalf("got %v", got)
+	}
+}
+
+func TestRunWriterSpillExpr(t *testing.T) {
+	s := kont.NewSpillWriter[int](1, t.TempDir())
+	defer s.Close()
+	comp := kont.ExprThen(kont.ExprPerform(kont.Tell[int]{Value: 1}),
+		kont.ExprThen(kont.ExprPerform(kont.Tell[int]{Value: 2}), kont.ExprReturn("ok")))
+	if got := kont.RunWriterSpillExpr(s, comp); got != "ok" {
+		t.Fatalf("got %q", got)
+	}
+	if got := collectSpill(t, s); !slices.Equal(got, []int{1, 2}) {
+		t.Fatalf("got %v", got)
+	}
+}
+
+func TestSpillWriterHandlerUnhandledPanic(t *testing.T) {
+	defer func() {
+		if r := recover(); unhandledIn(r) != "SpillWriterHandler" {
+			t.Fatalf("got panic %v", r)
+		}
+	}()
+	s := kont.NewSpillWriter[int](1, t.TempDir())
+	kont.RunWriterSpill(s, kont.Perform(kont.Get[int]{}))
+}
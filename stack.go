@@ -0,0 +1,147 @@
+// ©Hayabusa Cloud Co., Ltd. 2026. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package kont
+
+// Handler stacks.
+// A HandlerStack composes any set of standard effect layers into one flat
+// handler, so combinations without a hand-written composed runner need no
+// nesting. Go methods cannot take type parameters, so layers are added by
+// the Stack* functions, each returning a typed slot that holds the layer's
+// result after Run:
+//
+//	st := kont.NewStack[int]()
+//	count := kont.StackState(st, 0)
+//	kont.StackReader(st, cfg)
+//	logs := kont.StackWriter[string](st)
+//	errs := kont.StackError[string](st)
+//	result := st.Run(m)
+//	// count.Get(), logs.Output(), errs.Err()
+
+// stackLayer dispatches the operations of one effect family.
+// handled reports whether the layer recognized op.
+type stackLayer func(op Operation) (v Resumed, resume, handled bool)
+
+// HandlerStack is a handler built from effect layers, tried in the order
+// they were added. The first layer recognizing an operation handles it.
+type HandlerStack[A any] struct {
+	layers []stackLayer
+}
+
+// NewStack creates an empty HandlerStack for computations returning A.
+func NewStack[A any]() *HandlerStack[A] {
+	return &HandlerStack[A]{}
+}
+
+// Dispatch implements Handler by trying each layer in order.
+func (st *HandlerStack[A]) Dispatch(op Operation) (Resumed, bool) {
+	for _, layer := range st.layers {
+		if v, resume, handled := layer(op); handled {
+			return v, resume
+		}
+	}
+	unhandledEffect("HandlerStack", op)
+	return nil, false
+}
+
+// Run runs m with every layer of the stack. When an Error layer ends the
+// computation, Run returns the zero A and the error is in the layer's slot.
+func (st *HandlerStack[A]) Run(m Cont[Resumed, A]) A {
+	return Handle(m, st)
+}
+
+// RunExpr is the Expr counterpart of [HandlerStack.Run].
+func (st *HandlerStack[A]) RunExpr(m Expr[A]) A {
+	return HandleExpr(m, st)
+}
+
+// StateSlot holds the state of a State layer.
+type StateSlot[S any] struct{ state S }
+
+// Get returns the current state.
+func (s *StateSlot[S]) Get() S { return s.state }
+
+// StackState adds a State[S] layer starting at initial.
+func StackState[S, A any](st *HandlerStack[A], initial S) *StateSlot[S] {
+	slot := &StateSlot[S]{state: initial}
+	st.layers = append(st.layers, func(op Operation) (Resumed, bool, bool) {
+		if sop, ok := op.(interface {
+			DispatchState(state *S) (Resumed, bool)
+		}); ok {
+			v, resume := sop.DispatchState(&slot.state)
+			return v, resume, true
+		}
+		return nil, false, false
+	})
+	return slot
+}
+
+// StackReader adds a Reader[E] layer over env.
+func StackReader[E, A any](st *HandlerStack[A], env E) {
+	st.layers = append(st.layers, func(op Operation) (Resumed, bool, bool) {
+		if rop, ok := op.(interface {
+			DispatchReader(env *E) (Resumed, bool)
+		}); ok {
+			v, resume := rop.DispatchReader(&env)
+			return v, resume, true
+		}
+		return nil, false, false
+	})
+}
+
+// WriterSlot holds the output of a Writer layer.
+type WriterSlot[W any] struct{ output []W }
+
+// Output returns the accumulated output.
+func (s *WriterSlot[W]) Output() []W { return s.output }
+
+// StackWriter adds a Writer[W] layer.
+func StackWriter[W, A any](st *HandlerStack[A]) *WriterSlot[W] {
+	slot := &WriterSlot[W]{}
+	ctx := &WriterContext[W]{Output: &slot.output}
+	st.layers = append(st.layers, func(op Operation) (Resumed, bool, bool) {
+		if wop, ok := op.(interface {
+			DispatchWriter(ctx *WriterContext[W]) (Resumed, bool)
+		}); ok {
+			v, resume := wop.DispatchWriter(ctx)
+			return v, resume, true
+		}
+		return nil, false, false
+	})
+	return slot
+}
+
+// ErrorSlot holds the outcome of an Error layer.
+type ErrorSlot[E any] struct{ ctx ErrorContext[E] }
+
+// Err returns the error that ended the computation and true, or zero and
+// false if it completed.
+func (s *ErrorSlot[E]) Err() (E, bool) { return s.ctx.Err, s.ctx.HasErr }
+
+// StackError adds an Error[E] layer. An uncaught Throw ends the computation;
+// CatchAll bodies run with the whole stack.
+func StackError[E, A any](st *HandlerStack[A]) *ErrorSlot[E] {
+	slot := &ErrorSlot[E]{}
+	ctx := &slot.ctx
+	st.layers = append(st.layers, func(op Operation) (Resumed, bool, bool) {
+		var v Resumed
+		if dop, ok := op.(interface {
+			DispatchErrorDeep(ctx *ErrorContext[E], outer func(Operation) (Resumed, bool)) (Resumed, bool)
+		}); ok {
+			v, _ = dop.DispatchErrorDeep(ctx, st.Dispatch)
+		} else if eop, ok := op.(interface {
+			DispatchError(ctx *ErrorContext[E]) (Resumed, bool)
+		}); ok {
+			v, _ = eop.DispatchError(ctx)
+		} else {
+			return nil, false, false
+		}
+		if ctx.HasErr {
+			var zero A
+			return zero, false, true
+		}
+		return v, true, true
+	})
+	return slot
+}
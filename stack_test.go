@@ -0,0 +1,94 @@
+// ©Hayabusa Cloud Co., Ltd. 2026. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package kont_test
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"code.hybscloud.com/kont"
+)
+
+// process uses Reader, Writer, Error and State together.
+func process(item string) kont.Eff[int] {
+	return kont.AskReader(func(limit int) kont.Eff[int] {
+		if len(item) > limit {
+			return kont.ThrowError[string, int]("too long: " + item)
+		}
+		return kont.TellWriter("ok "+item, kont.ModifyState(func(n int) int { return n + len(item) },
+			func(n int) kont.Eff[int] { return kont.Pure(n) }))
+	})
+}
+
+func TestHandlerStackFourEffects(t *testing.T) {
+	st := kont.NewStack[int]()
+	total := kont.StackState(st, 0)
+	kont.StackReader(st, 5)
+	logs := kont.StackWriter[string](st)
+	errs := kont.StackError[string](st)
+	got := st.Run(kont.Then(process("ab"), process("cde")))
+	if got != 5 || total.Get() != 5 {
+		t.Fatalf("got %d state %d", got, total.Get())
+	}
+	if !slices.Equal(logs.Output(), []string{"ok ab", "ok cde"}) {
+		t.Fatalf("logs %v", logs.Output())
+	}
+	if _, failed := errs.Err(); failed {
+		t.Fatal("unexpected error")
+	}
+}
+
+func TestHandlerStackErrorShortCircuits(t *testing.T) {
+	st := kont.NewStack[int]()
+	total := kont.StackState(st, 0)
+	kont.StackReader(st, 3)
+	logs := kont.StackWriter[string](st)
+	errs := kont.StackError[string](st)
+	got := st.Run(kont.Then(process("ab"), kont.Then(process("toolong"), process("c"))))
+	e, failed := errs.Err()
+	if got != 0 || !failed || !strings.HasPrefix(e, "too long") {
+		t.Fatalf("got %d err %q %v", got, e, failed)
+	}
+	if total.Get() != 2 || len(logs.Output()) != 1 {
+		t.Fatalf("state %d logs %v", total.Get(), logs.Output())
+	}
+}
+
+func TestHandlerStackCatchAllUsesWholeStack(t *testing.T) {
+	st := kont.NewStack[int]()
+	total := kont.StackState(st, 0)
+	kont.StackReader(st, 2)
+	kont.StackWriter[string](st)
+	errs := kont.StackError[string](st)
+	comp := kont.CatchAllError(process("xyz"), func(string) kont.Eff[int] { return process("xy") })
+	if got := st.Run(comp); got != 2 || total.Get() != 2 {
+		t.Fatalf("got %d state %d", got, total.Get())
+	}
+	if _, failed := errs.Err(); failed {
+		t.Fatal("caught error reported")
+	}
+}
+
+func TestHandlerStackRunExpr(t *testing.T) {
+	st := kont.NewStack[string]()
+	kont.StackReader(st, "env")
+	cells := kont.StackState(st, 1)
+	comp := kont.ExprBind(kont.ExprPerform(kont.Ask[string]{}), func(env string) kont.Expr[string] {
+		return kont.ExprThen(kont.ExprPerform(kont.Put[int]{Value: 9}), kont.ExprReturn(env))
+	})
+	if got := st.RunExpr(comp); got != "env" || cells.Get() != 9 {
+		t.Fatalf("got %q state %d", got, cells.Get())
+	}
+}
+
+func TestHandlerStackUnhandledPanics(t *testing.T) {
+	defer func() {
+		if r := recover(); unhandledIn(r) != "HandlerStack" {
+			t.Fatalf("recovered %v", r)
+		}
+	}()
+	kont.NewStack[int]().Run(kont.Perform(kont.Get[int]{}))
+}
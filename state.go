@@ -4,6 +4,8 @@
 
 package kont
 
+import "sync"
+
 // State effect operations.
 // State[S] provides mutable state threading through computations.
 
@@ -18,6 +20,12 @@ func (Get[S]) DispatchState(state *S) (Resumed, bool) {
 	return *state, true
 }
 
+func (Get[S]) readsOnly() bool { return true }
+
+// stateReader is implemented by State operations that may never change the
+// state, so handlers recording changes can skip them.
+type stateReader interface{ readsOnly() bool }
+
 // Put is the effect operation for writing state.
 // Perform(Put[S]{Value: s}) replaces the current state.
 type Put[S any] struct{ Value S }
@@ -42,15 +50,43 @@ func (o Modify[S]) DispatchState(state *S) (Resumed, bool) {
 	return *state, true
 }
 
+// Gets is the effect operation for reading a projection of state.
+// Perform(Gets[S, A]{F: f}) returns f(state). The projection runs at dispatch
+// time, so only the projected value crosses the Resumed boundary.
+//
+// Gets[S, A] for all A dispatches through the structural DispatchState assertion.
+type Gets[S, A any] struct{ F func(S) A }
+
+func (Gets[S, A]) OpResult() A { panic("phantom") }
+
+// DispatchState handles Gets in State handler dispatch.
+func (o Gets[S, A]) DispatchState(state *S) (Resumed, bool) {
+	return o.F(*state), true
+}
+
+func (Gets[S, A]) readsOnly() bool { return true }
+
+// GetsState performs Gets: projects the state with f at dispatch time.
+func GetsState[S, A any](f func(S) A) Cont[Resumed, A] {
+	return Perform(Gets[S, A]{F: f})
+}
+
+// ExprGetsState performs Gets in an Expr computation.
+func ExprGetsState[S, A any](f func(S) A) Expr[A] {
+	return ExprPerform(Gets[S, A]{F: f})
+}
+
 // GetState fuses Get + Bind: performs Get, passes state to f.
 func GetState[S, B any](f func(S) Cont[Resumed, B]) Cont[Resumed, B] {
 	resume := bindMarkerResume[S, B]
+	at := captureProvenance()
 	return func(k func(B) Resumed) Resumed {
 		m := acquireMarker()
 		m.op = Get[S]{}
 		m.f = f
 		m.k = k
 		m.resume = resume
+		m.at = at
 		return m
 	}
 }
@@ -58,12 +94,14 @@ func GetState[S, B any](f func(S) Cont[Resumed, B]) Cont[Resumed, B] {
 // PutState fuses Put + Then: performs Put, then runs next.
 func PutState[S, B any](s S, next Cont[Resumed, B]) Cont[Resumed, B] {
 	resume := thenMarkerResume[B]
+	at := captureProvenance()
 	return func(k func(B) Resumed) Resumed {
 		m := acquireMarker()
 		m.op = Put[S]{Value: s}
 		m.f = next
 		m.k = k
 		m.resume = resume
+		m.at = at
 		return m
 	}
 }
@@ -71,16 +109,151 @@ func PutState[S, B any](s S, next Cont[Resumed, B]) Cont[Resumed, B] {
 // ModifyState fuses Modify + Bind: performs Modify, passes new state to f.
 func ModifyState[S, B any](f func(S) S, then func(S) Cont[Resumed, B]) Cont[Resumed, B] {
 	resume := bindMarkerResume[S, B]
+	at := captureProvenance()
 	return func(k func(B) Resumed) Resumed {
 		m := acquireMarker()
 		m.op = Modify[S]{F: f}
 		m.f = then
 		m.k = k
 		m.resume = resume
+		m.at = at
 		return m
 	}
 }
 
+// WithState runs body with a fresh State[S] cell holding initial and returns
+// body's result paired with the final sub-state. State[S] operations inside
+// body are answered by the local cell and never reach the enclosing handler,
+// so the scope works inside RunState and every other runner; operations of
+// other types pass through. Effects performed after body completes see the
+// enclosing state.
+func WithState[S, A any](initial S, body Cont[Resumed, A]) Cont[Resumed, Pair[A, S]] {
+	return func(k func(Pair[A, S]) Resumed) Resumed {
+		cell := initial
+		return stateScopeResult(body(scopeDoneCont[A]), &cell, k)
+	}
+}
+
+// ExprWithState is the Expr counterpart of [WithState].
+func ExprWithState[S, A any](initial S, m Expr[A]) Expr[Pair[A, S]] {
+	return Reify(WithState(initial, Reflect(m)))
+}
+
+// stateScopeSuspension wraps a suspension raised inside WithState for an
+// operation the local cell does not answer.
+type stateScopeSuspension[S, A any] struct {
+	inner effectSuspension
+	cell  *S
+	k     func(Pair[A, S]) Resumed
+}
+
+func (s *stateScopeSuspension[S, A]) Op() Operation { return s.inner.Op() }
+func (s *stateScopeSuspension[S, A]) Resume(v Resumed) Resumed {
+	return stateScopeResult(s.inner.Resume(v), s.cell, s.k)
+}
+func (s *stateScopeSuspension[S, A]) resumeWithError(throw Operation) Resumed {
+	return stateScopeResult(s.inner.resumeWithError(throw), s.cell, s.k)
+}
+func (s *stateScopeSuspension[S, A]) release()         { s.inner.release() }
+func (s *stateScopeSuspension[S, A]) site() provenance { return s.inner.site() }
+
+func stateScopeResult[S, A any](r Resumed, cell *S, k func(Pair[A, S]) Resumed) Resumed {
+	for {
+		switch rr := r.(type) {
+		case scopeDone[A]:
+			return k(Pair[A, S]{Fst: rr.value, Snd: *cell})
+		case effectSuspension:
+			if sop, ok := rr.Op().(interface {
+				DispatchState(state *S) (Resumed, bool)
+			}); ok {
+				v, _ := sop.DispatchState(cell)
+				r = rr.Resume(v)
+				continue
+			}
+			return &stateScopeSuspension[S, A]{inner: rr, cell: cell, k: k}
+		}
+		return r
+	}
+}
+
+// ZoomState runs body, written against State[Inner], inside a State[Outer]
+// handler: each State[Inner] operation in body acts on get(outer) and
+// stores the result back with set. Operations of other types pass through,
+// and effects performed after body completes are not zoomed.
+func ZoomState[Outer, Inner, A any](get func(Outer) Inner, set func(Outer, Inner) Outer, body Cont[Resumed, A]) Cont[Resumed, A] {
+	lens := &stateLens[Outer, Inner]{get: get, set: set}
+	return func(k func(A) Resumed) Resumed {
+		return zoomResult(body(scopeDoneCont[A]), lens, k)
+	}
+}
+
+// ExprZoomState is the Expr counterpart of [ZoomState].
+func ExprZoomState[Outer, Inner, A any](get func(Outer) Inner, set func(Outer, Inner) Outer, m Expr[A]) Expr[A] {
+	return Reify(ZoomState(get, set, Reflect(m)))
+}
+
+// stateLens focuses a State[Outer] cell on an Inner part.
+type stateLens[Outer, Inner any] struct {
+	get func(Outer) Inner
+	set func(Outer, Inner) Outer
+}
+
+// zoomedState is a State[Inner] operation lifted to State[Outer].
+type zoomedState[Outer, Inner any] struct {
+	op interface {
+		DispatchState(state *Inner) (Resumed, bool)
+	}
+	lens *stateLens[Outer, Inner]
+}
+
+// DispatchState runs the inner operation on the focused part of state.
+func (o zoomedState[Outer, Inner]) DispatchState(state *Outer) (Resumed, bool) {
+	inner := o.lens.get(*state)
+	v, ok := o.op.DispatchState(&inner)
+	*state = o.lens.set(*state, inner)
+	return v, ok
+}
+
+func (o zoomedState[Outer, Inner]) readsOnly() bool {
+	r, ok := o.op.(stateReader)
+	return ok && r.readsOnly()
+}
+
+// zoomSuspension wraps a suspension raised inside ZoomState, presenting the
+// lifted operation.
+type zoomSuspension[Outer, Inner, A any] struct {
+	inner effectSuspension
+	op    Operation
+	lens  *stateLens[Outer, Inner]
+	k     func(A) Resumed
+}
+
+func (s *zoomSuspension[Outer, Inner, A]) Op() Operation { return s.op }
+func (s *zoomSuspension[Outer, Inner, A]) Resume(v Resumed) Resumed {
+	return zoomResult(s.inner.Resume(v), s.lens, s.k)
+}
+func (s *zoomSuspension[Outer, Inner, A]) resumeWithError(throw Operation) Resumed {
+	return zoomResult(s.inner.resumeWithError(throw), s.lens, s.k)
+}
+func (s *zoomSuspension[Outer, Inner, A]) release()         { s.inner.release() }
+func (s *zoomSuspension[Outer, Inner, A]) site() provenance { return s.inner.site() }
+
+func zoomResult[Outer, Inner, A any](r Resumed, lens *stateLens[Outer, Inner], k func(A) Resumed) Resumed {
+	switch r := r.(type) {
+	case scopeDone[A]:
+		return k(r.value)
+	case effectSuspension:
+		op := r.Op()
+		if sop, ok := op.(interface {
+			DispatchState(state *Inner) (Resumed, bool)
+		}); ok {
+			op = zoomedState[Outer, Inner]{op: sop, lens: lens}
+		}
+		return &zoomSuspension[Outer, Inner, A]{inner: r, op: op, lens: lens, k: k}
+	}
+	return r
+}
+
 // stateHandler implements Handler for zero-allocation state handling.
 type stateHandler[S, R any] struct {
 	state *S
@@ -99,6 +272,34 @@ func StateHandler[S, R any](initial S) (*stateHandler[S, R], func() S) {
 	return h, func() S { return state }
 }
 
+// concurrentStateHandler implements Handler for State effects shared across goroutines.
+type concurrentStateHandler[S, R any] struct {
+	mu    sync.Mutex
+	state S
+}
+
+// Dispatch implements Handler, holding the lock for the whole operation so
+// Modify and Gets functions see and produce a consistent state.
+func (h *concurrentStateHandler[S, R]) Dispatch(op Operation) (Resumed, bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return dispatchState(op, &h.state)
+}
+
+// ConcurrentStateHandler creates a State handler whose cell may be shared by
+// computations stepped on different goroutines. Each operation runs under a
+// mutex; Modify and Gets functions run under it and must not dispatch to the
+// same handler.
+// Returns a concrete handler and a function to retrieve the current state.
+func ConcurrentStateHandler[S, R any](initial S) (*concurrentStateHandler[S, R], func() S) {
+	h := &concurrentStateHandler[S, R]{state: initial}
+	return h, func() S {
+		h.mu.Lock()
+		defer h.mu.Unlock()
+		return h.state
+	}
+}
+
 func dispatchState[S any](op Operation, state *S) (Resumed, bool) {
 	switch o := op.(type) {
 	case Get[S]:
@@ -114,7 +315,7 @@ func dispatchState[S any](op Operation, state *S) (Resumed, bool) {
 			return sop.DispatchState(state)
 		}
 	}
-	unhandledEffect("StateHandler")
+	unhandledEffect("StateHandler", op)
 	return nil, false
 }
 
@@ -124,7 +325,7 @@ func RunState[S, A any](initial S, m Cont[Resumed, A]) (A, S) {
 	result := m(toResumed[A])
 	for {
 		if susp, ok := result.(effectSuspension); ok {
-			v, shouldResume := dispatchState(susp.Op(), &state)
+			v, shouldResume := dispatchStateAt(susp.Op(), &state, susp.site())
 			if !shouldResume {
 				susp.release()
 				return valueOrZero[A](v), state
@@ -159,6 +360,21 @@ func RunStateExpr[S, A any](initial S, m Expr[A]) (A, S) {
 	return result, state
 }
 
+// RunStateDiff runs a stateful computation and also returns diff(initial, final).
+// diff sees the initial value as passed in, so S should be a value type or
+// treated as immutable: a Put must replace the state, not mutate what
+// initial refers to.
+func RunStateDiff[S, D, A any](initial S, m Cont[Resumed, A], diff func(from, to S) D) (A, S, D) {
+	result, state := RunState[S, A](initial, m)
+	return result, state, diff(initial, state)
+}
+
+// RunStateDiffExpr is the Expr counterpart of [RunStateDiff].
+func RunStateDiffExpr[S, D, A any](initial S, m Expr[A], diff func(from, to S) D) (A, S, D) {
+	result, state := RunStateExpr[S, A](initial, m)
+	return result, state, diff(initial, state)
+}
+
 type stateHandlerInline[S, R any] struct {
 	state *S
 }
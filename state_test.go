@@ -5,6 +5,8 @@
 package kont_test
 
 import (
+	"fmt"
+	"sync"
 	"testing"
 
 	"code.hybscloud.com/kont"
@@ -235,3 +237,175 @@ func TestStateNilResult(t *testing.T) {
 		t.Fatalf("got state %d, want 10", finalState)
 	}
 }
+
+func TestGetsState(t *testing.T) {
+	type big struct {
+		Name  string
+		Count int
+		Pad   [64]byte
+	}
+	comp := kont.Bind(kont.GetsState(func(s big) int { return s.Count }), func(n int) kont.Eff[int] {
+		return kont.Then(kont.Perform(kont.Modify[big]{F: func(s big) big { s.Count = n + 1; return s }}),
+			kont.GetsState(func(s big) int { return s.Count }))
+	})
+	got, final := kont.RunState[big, int](big{Count: 4}, comp)
+	if got != 5 || final.Count != 5 {
+		t.Fatalf("got (%d, %d), want (5, 5)", got, final.Count)
+	}
+}
+
+func TestExprGetsState(t *testing.T) {
+	comp := kont.ExprMap(kont.ExprGetsState(func(s string) int { return len(s) }), func(n int) int { return n * 2 })
+	got, _ := kont.RunStateExpr[string, int]("abc", comp)
+	if got != 6 {
+		t.Fatalf("got %d, want 6", got)
+	}
+}
+
+func TestGetsStateHandlerAndComposed(t *testing.T) {
+	h, _ := kont.StateHandler[int, int](7)
+	if got := kont.Handle(kont.GetsState(func(s int) int { return s * 3 }), h); got != 21 {
+		t.Fatalf("StateHandler: got %d, want 21", got)
+	}
+	got, _ := kont.RunStateReader[int, string, int](2, "env", kont.GetsState(func(s int) int { return -s }))
+	if got != -2 {
+		t.Fatalf("RunStateReader: got %d, want -2", got)
+	}
+	r, _ := kont.RunStateError[int, string, int](9, kont.GetsState(func(s int) int { return s + 1 }))
+	if v, ok := r.GetRight(); !ok || v != 10 {
+		t.Fatalf("RunStateError: got %+v, want Right(10)", r)
+	}
+}
+
+func TestRunStateDiff(t *testing.T) {
+	type counters struct{ Hits, Misses int }
+	diff := func(from, to counters) counters {
+		return counters{Hits: to.Hits - from.Hits, Misses: to.Misses - from.Misses}
+	}
+	comp := kont.ModifyState(func(c counters) counters { c.Hits += 3; return c },
+		func(counters) kont.Eff[string] { return kont.Pure("ok") })
+	got, final, d := kont.RunStateDiff(counters{Hits: 10, Misses: 1}, comp, diff)
+	if got != "ok" || final != (counters{13, 1}) || d != (counters{3, 0}) {
+		t.Fatalf("got %q, %+v, %+v", got, final, d)
+	}
+
+	expr := kont.ExprThen(kont.ExprPerform(kont.Put[counters]{Value: counters{Misses: 5}}), kont.ExprReturn(1))
+	_, _, d = kont.RunStateDiffExpr(counters{Misses: 2}, expr, diff)
+	if d != (counters{Misses: 3}) {
+		t.Fatalf("got %+v", d)
+	}
+}
+
+func TestWithStateShadowsOuterState(t *testing.T) {
+	body := kont.ModifyState(func(s int) int { return s * 2 }, func(s int) kont.Eff[string] {
+		return kont.Pure("local")
+	})
+	comp := kont.PutState(1, kont.Bind(kont.WithState(21, body), func(p kont.Pair[string, int]) kont.Eff[kont.Pair[string, int]] {
+		return kont.ModifyState(func(s int) int { return s + 1 }, func(int) kont.Eff[kont.Pair[string, int]] {
+			return kont.Pure(p)
+		})
+	}))
+	p, outer := kont.RunState[int](0, comp)
+	if p.Fst != "local" || p.Snd != 42 || outer != 2 {
+		t.Fatalf("got %v outer %d", p, outer)
+	}
+}
+
+func TestWithStatePassesOtherStateThrough(t *testing.T) {
+	body := kont.GetState(func(n int) kont.Eff[int] {
+		return kont.PutState(fmt.Sprint("seen ", n), kont.Pure(n+1))
+	})
+	p, outer := kont.RunState[int](5, kont.WithState("", body))
+	if p.Fst != 6 || p.Snd != "seen 5" || outer != 5 {
+		t.Fatalf("got %v outer %d", p, outer)
+	}
+}
+
+func TestExprWithState(t *testing.T) {
+	body := kont.ExprThen(kont.ExprPerform(kont.Put[int]{Value: 7}), kont.ExprPerform(kont.Get[int]{}))
+	p, outer := kont.RunStateExpr[int](100, kont.ExprWithState(0, body))
+	if p.Fst != 7 || p.Snd != 7 || outer != 100 {
+		t.Fatalf("got %v outer %d", p, outer)
+	}
+}
+
+func TestWithStateStepExposesOnlyOuterOps(t *testing.T) {
+	body := kont.PutState(3, kont.Perform(kont.Ask[string]{}))
+	_, susp := kont.Step(kont.WithState(0, body))
+	if susp == nil {
+		t.Fatal("expected suspension")
+	}
+	if _, ok := susp.Op().(kont.Ask[string]); !ok {
+		t.Fatalf("op %T", susp.Op())
+	}
+	p, next := susp.Resume("env")
+	if next != nil || p.Fst != "env" || p.Snd != 3 {
+		t.Fatalf("got %v %v", p, next)
+	}
+}
+
+func TestConcurrentStateHandlerSharedAcrossGoroutines(t *testing.T) {
+	const workers, incs = 8, 200
+	h, state := kont.ConcurrentStateHandler[int, struct{}](0)
+	var wg sync.WaitGroup
+	for range workers {
+		wg.Go(func() {
+			for range incs {
+				_, susp := kont.Step(kont.Perform(kont.Modify[int]{F: func(n int) int { return n + 1 }}))
+				v, _ := h.Dispatch(susp.Op())
+				if _, next := susp.Resume(v); next != nil {
+					t.Error("unexpected suspension")
+					return
+				}
+			}
+		})
+	}
+	wg.Wait()
+	if got := state(); got != workers*incs {
+		t.Fatalf("state %d, want %d", got, workers*incs)
+	}
+}
+
+func TestConcurrentStateHandlerWithHandle(t *testing.T) {
+	h, state := kont.ConcurrentStateHandler[int, int](10)
+	comp := kont.GetState(func(s int) kont.Eff[int] { return kont.PutState(s*2, kont.Pure(s)) })
+	if got := kont.Handle(comp, h); got != 10 || state() != 20 {
+		t.Fatalf("got %d state %d", got, state())
+	}
+}
+
+type zoomApp struct {
+	Name    string
+	Counter int
+}
+
+// tick is a module written against State[int] only.
+func tick() kont.Eff[int] {
+	return kont.ModifyState(func(n int) int { return n + 1 }, func(n int) kont.Eff[int] { return kont.Pure(n) })
+}
+
+func counterLens() (func(zoomApp) int, func(zoomApp, int) zoomApp) {
+	return func(a zoomApp) int { return a.Counter },
+		func(a zoomApp, n int) zoomApp { a.Counter = n; return a }
+}
+
+func TestZoomState(t *testing.T) {
+	get, set := counterLens()
+	comp := kont.Then(kont.ZoomState(get, set, kont.Then(tick(), tick())),
+		kont.GetState(func(a zoomApp) kont.Eff[string] { return kont.Pure(a.Name) }))
+	name, app := kont.RunState(zoomApp{Name: "app", Counter: 40}, comp)
+	if name != "app" || app.Counter != 42 {
+		t.Fatalf("got %q %+v", name, app)
+	}
+}
+
+func TestExprZoomStateInComposedRunner(t *testing.T) {
+	get, set := counterLens()
+	body := kont.ExprBind(kont.ExprPerform(kont.Ask[string]{}), func(env string) kont.Expr[int] {
+		return kont.ExprThen(kont.ExprPerform(kont.Put[int]{Value: len(env)}), kont.ExprPerform(kont.Get[int]{}))
+	})
+	got, app := kont.RunStateReaderExpr(zoomApp{Name: "x"}, "hello", kont.ExprZoomState(get, set, body))
+	if got != 5 || app != (zoomApp{Name: "x", Counter: 5}) {
+		t.Fatalf("got %d %+v", got, app)
+	}
+}
@@ -0,0 +1,166 @@
+// ©Hayabusa Cloud Co., Ltd. 2026. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package kont
+
+import (
+	"maps"
+	"slices"
+)
+
+// Labeled state effect operations.
+// One handler holds several independent state cells of type S keyed by K,
+// so a computation juggling several counters or registers needs a single
+// runner instead of nested composed handlers. Use S = any for cells of
+// different types.
+
+// StateCells holds the labeled state cells of a handler, remembering the
+// order in which keys were first written.
+type StateCells[K comparable, S any] struct {
+	cells map[K]S
+	order []K
+}
+
+func newStateCells[K comparable, S any](initial []Pair[K, S]) *StateCells[K, S] {
+	c := &StateCells[K, S]{cells: make(map[K]S, len(initial))}
+	for _, p := range initial {
+		c.set(p.Fst, p.Snd)
+	}
+	return c
+}
+
+func stateCellsFromMap[K comparable, S any](initial map[K]S) *StateCells[K, S] {
+	c := &StateCells[K, S]{cells: maps.Clone(initial)}
+	if c.cells == nil {
+		c.cells = make(map[K]S)
+	}
+	c.order = slices.Collect(maps.Keys(initial))
+	return c
+}
+
+func (c *StateCells[K, S]) set(k K, s S) {
+	if _, ok := c.cells[k]; !ok {
+		c.order = append(c.order, k)
+	}
+	c.cells[k] = s
+}
+
+// GetAt is the effect operation for reading the cell at Key.
+// An unset cell reads as the zero S.
+type GetAt[K comparable, S any] struct{ Key K }
+
+func (GetAt[K, S]) OpResult() S { panic("phantom") }
+
+// DispatchStateAt handles GetAt in labeled State handler dispatch.
+func (o GetAt[K, S]) DispatchStateAt(c *StateCells[K, S]) (Resumed, bool) {
+	return c.cells[o.Key], true
+}
+
+// PutAt is the effect operation for writing the cell at Key.
+type PutAt[K comparable, S any] struct {
+	Key   K
+	Value S
+}
+
+func (PutAt[K, S]) OpResult() struct{} { panic("phantom") }
+
+// DispatchStateAt handles PutAt in labeled State handler dispatch.
+func (o PutAt[K, S]) DispatchStateAt(c *StateCells[K, S]) (Resumed, bool) {
+	c.set(o.Key, o.Value)
+	return struct{}{}, true
+}
+
+// ModifyAt is the effect operation for modifying the cell at Key.
+// Resumes with the new value.
+type ModifyAt[K comparable, S any] struct {
+	Key K
+	F   func(S) S
+}
+
+func (ModifyAt[K, S]) OpResult() S { panic("phantom") }
+
+// DispatchStateAt handles ModifyAt in labeled State handler dispatch.
+func (o ModifyAt[K, S]) DispatchStateAt(c *StateCells[K, S]) (Resumed, bool) {
+	s := o.F(c.cells[o.Key])
+	c.set(o.Key, s)
+	return s, true
+}
+
+// GetStateAt performs GetAt.
+func GetStateAt[K comparable, S any](key K) Cont[Resumed, S] {
+	return Perform(GetAt[K, S]{Key: key})
+}
+
+// PutStateAt performs PutAt.
+func PutStateAt[K comparable, S any](key K, s S) Cont[Resumed, struct{}] {
+	return Perform(PutAt[K, S]{Key: key, Value: s})
+}
+
+// ModifyStateAt performs ModifyAt.
+func ModifyStateAt[K comparable, S any](key K, f func(S) S) Cont[Resumed, S] {
+	return Perform(ModifyAt[K, S]{Key: key, F: f})
+}
+
+// ExprGetStateAt is the Expr counterpart of [GetStateAt].
+func ExprGetStateAt[K comparable, S any](key K) Expr[S] {
+	return ExprPerform(GetAt[K, S]{Key: key})
+}
+
+// ExprPutStateAt is the Expr counterpart of [PutStateAt].
+func ExprPutStateAt[K comparable, S any](key K, s S) Expr[struct{}] {
+	return ExprPerform(PutAt[K, S]{Key: key, Value: s})
+}
+
+// ExprModifyStateAt is the Expr counterpart of [ModifyStateAt].
+func ExprModifyStateAt[K comparable, S any](key K, f func(S) S) Expr[S] {
+	return ExprPerform(ModifyAt[K, S]{Key: key, F: f})
+}
+
+// stateMapHandler implements Handler for labeled State effects.
+type stateMapHandler[K comparable, S, R any] struct {
+	cells *StateCells[K, S]
+}
+
+// Dispatch implements Handler for labeled State effects.
+func (h *stateMapHandler[K, S, R]) Dispatch(op Operation) (Resumed, bool) {
+	if sop, ok := op.(interface {
+		DispatchStateAt(c *StateCells[K, S]) (Resumed, bool)
+	}); ok {
+		return sop.DispatchStateAt(h.cells)
+	}
+	unhandledEffect("StateMapHandler", op)
+	return nil, false
+}
+
+// Keys returns the keys of the handler's cells in the order they were
+// first written; keys from the seed come first, in seed order.
+func (h *stateMapHandler[K, S, R]) Keys() []K { return slices.Clone(h.cells.order) }
+
+// StateMapHandler creates a labeled State handler seeded with the cells in
+// initial, in order; a repeated key keeps its first position and its last
+// value. Returns a concrete handler and a function to retrieve a copy of
+// the cells.
+func StateMapHandler[K comparable, S, R any](initial []Pair[K, S]) (*stateMapHandler[K, S, R], func() map[K]S) {
+	return stateMapHandlerOf[K, S, R](newStateCells(initial))
+}
+
+func stateMapHandlerOf[K comparable, S, R any](c *StateCells[K, S]) (*stateMapHandler[K, S, R], func() map[K]S) {
+	h := &stateMapHandler[K, S, R]{cells: c}
+	return h, func() map[K]S { return maps.Clone(h.cells.cells) }
+}
+
+// RunStateMap runs a computation with labeled State effects seeded with a
+// copy of initial. Returns the result and the final cells.
+func RunStateMap[K comparable, S, A any](initial map[K]S, m Cont[Resumed, A]) (A, map[K]S) {
+	h, cells := stateMapHandlerOf[K, S, A](stateCellsFromMap(initial))
+	result := Handle(m, h)
+	return result, cells()
+}
+
+// RunStateMapExpr is the Expr counterpart of [RunStateMap].
+func RunStateMapExpr[K comparable, S, A any](initial map[K]S, m Expr[A]) (A, map[K]S) {
+	h, cells := stateMapHandlerOf[K, S, A](stateCellsFromMap(initial))
+	result := HandleExpr(m, h)
+	return result, cells()
+}
@@ -0,0 +1,56 @@
+// ©Hayabusa Cloud Co., Ltd. 2026. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package kont_test
+
+import (
+	"maps"
+	"slices"
+	"testing"
+
+	"code.hybscloud.com/kont"
+)
+
+func TestRunStateMapIndependentCells(t *testing.T) {
+	comp := kont.Then(kont.PutStateAt("hits", 1),
+		kont.Bind(kont.ModifyStateAt("misses", func(n int) int { return n + 10 }), func(m int) kont.Eff[int] {
+			return kont.Map(kont.GetStateAt[string, int]("hits"), func(h int) int { return h + m })
+		}))
+	initial := map[string]int{"misses": 5}
+	got, cells := kont.RunStateMap(initial, comp)
+	if got != 16 {
+		t.Fatalf("got %d", got)
+	}
+	if !maps.Equal(cells, map[string]int{"hits": 1, "misses": 15}) {
+		t.Fatalf("cells %v", cells)
+	}
+	if initial["misses"] != 5 || len(initial) != 1 {
+		t.Fatalf("initial modified: %v", initial)
+	}
+}
+
+func TestRunStateMapExprHeterogeneous(t *testing.T) {
+	comp := kont.ExprThen(kont.ExprPutStateAt[string, any]("name", "kont"),
+		kont.ExprThen(kont.ExprPutStateAt[string, any]("n", 3),
+			kont.ExprGetStateAt[string, any]("unset")))
+	got, cells := kont.RunStateMapExpr[string, any](nil, comp)
+	if got != nil || cells["name"] != "kont" || cells["n"] != 3 {
+		t.Fatalf("got %v cells %v", got, cells)
+	}
+}
+
+func TestStateMapHandlerKeysWriteOrder(t *testing.T) {
+	seed := []kont.Pair[int, string]{{Fst: 9, Snd: "z"}, {Fst: 4, Snd: "d"}, {Fst: 9, Snd: "zz"}}
+	h, cells := kont.StateMapHandler[int, string, struct{}](seed)
+	comp := kont.Then(kont.PutStateAt(3, "c"),
+		kont.Then(kont.PutStateAt(1, "a"),
+			kont.Then(kont.PutStateAt(3, "cc"), kont.PutStateAt(2, "b"))))
+	kont.Handle(comp, h)
+	if got := h.Keys(); !slices.Equal(got, []int{9, 4, 3, 1, 2}) {
+		t.Fatalf("Keys %v", got)
+	}
+	if cells()[3] != "cc" || cells()[9] != "zz" {
+		t.Fatalf("cells %v", cells())
+	}
+}
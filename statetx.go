@@ -0,0 +1,110 @@
+// ©Hayabusa Cloud Co., Ltd. 2026. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package kont
+
+// Transactional State + Error.
+// RunStateTx is RunStateError with rollback: an uncaught Throw restores
+// the state to its snapshot from before the computation, and Rollback
+// restores it mid-computation. RunStateError keeps the state on error.
+
+// Rollback is the effect operation for discarding every state change made
+// since the transaction began. The computation continues with the
+// restored state.
+type Rollback struct{}
+
+func (Rollback) OpResult() struct{} { panic("phantom") }
+
+// RollbackState performs Rollback.
+func RollbackState() Cont[Resumed, struct{}] {
+	return Perform(Rollback{})
+}
+
+// ExprRollbackState is the Expr counterpart of [RollbackState].
+func ExprRollbackState() Expr[struct{}] {
+	return ExprPerform(Rollback{})
+}
+
+// stateTxHandler handles State, Error and Rollback effects.
+type stateTxHandler[S, E, A any] struct {
+	state    *S
+	saved    S
+	snapshot func(S) S
+	ctx      *ErrorContext[E]
+}
+
+// Dispatch implements Handler for the transactional State+Error handler.
+// Dispatch order: State → Error → Rollback. A Throw that ends the
+// computation restores the saved state before short-circuiting.
+func (h *stateTxHandler[S, E, A]) Dispatch(op Operation) (Resumed, bool) {
+	if sop, ok := op.(interface {
+		DispatchState(state *S) (Resumed, bool)
+	}); ok {
+		return sop.DispatchState(h.state)
+	}
+	if dop, ok := op.(interface {
+		DispatchErrorDeep(ctx *ErrorContext[E], outer func(Operation) (Resumed, bool)) (Resumed, bool)
+	}); ok {
+		v, _ := dop.DispatchErrorDeep(h.ctx, h.Dispatch)
+		if h.ctx.HasErr {
+			return h.abort()
+		}
+		return v, true
+	}
+	if eop, ok := op.(interface {
+		DispatchError(ctx *ErrorContext[E]) (Resumed, bool)
+	}); ok {
+		v, _ := eop.DispatchError(h.ctx)
+		if h.ctx.HasErr {
+			return h.abort()
+		}
+		return v, true
+	}
+	if _, ok := op.(Rollback); ok {
+		*h.state = h.snapshot(h.saved)
+		return struct{}{}, true
+	}
+	unhandledEffect("StateTxHandler", op)
+	return nil, false
+}
+
+func (h *stateTxHandler[S, E, A]) abort() (Resumed, bool) {
+	*h.state = h.saved
+	return Left[E, A](h.ctx.Err), false
+}
+
+func newStateTxHandler[S, E, A any](state *S, snapshot func(S) S, ctx *ErrorContext[E]) *stateTxHandler[S, E, A] {
+	if snapshot == nil {
+		snapshot = func(s S) S { return s }
+	}
+	return &stateTxHandler[S, E, A]{state: state, saved: snapshot(*state), snapshot: snapshot, ctx: ctx}
+}
+
+// RunStateTx runs a computation with State, Error and Rollback effects.
+// Returns (Either[E, A], S); on Left the state is the snapshot taken before
+// the computation started. snapshot copies a state so later mutations
+// cannot reach the copy, such as a deep copy for maps and slices modified
+// in place; nil copies by assignment.
+func RunStateTx[S, E, A any](initial S, snapshot func(S) S, m Cont[Resumed, A]) (Either[E, A], S) {
+	state := initial
+	var ctx ErrorContext[E]
+	h := newStateTxHandler[S, E, A](&state, snapshot, &ctx)
+	result := m(rightCont[E, A])
+	if result == nil {
+		var zero A
+		return Right[E, A](zero), state
+	}
+	either := handleDispatch[*stateTxHandler[S, E, A], Either[E, A]](result, h)
+	return either, state
+}
+
+// RunStateTxExpr is the Expr counterpart of [RunStateTx].
+func RunStateTxExpr[S, E, A any](initial S, snapshot func(S) S, m Expr[A]) (Either[E, A], S) {
+	wrapped := ExprMap(m, func(a A) Either[E, A] { return Right[E, A](a) })
+	state := initial
+	var ctx ErrorContext[E]
+	h := newStateTxHandler[S, E, A](&state, snapshot, &ctx)
+	result := HandleExpr(wrapped, h)
+	return result, state
+}
@@ -0,0 +1,58 @@
+// ©Hayabusa Cloud Co., Ltd. 2026. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package kont_test
+
+import (
+	"maps"
+	"testing"
+
+	"code.hybscloud.com/kont"
+)
+
+func TestRunStateTxRollsBackOnThrow(t *testing.T) {
+	comp := kont.PutState(99, kont.ThrowError[string, int]("boom"))
+	result, state := kont.RunStateTx[int, string](1, nil, comp)
+	if e, ok := result.GetLeft(); !ok || e != "boom" || state != 1 {
+		t.Fatalf("got %v state %d", result, state)
+	}
+	// RunStateError keeps the state on error.
+	if _, kept := kont.RunStateError[int, string](1, comp); kept != 99 {
+		t.Fatalf("RunStateError state %d", kept)
+	}
+}
+
+func TestRunStateTxCommitsOnSuccess(t *testing.T) {
+	// A caught Throw does not end the transaction, so nothing is rolled back.
+	comp := kont.PutState(5, kont.CatchAllError(kont.PutState(6, kont.ThrowError[string, int]("x")),
+		func(string) kont.Eff[int] { return kont.Pure(0) }))
+	result, state := kont.RunStateTx[int, string](1, nil, comp)
+	if !result.IsRight() || state != 6 {
+		t.Fatalf("got %v state %d", result, state)
+	}
+}
+
+func TestRunStateTxSnapshotDeepCopy(t *testing.T) {
+	comp := kont.GetState(func(m map[string]int) kont.Eff[int] {
+		m["a"] = 100
+		return kont.ThrowError[string, int]("fail")
+	})
+	initial := map[string]int{"a": 1}
+	_, state := kont.RunStateTx[map[string]int, string](initial, maps.Clone, comp)
+	if state["a"] != 1 {
+		t.Fatalf("state %v", state)
+	}
+}
+
+func TestRunStateTxExprRollback(t *testing.T) {
+	comp := kont.ExprThen(kont.ExprPerform(kont.Put[int]{Value: 7}),
+		kont.ExprThen(kont.ExprRollbackState(),
+			kont.ExprBind(kont.ExprPerform(kont.Get[int]{}), func(s int) kont.Expr[int] {
+				return kont.ExprThen(kont.ExprPerform(kont.Put[int]{Value: s + 1}), kont.ExprReturn(s))
+			})))
+	result, state := kont.RunStateTxExpr[int, string](3, nil, comp)
+	if v, ok := result.GetRight(); !ok || v != 3 || state != 4 {
+		t.Fatalf("got %v state %d", result, state)
+	}
+}
@@ -4,7 +4,10 @@
 
 package kont
 
-import "sync/atomic"
+import (
+	"sync/atomic"
+	"time"
+)
 
 // Stepping boundary for external runtimes.
 // Step/StepExpr provide shallow one-effect-at-a-time evaluation,
@@ -18,16 +21,97 @@ import "sync/atomic"
 // After Resume or Discard, the original suspension is consumed; use only the
 // suspension returned by Resume, if any.
 type Suspension[A any] struct {
-	used atomic.Uintptr
-	op   Operation
-	cont effectSuspension     // Cont path: resume via classifyResumed(cont.Resume(v))
-	ef   *EffectFrame[Erased] // Expr path: resume via evalFrames[stepProcessor](ef.Resume(v), rest)
-	rest Frame                // Expr path: remaining frames after ef
+	used   atomic.Uintptr
+	op     Operation
+	cont   effectSuspension     // Cont path: resume via classifyResumed(cont.Resume(v))
+	ef     *EffectFrame[Erased] // Expr path: resume via evalFrames[stepProcessor](ef.Resume(v), rest)
+	rest   Frame                // Expr path: remaining frames after ef
+	opt    *suspensionOpt[A]    // opt-in state, carried to the next suspension; nil unless used
+	leak   leakRecord           // live-suspension tracking for CheckLeaks (kontdebug builds)
+	shared bool                 // Expr path: frames may be shared with clones; never released
+}
+
+// suspensionOpt holds the opt-in state of a suspension, allocated only once
+// it is used so that plain stepping neither allocates it nor reads the clock.
+type suspensionOpt[A any] struct {
+	created time.Time // when the suspension was created, if timed
+	timed   bool      // Age is measured
+	done    func(A)   // completion callbacks
+	metrics Metrics   // lifetime reporting
+}
+
+func (s *Suspension[A]) options() *suspensionOpt[A] {
+	if s.opt == nil {
+		s.opt = new(suspensionOpt[A])
+	}
+	return s.opt
+}
+
+// LeakedSuspension describes a suspension reported by [CheckLeaks].
+type LeakedSuspension struct {
+	// Op is the operation the suspension is pending on.
+	Op Operation
+	// Age is the time since the suspension was created.
+	Age time.Duration
+	// Stack is the call stack that created the suspension.
+	Stack string
 }
 
 // Op returns the effect operation that caused the suspension.
 func (s *Suspension[A]) Op() Operation { return s.op }
 
+// Site returns the file:line where the suspended operation was performed.
+// Returns "" unless built with the kontdebug tag, or once the suspension
+// has been resumed or discarded.
+func (s *Suspension[A]) Site() string {
+	if s.cont != nil {
+		return s.cont.site().String()
+	}
+	if s.ef != nil {
+		return s.ef.at.String()
+	}
+	return ""
+}
+
+// Age returns the time elapsed since the suspension was created.
+// Drivers that queue suspensions before dispatching them can compare Age
+// at dispatch against handler time to separate queueing latency.
+// Age is measured only for suspensions passed to TrackAge or Meter and
+// those they lead to, or for every suspension with the kontdebug build
+// tag; otherwise it is 0, so that stepping does not read the clock.
+func (s *Suspension[A]) Age() time.Duration {
+	if o := s.opt; o != nil && o.timed {
+		return time.Since(o.created)
+	}
+	return s.leak.age()
+}
+
+// TrackAge starts measuring [Suspension.Age] for s and every suspension it
+// leads to.
+func (s *Suspension[A]) TrackAge() {
+	if o := s.options(); !o.timed {
+		o.timed = true
+		o.created = time.Now()
+	}
+}
+
+// OnComplete registers f to be called with the final value when the
+// computation completes through Resume or TryResume on this suspension or any
+// suspension it leads to. Callbacks run in registration order on the
+// goroutine that performs the completing resume. They are not called if a
+// suspension in the chain is discarded.
+func (s *Suspension[A]) OnComplete(f func(A)) {
+	o := s.options()
+	if prev := o.done; prev != nil {
+		o.done = func(a A) {
+			prev(a)
+			f(a)
+		}
+		return
+	}
+	o.done = f
+}
+
 // Resume advances the computation with the given value.
 // Returns either a completed value (with nil suspension) or the next suspension.
 // Panics if the suspension has already been resumed or discarded.
@@ -35,22 +119,59 @@ func (s *Suspension[A]) Resume(v Resumed) (A, *Suspension[A]) {
 	if s.used.Add(1) != 1 {
 		panic("kont: suspension resumed twice")
 	}
+	s.ended()
 	if s.cont != nil {
 		cont := s.cont
 		s.cont = nil
-		return classifyResumed[A](cont.Resume(v))
+		return s.complete(classifyResumed[A](cont.Resume(v)))
 	}
+	return s.complete(s.resumeExpr(v))
+}
+
+// resumeExpr resumes the Expr path, leaving frames in place when they may
+// be shared with clones.
+func (s *Suspension[A]) resumeExpr(v Resumed) (A, *Suspension[A]) {
 	ef := s.ef
 	rest := s.rest
 	s.ef = nil
 	s.rest = nil
-	resumed := ef.Resume(v)
+	return s.evalExpr(ef, rest, ef.Resume(v))
+}
+
+// evalExpr evaluates rest from the value ef resumed with.
+func (s *Suspension[A]) evalExpr(ef *EffectFrame[Erased], rest Frame, resumed Erased) (A, *Suspension[A]) {
+	if s.shared {
+		return classifyStepResult[A](
+			evalFrames[sharedStepProcessor[A], Erased](resumed, rest, sharedStepProcessor[A]{}),
+		)
+	}
 	releaseEffectFrame(ef)
 	return classifyStepResult[A](
 		evalFrames[stepProcessor[A], Erased](resumed, rest, stepProcessor[A]{}),
 	)
 }
 
+// complete hands the opt-in state on to next, or runs the completion
+// callbacks with a when the computation has completed.
+func (s *Suspension[A]) complete(a A, next *Suspension[A]) (A, *Suspension[A]) {
+	o := s.opt
+	if o == nil {
+		return a, next
+	}
+	s.opt = nil
+	if next != nil {
+		if o.timed {
+			o.created = time.Now()
+		}
+		next.opt = o
+		return a, next
+	}
+	if o.done != nil {
+		o.done(a)
+	}
+	return a, nil
+}
+
 // TryResume attempts to advance the computation.
 // Returns (value, suspension, true) on success, or (zero, nil, false) if already used.
 func (s *Suspension[A]) TryResume(v Resumed) (A, *Suspension[A], bool) {
@@ -58,27 +179,60 @@ func (s *Suspension[A]) TryResume(v Resumed) (A, *Suspension[A], bool) {
 		var zero A
 		return zero, nil, false
 	}
+	s.ended()
 	if s.cont != nil {
 		cont := s.cont
 		s.cont = nil
-		a, next := classifyResumed[A](cont.Resume(v))
+		a, next := s.complete(classifyResumed[A](cont.Resume(v)))
 		return a, next, true
 	}
-	ef := s.ef
-	rest := s.rest
-	s.ef = nil
-	s.rest = nil
-	resumed := ef.Resume(v)
-	releaseEffectFrame(ef)
-	a, next := classifyStepResult[A](
-		evalFrames[stepProcessor[A], Erased](resumed, rest, stepProcessor[A]{}),
-	)
+	a, next := s.complete(s.resumeExpr(v))
 	return a, next, true
 }
 
+// Clone returns an independent copy of a pending suspension, so the same
+// continuation can be resumed with several values, as search and
+// backtracking drivers need. The clone carries the same operation and
+// completion callbacks. Each copy is still affine: resume or discard it once.
+//
+// Suspensions from StepExpr can always be cloned; frames after the
+// operation are then shared and are no longer returned to their pools, and
+// their closures run once per resumed copy. Suspensions from Step can be
+// cloned only when suspended on [Amb]. Panics if the suspension has been
+// consumed or cannot be cloned.
+func (s *Suspension[A]) Clone() *Suspension[A] {
+	if s.used.Load() != 0 {
+		panic("kont: clone of consumed suspension")
+	}
+	c := &Suspension[A]{op: s.op, leak: trackSuspension(s.op)}
+	if s.opt != nil {
+		o := *s.opt
+		c.opt = &o
+	}
+	if s.cont != nil {
+		if _, ok := s.cont.(interface{ multiShot() }); !ok {
+			panic("kont: suspension cannot be cloned")
+		}
+		c.cont = s.cont
+		return c
+	}
+	s.rest = detachFrames(s.rest)
+	s.shared = true
+	c.ef, c.rest, c.shared = s.ef, s.rest, true
+	return c
+}
+
 // Discard marks the suspension as consumed without resuming.
 func (s *Suspension[A]) Discard() {
-	s.used.Store(1)
+	if s.used.Swap(1) == 0 {
+		s.ended()
+	}
+	s.opt = nil
+	if s.shared {
+		s.ef = nil
+		s.rest = nil
+		return
+	}
 	if s.cont != nil {
 		s.cont.release()
 		s.cont = nil
@@ -115,6 +269,7 @@ func classifyResumed[A any](result Resumed) (A, *Suspension[A]) {
 		return zero, &Suspension[A]{
 			op:   s.Op(),
 			cont: s,
+			leak: trackSuspension(s.Op()),
 		}
 	}
 	if result == nil {
@@ -142,6 +297,7 @@ func (p stepProcessor[A]) processEffect(f *EffectFrame[Erased], rest Frame) (Era
 		op:   f.Operation,
 		ef:   f,
 		rest: rest,
+		leak: trackSuspension(f.Operation),
 	}, false
 }
 
@@ -149,6 +305,28 @@ func (stepProcessor[A]) processReturn(current Erased) Erased {
 	return current
 }
 
+func (stepProcessor[A]) ownsFrames() bool { return true }
+
+// sharedStepProcessor yields like stepProcessor but leaves frames in place,
+// for resuming cloned suspensions whose frames other clones still hold.
+type sharedStepProcessor[A any] struct{}
+
+func (p sharedStepProcessor[A]) processEffect(f *EffectFrame[Erased], rest Frame) (Erased, Frame, Erased, bool) {
+	return nil, nil, &Suspension[A]{
+		op:     f.Operation,
+		ef:     f,
+		rest:   rest,
+		leak:   trackSuspension(f.Operation),
+		shared: true,
+	}, false
+}
+
+func (sharedStepProcessor[A]) processReturn(current Erased) Erased {
+	return current
+}
+
+func (sharedStepProcessor[A]) ownsFrames() bool { return false }
+
 // classifyStepResult unpacks the Erased result from evalFrames[stepProcessor]:
 // *Suspension[A] → suspended; otherwise → completed value.
 func classifyStepResult[A any](result Erased) (A, *Suspension[A]) {
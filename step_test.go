@@ -5,7 +5,9 @@
 package kont_test
 
 import (
+	"slices"
 	"testing"
+	"time"
 
 	"code.hybscloud.com/kont"
 )
@@ -680,3 +682,112 @@ func TestStepExprEquivalence(t *testing.T) {
 		t.Fatalf("got %d, want 42", contResult)
 	}
 }
+
+func TestSuspensionAge(t *testing.T) {
+	_, susp := kont.Step(kont.Perform(kont.Get[int]{}))
+	_, esusp := kont.StepExpr(kont.ExprPerform(kont.Get[int]{}))
+	susp.TrackAge()
+	esusp.TrackAge()
+	time.Sleep(2 * time.Millisecond)
+	if susp.Age() < 2*time.Millisecond || esusp.Age() < 2*time.Millisecond {
+		t.Fatalf("ages %v, %v; want at least 2ms", susp.Age(), esusp.Age())
+	}
+	susp.Discard()
+	esusp.Discard()
+}
+
+func TestSuspensionTrackAgeCarried(t *testing.T) {
+	comp := kont.Then(kont.Perform(kont.Get[int]{}), kont.Perform(kont.Get[int]{}))
+	_, susp := kont.Step(comp)
+	susp.TrackAge()
+	_, next := susp.Resume(0)
+	time.Sleep(2 * time.Millisecond)
+	if next.Age() < 2*time.Millisecond {
+		t.Fatalf("age %v; want at least 2ms", next.Age())
+	}
+	next.Discard()
+}
+
+func TestSuspensionOnComplete(t *testing.T) {
+	comp := kont.GetState(func(s int) kont.Eff[int] {
+		return kont.PutState(s+1, kont.Pure(s*10))
+	})
+	var got []int
+	_, susp := kont.Step(comp)
+	susp.OnComplete(func(v int) { got = append(got, v) })
+	susp.OnComplete(func(v int) { got = append(got, v+1) })
+
+	_, susp = susp.Resume(4)
+	if len(got) != 0 {
+		t.Fatalf("callback ran before completion: %v", got)
+	}
+	v, next, ok := susp.TryResume(struct{}{})
+	if !ok || next != nil || v != 40 {
+		t.Fatalf("got %d, %v, %v", v, next, ok)
+	}
+	if len(got) != 2 || got[0] != 40 || got[1] != 41 {
+		t.Fatalf("callbacks got %v, want [40 41]", got)
+	}
+}
+
+func TestSuspensionOnCompleteExprDiscard(t *testing.T) {
+	called := false
+	_, susp := kont.StepExpr(kont.ExprThen(kont.ExprPerform(kont.Get[int]{}), kont.ExprPerform(kont.Get[int]{})))
+	susp.OnComplete(func(int) { called = true })
+	_, susp = susp.Resume(1)
+	susp.Discard()
+	if called {
+		t.Fatal("callback ran after Discard")
+	}
+}
+
+func TestSuspensionCloneExpr(t *testing.T) {
+	comp := kont.ExprBind(kont.ExprPerform(kont.Get[int]{}), func(x int) kont.Expr[int] {
+		return kont.ExprMap(kont.ExprPerform(kont.Ask[int]{}), func(y int) int { return x*10 + y })
+	})
+	_, susp := kont.StepExpr(comp)
+	clones := []*kont.Suspension[int]{susp, susp.Clone(), susp.Clone()}
+	var got []int
+	for i, c := range clones {
+		_, next := c.Resume(i + 1)
+		if _, ok := next.Op().(kont.Ask[int]); !ok {
+			t.Fatalf("clone %d: got op %T", i, next.Op())
+		}
+		retry := next.Clone()
+		v, done := next.Resume(5)
+		if done != nil {
+			t.Fatal("expected completion")
+		}
+		w, _ := retry.Resume(6)
+		got = append(got, v, w)
+	}
+	if want := []int{15, 16, 25, 26, 35, 36}; !slices.Equal(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+}
+
+func TestSuspensionCloneAmb(t *testing.T) {
+	_, susp := kont.Step(kont.Map(kont.Amb(1, 2), func(x int) int { return -x }))
+	c := susp.Clone()
+	a, _ := susp.Resume(1)
+	b, _ := c.Resume(2)
+	if a != -1 || b != -2 {
+		t.Fatalf("got %d, %d", a, b)
+	}
+}
+
+func TestSuspensionClonePanics(t *testing.T) {
+	expectPanic := func(name, want string, f func()) {
+		t.Helper()
+		defer func() {
+			if r := recover(); r != want {
+				t.Fatalf("%s: got panic %v, want %q", name, r, want)
+			}
+		}()
+		f()
+	}
+	_, susp := kont.Step(kont.Perform(kont.Get[int]{}))
+	expectPanic("one-shot Cont", "kont: suspension cannot be cloned", func() { susp.Clone() })
+	susp.Discard()
+	expectPanic("consumed", "kont: clone of consumed suspension", func() { susp.Clone() })
+}
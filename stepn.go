@@ -0,0 +1,108 @@
+// ©Hayabusa Cloud Co., Ltd. 2026. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package kont
+
+// Batch stepping.
+// Event loops usually care about a few operations, such as I/O, and want
+// the rest (State, Writer, logging) answered in line. StepN, RunUntil, and
+// StepWith dispatch those operations with a handler and yield only at a
+// budget, a selected operation, or one the handler declines.
+
+// StepN is [Step] dispatching up to n operations with h before yielding the
+// next pending suspension. A negative n dispatches without limit. If h
+// short-circuits, the computation ends with its value.
+func StepN[H Handler[H, A], A any](m Cont[Resumed, A], h H, n int) (A, *Suspension[A]) {
+	a, s := Step(m)
+	return stepBatch(a, s, h, n, nil)
+}
+
+// StepNExpr is the Expr counterpart of [StepN].
+func StepNExpr[H Handler[H, A], A any](m Expr[A], h H, n int) (A, *Suspension[A]) {
+	a, s := StepExpr(m)
+	return stepBatch(a, s, h, n, nil)
+}
+
+// ResumeN resumes s with v and continues like [StepN].
+func ResumeN[H Handler[H, A], A any](s *Suspension[A], v Resumed, h H, n int) (A, *Suspension[A]) {
+	a, next := s.Resume(v)
+	return stepBatch(a, next, h, n, nil)
+}
+
+// RunUntil is [Step] dispatching operations with h until one satisfies
+// pred, which is returned undispatched as the pending suspension. If h
+// short-circuits, the computation ends with its value.
+func RunUntil[H Handler[H, A], A any](m Cont[Resumed, A], h H, pred func(Operation) bool) (A, *Suspension[A]) {
+	a, s := Step(m)
+	return stepBatch(a, s, h, -1, pred)
+}
+
+// RunUntilExpr is the Expr counterpart of [RunUntil].
+func RunUntilExpr[H Handler[H, A], A any](m Expr[A], h H, pred func(Operation) bool) (A, *Suspension[A]) {
+	a, s := StepExpr(m)
+	return stepBatch(a, s, h, -1, pred)
+}
+
+// ResumeUntil resumes s with v and continues like [RunUntil].
+func ResumeUntil[H Handler[H, A], A any](s *Suspension[A], v Resumed, h H, pred func(Operation) bool) (A, *Suspension[A]) {
+	a, next := s.Resume(v)
+	return stepBatch(a, next, h, -1, pred)
+}
+
+// StepWith is [Step] with a partial handler: operations h recognizes are
+// dispatched in line, and the first one it declines, by panicking as
+// unhandled like the standard handlers, is returned as the pending
+// suspension. If h short-circuits, the computation ends with its value.
+// h should not change state before declining.
+func StepWith[H Handler[H, A], A any](m Cont[Resumed, A], h H) (A, *Suspension[A]) {
+	a, s := Step(m)
+	return stepPartial(a, s, h)
+}
+
+// StepWithExpr is the Expr counterpart of [StepWith].
+func StepWithExpr[H Handler[H, A], A any](m Expr[A], h H) (A, *Suspension[A]) {
+	a, s := StepExpr(m)
+	return stepPartial(a, s, h)
+}
+
+// ResumeWith resumes s with v and continues like [StepWith].
+func ResumeWith[H Handler[H, A], A any](s *Suspension[A], v Resumed, h H) (A, *Suspension[A]) {
+	a, next := s.Resume(v)
+	return stepPartial(a, next, h)
+}
+
+// stepBatch dispatches up to n pending operations with h, stopping early at
+// one satisfying stop.
+func stepBatch[H Handler[H, A], A any](a A, s *Suspension[A], h H, n int, stop func(Operation) bool) (A, *Suspension[A]) {
+	for ; s != nil && n != 0; n-- {
+		op := s.Op()
+		if stop != nil && stop(op) {
+			break
+		}
+		v, resume := h.Dispatch(op)
+		if !resume {
+			s.Discard()
+			return valueOrZero[A](v), nil
+		}
+		a, s = s.Resume(v)
+	}
+	return a, s
+}
+
+// stepPartial dispatches pending operations with h until it declines one.
+func stepPartial[H Handler[H, A], A any](a A, s *Suspension[A], h H) (A, *Suspension[A]) {
+	dispatch := DispatchFunc(h.Dispatch)
+	for s != nil {
+		v, resume, matched := tryDispatch(dispatch, s.Op())
+		if !matched {
+			return a, s
+		}
+		if !resume {
+			s.Discard()
+			return valueOrZero[A](v), nil
+		}
+		a, s = s.Resume(v)
+	}
+	return a, s
+}
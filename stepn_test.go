@@ -0,0 +1,109 @@
+// ©Hayabusa Cloud Co., Ltd. 2026. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package kont_test
+
+import (
+	"testing"
+
+	"code.hybscloud.com/kont"
+)
+
+// counterLoop increments state n times, then asks for the step size.
+func counterLoop(n int) kont.Eff[int] {
+	if n == 0 {
+		return kont.Perform(kont.Ask[int]{})
+	}
+	return kont.Bind(kont.Perform(kont.Modify[int]{F: func(s int) int { return s + 1 }}), func(int) kont.Eff[int] {
+		return counterLoop(n - 1)
+	})
+}
+
+func TestStepN(t *testing.T) {
+	st, state := kont.StateHandler[int, int](0)
+	_, s := kont.StepN(counterLoop(5), st, 3)
+	if _, ok := s.Op().(kont.Modify[int]); !ok || state() != 3 {
+		t.Fatalf("pending %T, state %d", s.Op(), state())
+	}
+	// Resuming supplies the pending Modify's result without running it.
+	_, s = kont.ResumeN(s, 3, st, 1)
+	if _, ok := s.Op().(kont.Ask[int]); !ok || state() != 4 {
+		t.Fatalf("pending %T, state %d", s.Op(), state())
+	}
+	if got, next := s.Resume(9); got != 9 || next != nil {
+		t.Fatalf("got %d", got)
+	}
+
+	_, s = kont.StepN(counterLoop(1), st, 0)
+	if _, ok := s.Op().(kont.Modify[int]); !ok || state() != 4 {
+		t.Fatalf("n = 0: pending %T, state %d", s.Op(), state())
+	}
+	s.Discard()
+}
+
+func TestRunUntil(t *testing.T) {
+	isAsk := func(op kont.Operation) bool {
+		_, ok := op.(kont.Ask[int])
+		return ok
+	}
+	st, state := kont.StateHandler[int, int](0)
+	_, s := kont.RunUntilExpr(kont.Reify(counterLoop(4)), st, isAsk)
+	if s == nil || state() != 4 {
+		t.Fatalf("state %d", state())
+	}
+	if _, ok := s.Op().(kont.Ask[int]); !ok {
+		t.Fatalf("pending %T", s.Op())
+	}
+	s.Discard()
+
+	comp := kont.Bind(kont.Perform(kont.Ask[int]{}), func(int) kont.Eff[int] { return counterLoop(2) })
+	_, s = kont.RunUntil(comp, st, isAsk)
+	got, s := kont.ResumeUntil(s, 1, st, isAsk)
+	if s == nil || got != 0 {
+		t.Fatalf("got %d", got)
+	}
+	s.Discard()
+}
+
+func TestStepNShortCircuit(t *testing.T) {
+	h := kont.HandleFunc[int](func(kont.Operation) (kont.Resumed, bool) { return -1, false })
+	got, s := kont.StepNExpr(kont.ExprPerform(kont.Get[int]{}), h, 5)
+	if got != -1 || s != nil {
+		t.Fatalf("got %d, %v", got, s)
+	}
+}
+
+func TestStepWith(t *testing.T) {
+	st, state := kont.StateHandler[int, int](0)
+	comp := kont.Bind(counterLoop(3), func(n int) kont.Eff[int] {
+		return kont.Then(kont.Perform(kont.Put[int]{Value: n}), kont.Perform(kont.Ask[int]{}))
+	})
+	_, s := kont.StepWith(comp, st)
+	if _, ok := s.Op().(kont.Ask[int]); !ok || state() != 3 {
+		t.Fatalf("pending %T, state %d", s.Op(), state())
+	}
+	got, s := kont.ResumeWith(s, 10, st)
+	if _, ok := s.Op().(kont.Ask[int]); !ok || state() != 10 {
+		t.Fatalf("pending %T, state %d", s.Op(), state())
+	}
+	if got, s = kont.ResumeWith(s, 7, st); got != 7 || s != nil {
+		t.Fatalf("got %d", got)
+	}
+}
+
+func TestStepWithExprShortCircuit(t *testing.T) {
+	h := kont.HandleFunc[int](func(op kont.Operation) (kont.Resumed, bool) {
+		if _, ok := op.(kont.Get[int]); ok {
+			return -1, false
+		}
+		panic(&kont.UnhandledError{Handler: "test", Op: op})
+	})
+	_, s := kont.StepWithExpr(kont.ExprThen(kont.ExprPerform(kont.Ask[int]{}), kont.ExprPerform(kont.Get[int]{})), h)
+	if _, ok := s.Op().(kont.Ask[int]); !ok {
+		t.Fatalf("pending %T", s.Op())
+	}
+	if got, s := kont.ResumeWith(s, 1, h); got != -1 || s != nil {
+		t.Fatalf("got %d", got)
+	}
+}
@@ -0,0 +1,42 @@
+// ©Hayabusa Cloud Co., Ltd. 2026. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package kont
+
+import "sync/atomic"
+
+// swappableHandler delegates to a handler that can be replaced at runtime.
+type swappableHandler[H Handler[H, R], R any] struct {
+	current atomic.Pointer[H]
+}
+
+// Dispatch implements Handler by loading the current handler once and
+// delegating the whole dispatch to it.
+func (h *swappableHandler[H, R]) Dispatch(op Operation) (Resumed, bool) {
+	return (*h.current.Load()).Dispatch(op)
+}
+
+// Swap installs next and returns the previously installed handler.
+// Every dispatch is served entirely by one handler: dispatches that begin
+// after Swap returns see next, a dispatch already in progress finishes on
+// the handler it started with. Safe for concurrent use.
+func (h *swappableHandler[H, R]) Swap(next H) H {
+	return *h.current.Swap(&next)
+}
+
+// Current returns the installed handler.
+func (h *swappableHandler[H, R]) Current() H {
+	return *h.current.Load()
+}
+
+// SwappableHandler wraps initial so it can be replaced between dispatches
+// without restarting the computation, e.g. to rotate credentials or switch
+// backends. Handlers carrying state (State, Writer) do not transfer it on
+// Swap; install a handler that already holds the state to carry forward.
+// Returns a concrete handler with Swap and Current methods.
+func SwappableHandler[R any, H Handler[H, R]](initial H) *swappableHandler[H, R] {
+	h := &swappableHandler[H, R]{}
+	h.current.Store(&initial)
+	return h
+}
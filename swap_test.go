@@ -0,0 +1,49 @@
+// ©Hayabusa Cloud Co., Ltd. 2026. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package kont_test
+
+import (
+	"testing"
+
+	"code.hybscloud.com/kont"
+)
+
+func constReader(v string) func(kont.Operation) (kont.Resumed, bool) {
+	return func(kont.Operation) (kont.Resumed, bool) { return v, true }
+}
+
+func TestSwappableHandlerBetweenDispatches(t *testing.T) {
+	h := kont.SwappableHandler[string](kont.HandleFunc[string](constReader("old")))
+	comp := kont.Bind(kont.Perform(kont.Ask[string]{}), func(first string) kont.Eff[string] {
+		h.Swap(kont.HandleFunc[string](constReader("new")))
+		return kont.Map(kont.Perform(kont.Ask[string]{}), func(second string) string {
+			return first + "," + second
+		})
+	})
+	if got := kont.Handle(comp, h); got != "old,new" {
+		t.Fatalf("got %q, want old,new", got)
+	}
+}
+
+func TestSwappableHandlerSwapReturnsPrevious(t *testing.T) {
+	h := kont.SwappableHandler[string](kont.HandleFunc[string](constReader("a")))
+	prev := h.Swap(kont.HandleFunc[string](constReader("b")))
+	if v, _ := prev.Dispatch(kont.Ask[string]{}); v != "a" {
+		t.Fatalf("previous handler resumed with %v, want a", v)
+	}
+	if v, _ := h.Current().Dispatch(kont.Ask[string]{}); v != "b" {
+		t.Fatalf("current handler resumed with %v, want b", v)
+	}
+}
+
+func TestSwappableHandlerStep(t *testing.T) {
+	h := kont.SwappableHandler[string](kont.HandleFunc[string](constReader("x")))
+	_, susp := kont.Step(kont.Perform(kont.Ask[string]{}))
+	h.Swap(kont.HandleFunc[string](constReader("y")))
+	v, _ := h.Dispatch(susp.Op())
+	if got, _ := susp.Resume(v); got != "y" {
+		t.Fatalf("got %q, want y", got)
+	}
+}
@@ -0,0 +1,98 @@
+// ©Hayabusa Cloud Co., Ltd. 2026. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package kont
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Per-op timeouts.
+// The timeout wrapper runs each dispatch against a timer and, when the timer
+// fires first, abandons the dispatch and resumes the computation with a
+// Throw carrying the operation, so a hung handler call shows up as a typed
+// failure instead of a silent stall.
+
+// ErrOpTimeout is the sentinel matched by errors.Is for every dispatch overrun.
+var ErrOpTimeout = errors.New("kont: operation timed out")
+
+// OpTimeoutError reports a dispatch that exceeded its deadline.
+// It matches [ErrOpTimeout] under errors.Is.
+type OpTimeoutError struct {
+	Op      Operation
+	Timeout time.Duration
+}
+
+func (e *OpTimeoutError) Error() string {
+	return fmt.Sprintf("kont: %T timed out after %v", e.Op, e.Timeout)
+}
+
+// Unwrap returns ErrOpTimeout.
+func (e *OpTimeoutError) Unwrap() error { return ErrOpTimeout }
+
+// timeoutOpsHandler wraps a handler and bounds each dispatch it serves.
+type timeoutOpsHandler[H Handler[H, R], R any] struct {
+	inner   H
+	timeout time.Duration
+	after   func(time.Duration) <-chan time.Time
+}
+
+// dispatchResult is the outcome of a dispatch run on its own goroutine.
+type dispatchResult struct {
+	v            Resumed
+	shouldResume bool
+	panicked     any
+}
+
+// Control implements ControlHandler by dispatching the pending operation
+// to the inner handler on a goroutine. If the timer fires first, the
+// dispatch is abandoned and s is resumed with Throw[error] of an
+// [*OpTimeoutError]. A panic in the inner handler is re-raised here.
+func (h *timeoutOpsHandler[H, R]) Control(s *Suspension[R]) (R, *Suspension[R]) {
+	op := s.Op()
+	done := make(chan dispatchResult, 1)
+	go func() {
+		var r dispatchResult
+		defer func() {
+			if p := recover(); p != nil {
+				r.panicked = p
+			}
+			done <- r
+		}()
+		r.v, r.shouldResume = h.inner.Dispatch(op)
+	}()
+	select {
+	case r := <-done:
+		if r.panicked != nil {
+			s.Discard()
+			panic(r.panicked)
+		}
+		if !r.shouldResume {
+			s.Discard()
+			return valueOrZero[R](r.v), nil
+		}
+		return s.Resume(r.v)
+	case <-h.after(h.timeout):
+		return s.ResumeWithError(&OpTimeoutError{Op: op, Timeout: h.timeout})
+	}
+}
+
+// TimeoutOps wraps inner so that every dispatch, run with [HandleControl]
+// or [HandleControlExpr], is bounded by timeout as measured by after
+// (time.After when nil; [VirtualClock.After] for deterministic tests).
+// Each dispatch runs on its own goroutine; one that overruns is abandoned
+// and the computation resumes by performing Throw[error] of an
+// [*OpTimeoutError] naming the operation, which scopes such as MapError
+// and the Error handling of inner see like any other Throw. Since an
+// abandoned dispatch may still be running, inner must be safe for
+// concurrent use.
+// Returns a concrete handler.
+func TimeoutOps[R any, H Handler[H, R]](inner H, timeout time.Duration, after func(time.Duration) <-chan time.Time) *timeoutOpsHandler[H, R] {
+	if after == nil {
+		after = time.After
+	}
+	return &timeoutOpsHandler[H, R]{inner: inner, timeout: timeout, after: after}
+}
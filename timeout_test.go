@@ -0,0 +1,104 @@
+// ©Hayabusa Cloud Co., Ltd. 2026. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package kont_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"code.hybscloud.com/kont"
+)
+
+// failWithLeft handles Throw[error] by short-circuiting with Left.
+func failWithLeft(op kont.Operation) (kont.Resumed, bool) {
+	if t, ok := op.(kont.Throw[error]); ok {
+		return kont.Left[error, int](t.Err), false
+	}
+	return nil, false
+}
+
+func TestTimeoutOpsWithinDeadline(t *testing.T) {
+	inner := kont.HandleFunc[int](func(kont.Operation) (kont.Resumed, bool) { return 5, true })
+	h := kont.TimeoutOps[int](inner, time.Hour, nil)
+	if got := kont.HandleControl(kont.Perform(kont.Get[int]{}), h); got != 5 {
+		t.Fatalf("got %d, want 5", got)
+	}
+}
+
+func TestTimeoutOpsHungDispatch(t *testing.T) {
+	clock := kont.NewVirtualClock(time.Unix(0, 0))
+	armed := make(chan struct{}, 1)
+	after := func(d time.Duration) <-chan time.Time {
+		ch := clock.After(d)
+		armed <- struct{}{}
+		return ch
+	}
+	hang := make(chan struct{})
+	defer close(hang)
+	inner := kont.HandleFunc[kont.Either[error, int]](func(op kont.Operation) (kont.Resumed, bool) {
+		if _, ok := op.(kont.Put[int]); ok {
+			<-hang // never returns within the deadline
+		}
+		if _, ok := op.(kont.Throw[error]); ok {
+			return failWithLeft(op)
+		}
+		return 1, true
+	})
+	h := kont.TimeoutOps[kont.Either[error, int]](inner, time.Second, after)
+	go func() {
+		<-armed // Get: completes without the clock moving
+		<-armed // Put
+		clock.Advance(time.Second)
+		<-armed // Throw
+	}()
+	comp := kont.Bind(kont.Perform(kont.Get[int]{}), func(int) kont.Eff[kont.Either[error, int]] {
+		return kont.Then(kont.Perform(kont.Put[int]{Value: 2}), kont.Pure(kont.Right[error](0)))
+	})
+	got := kont.HandleControl(comp, h)
+	err, ok := got.GetLeft()
+	if !ok || !errors.Is(err, kont.ErrOpTimeout) {
+		t.Fatalf("got %+v, want Left(ErrOpTimeout)", got)
+	}
+	var te *kont.OpTimeoutError
+	if !errors.As(err, &te) {
+		t.Fatalf("got %T, want *OpTimeoutError", err)
+	}
+	if _, isPut := te.Op.(kont.Put[int]); !isPut || te.Timeout != time.Second {
+		t.Fatalf("got %+v", te)
+	}
+	if want := "kont: kont.Put[int] timed out after 1s"; err.Error() != want {
+		t.Fatalf("got %q, want %q", err.Error(), want)
+	}
+}
+
+func TestTimeoutOpsThrowSeenByScope(t *testing.T) {
+	hang := make(chan struct{})
+	defer close(hang)
+	inner := kont.HandleFunc[string](func(op kont.Operation) (kont.Resumed, bool) {
+		if t, ok := op.(kont.Throw[string]); ok {
+			return t.Err, false
+		}
+		<-hang
+		return 0, true
+	})
+	comp := kont.MapError(kont.Map(kont.Perform(kont.Get[int]{}), func(int) string { return "done" }), func(err error) string {
+		return "mapped: " + err.Error()
+	})
+	got := kont.HandleControl(comp, kont.TimeoutOps[string](inner, time.Millisecond, nil))
+	if want := "mapped: kont: kont.Get[int] timed out after 1ms"; got != want {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+}
+
+func TestTimeoutOpsPropagatesPanic(t *testing.T) {
+	defer func() {
+		if r := recover(); unhandledIn(r) != "StateHandler" {
+			t.Fatalf("got panic %v", r)
+		}
+	}()
+	h, _ := kont.StateHandler[int, int](0)
+	kont.HandleControl(kont.Perform(kont.Ask[int]{}), kont.TimeoutOps[int](h, time.Hour, nil))
+}
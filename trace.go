@@ -0,0 +1,67 @@
+// ©Hayabusa Cloud Co., Ltd. 2026. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package kont
+
+import "fmt"
+
+// Effect tracing.
+// Trace records every dispatch of a run as a TraceEvent, so a failing
+// computation can be inspected and its effect stream compared against an
+// expected one in tests.
+
+// TraceEvent is one dispatch observed by a tracing handler.
+type TraceEvent struct {
+	// Op is the dispatched operation.
+	Op Operation
+	// Value is the resume value, or the final result when the handler
+	// short-circuited.
+	Value Resumed
+	// ShortCircuit reports that the handler ended the computation
+	// instead of resuming it.
+	ShortCircuit bool
+}
+
+// String renders the event deterministically, for diffs and golden files.
+func (e TraceEvent) String() string {
+	if e.ShortCircuit {
+		return fmt.Sprintf("%T%+v ⇥ %+v", e.Op, e.Op, e.Value)
+	}
+	return fmt.Sprintf("%T%+v → %+v", e.Op, e.Op, e.Value)
+}
+
+// tracingHandler wraps a handler and appends its dispatches to a trace.
+type tracingHandler[H Handler[H, R], R any] struct {
+	inner  H
+	events *[]TraceEvent
+}
+
+// Dispatch implements Handler by delegating to the inner handler and
+// recording the outcome. Operations the inner handler panics on are not
+// recorded.
+func (h *tracingHandler[H, R]) Dispatch(op Operation) (Resumed, bool) {
+	v, resume := h.inner.Dispatch(op)
+	*h.events = append(*h.events, TraceEvent{Op: op, Value: v, ShortCircuit: !resume})
+	return v, resume
+}
+
+// TracingHandler wraps inner so every dispatch is appended to events.
+// Returns a concrete handler.
+func TracingHandler[R any, H Handler[H, R]](inner H, events *[]TraceEvent) *tracingHandler[H, R] {
+	return &tracingHandler[H, R]{inner: inner, events: events}
+}
+
+// Trace runs m with h and returns the result with every dispatch in order.
+func Trace[R any, H Handler[H, R]](m Cont[Resumed, R], h H) (R, []TraceEvent) {
+	var events []TraceEvent
+	result := Handle(m, TracingHandler[R](h, &events))
+	return result, events
+}
+
+// TraceExpr is the Expr counterpart of [Trace].
+func TraceExpr[R any, H Handler[H, R]](m Expr[R], h H) (R, []TraceEvent) {
+	var events []TraceEvent
+	result := HandleExpr(m, TracingHandler[R](h, &events))
+	return result, events
+}
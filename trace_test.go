@@ -0,0 +1,75 @@
+// ©Hayabusa Cloud Co., Ltd. 2026. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package kont_test
+
+import (
+	"slices"
+	"testing"
+
+	"code.hybscloud.com/kont"
+)
+
+func TestTrace(t *testing.T) {
+	m := kont.GetState(func(s int) kont.Eff[int] {
+		return kont.PutState(s+1, kont.Perform(kont.Get[int]{}))
+	})
+	st, _ := kont.StateHandler[int, int](1)
+	got, events := kont.Trace(m, st)
+	if got != 2 {
+		t.Fatalf("got %d, want 2", got)
+	}
+	want := []string{
+		"kont.Get[int]{} → 1",
+		"kont.Put[int]{Value:2} → {}",
+		"kont.Get[int]{} → 2",
+	}
+	if s := traceStrings(events); !slices.Equal(s, want) {
+		t.Fatalf("got %q, want %q", s, want)
+	}
+}
+
+func TestTraceShortCircuit(t *testing.T) {
+	h := kont.HandleFunc[int](func(op kont.Operation) (kont.Resumed, bool) {
+		if _, ok := op.(kont.Ask[int]); ok {
+			return 7, true
+		}
+		return -1, false
+	})
+	m := kont.ExprBind(kont.ExprPerform(kont.Ask[int]{}), func(int) kont.Expr[int] {
+		return kont.ExprPerform(kont.Get[int]{})
+	})
+	got, events := kont.TraceExpr(m, h)
+	if got != -1 || len(events) != 2 {
+		t.Fatalf("got %d with %d events", got, len(events))
+	}
+	if events[0].ShortCircuit || events[0].Value != 7 {
+		t.Fatalf("event 0: %v", events[0])
+	}
+	if !events[1].ShortCircuit || events[1].Value != -1 {
+		t.Fatalf("event 1: %v", events[1])
+	}
+	if s := events[1].String(); s != "kont.Get[int]{} ⇥ -1" {
+		t.Fatalf("got %q", s)
+	}
+}
+
+func TestTracingHandlerAppends(t *testing.T) {
+	var events []kont.TraceEvent
+	st, _ := kont.StateHandler[int, int](3)
+	h := kont.TracingHandler[int](st, &events)
+	kont.Handle(kont.Perform(kont.Get[int]{}), h)
+	kont.Handle(kont.Perform(kont.Get[int]{}), h)
+	if len(events) != 2 || events[1].Value != 3 {
+		t.Fatalf("got %v", events)
+	}
+}
+
+func traceStrings(events []kont.TraceEvent) []string {
+	s := make([]string, len(events))
+	for i, e := range events {
+		s[i] = e.String()
+	}
+	return s
+}
@@ -21,6 +21,10 @@ func (pureEval[R]) Dispatch(Operation) (Resumed, bool) {
 type frameProcessor[P frameProcessor[P, R], R any] interface {
 	processEffect(f *EffectFrame[Erased], rest Frame) (Erased, Frame, R, bool)
 	processReturn(current Erased) R
+	// ownsFrames reports whether consumed caller frames may be released to
+	// their pools. Shared evaluation leaves caller frames untouched. It is
+	// read once per evaluation.
+	ownsFrames() bool
 }
 
 // chainPool is a global pool for chainedFrame nodes used during evaluation.
@@ -61,10 +65,15 @@ func releaseChain(cf *chainedFrame) {
 //   - handlerProcessor[H, R]: dispatches EffectFrame to handler (HandleExpr/RunPure)
 //   - stepProcessor[A]: yields Suspension at EffectFrame (StepExpr)
 //   - reflectProcessor[A]: emits genericMarker at EffectFrame (Reflect)
+//   - sharedProcessor[H, R]: dispatches like handlerProcessor, never releases caller frames (HandleExprShared)
+//
+// evalFramesHooked is the same loop reporting each frame to EvalHooks; it is
+// a separate function so that this loop carries no per-frame hook checks.
 //
 // Transient chainedFrame nodes are acquired from a sync.Pool and released
 // after their fields are extracted, avoiding per-evaluation heap allocation.
 func evalFrames[P frameProcessor[P, R], R any](current Erased, frame Frame, p P) R {
+	owns := p.ownsFrames()
 	for {
 		// Flatten chained frames
 		for {
@@ -91,7 +100,9 @@ func evalFrames[P frameProcessor[P, R], R any](current Erased, frame Frame, p P)
 				fNext := f.Next
 				rest := cf.rest
 				releaseChain(cf)
-				releaseBindFrame(f)
+				if owns {
+					releaseBindFrame(f)
+				}
 				frame = chainFromPool(chainFromPool(next.Frame, fNext), rest)
 			case *MapFrame[Erased, Erased]:
 				current = f.F(current)
@@ -105,13 +116,17 @@ func evalFrames[P frameProcessor[P, R], R any](current Erased, frame Frame, p P)
 				fNext := f.Next
 				rest := cf.rest
 				releaseChain(cf)
-				releaseThenFrame(f)
+				if owns {
+					releaseThenFrame(f)
+				}
 				frame = chainFromPool(chainFromPool(secondFrame, fNext), rest)
 			case *UnwindFrame:
 				nextCurrent, nextFrame := f.Unwind(f.Data1, f.Data2, f.Data3, current)
 				rest := cf.rest
 				releaseChain(cf)
-				releaseUnwindFrame(f)
+				if owns {
+					releaseUnwindFrame(f)
+				}
 				current = nextCurrent
 				frame = chainFromPool(nextFrame, rest)
 			case *EffectFrame[Erased]:
@@ -147,7 +162,9 @@ func evalFrames[P frameProcessor[P, R], R any](current Erased, frame Frame, p P)
 			next := f.F(current)
 			current = Erased(next.Value)
 			fNext := f.Next
-			releaseBindFrame(f)
+			if owns {
+				releaseBindFrame(f)
+			}
 			frame = chainFromPool(next.Frame, fNext)
 		case *MapFrame[Erased, Erased]:
 			current = f.F(current)
@@ -156,11 +173,15 @@ func evalFrames[P frameProcessor[P, R], R any](current Erased, frame Frame, p P)
 			current = f.Second.Value
 			secondFrame := f.Second.Frame
 			fNext := f.Next
-			releaseThenFrame(f)
+			if owns {
+				releaseThenFrame(f)
+			}
 			frame = chainFromPool(secondFrame, fNext)
 		case *UnwindFrame:
 			current, frame = f.Unwind(f.Data1, f.Data2, f.Data3, current)
-			releaseUnwindFrame(f)
+			if owns {
+				releaseUnwindFrame(f)
+			}
 		case *EffectFrame[Erased]:
 			newCurrent, newFrame, result, ok := p.processEffect(f, f.Next)
 			if !ok {
@@ -185,7 +206,7 @@ func evalFrames[P frameProcessor[P, R], R any](current Erased, frame Frame, p P)
 type handlerProcessor[H Handler[H, R], R any] struct{ h H }
 
 func (p handlerProcessor[H, R]) processEffect(f *EffectFrame[Erased], rest Frame) (Erased, Frame, R, bool) {
-	v, shouldResume := p.h.Dispatch(f.Operation)
+	v, shouldResume := dispatchAt[H, R](p.h, f.Operation, f.at)
 	if !shouldResume {
 		releaseEffectFrame(f)
 		return nil, nil, valueOrZero[R](v), false
@@ -200,6 +221,27 @@ func (p handlerProcessor[H, R]) processReturn(current Erased) R {
 	return valueOrZero[R](current)
 }
 
+func (handlerProcessor[H, R]) ownsFrames() bool { return true }
+
+// sharedProcessor dispatches like handlerProcessor but leaves every caller
+// frame, pooled or not, untouched so the Expr can be evaluated again.
+type sharedProcessor[H Handler[H, R], R any] struct{ h H }
+
+func (p sharedProcessor[H, R]) processEffect(f *EffectFrame[Erased], rest Frame) (Erased, Frame, R, bool) {
+	v, shouldResume := dispatchAt[H, R](p.h, f.Operation, f.at)
+	if !shouldResume {
+		return nil, nil, valueOrZero[R](v), false
+	}
+	var zero R
+	return f.Resume(v), rest, zero, true
+}
+
+func (p sharedProcessor[H, R]) processReturn(current Erased) R {
+	return valueOrZero[R](current)
+}
+
+func (sharedProcessor[H, R]) ownsFrames() bool { return false }
+
 // HandleExpr evaluates a defunctionalized computation with an effect handler.
 // This is the Expr counterpart of [Handle] for closure-based [Cont].
 //
@@ -211,6 +253,15 @@ func HandleExpr[H Handler[H, R], R any](m Expr[R], h H) R {
 	return evalFrames(Erased(m.Value), m.Frame, handlerProcessor[H, R]{h: h})
 }
 
+// HandleExprShared evaluates m like [HandleExpr] but never mutates or
+// releases frames owned by m, so one constructed Expr can be evaluated many
+// times, including concurrently with separate handlers. Pooled frames in m
+// are not returned to their pools. Closures captured by m's frames must be
+// safe to call repeatedly (and concurrently, if m is shared across goroutines).
+func HandleExprShared[H Handler[H, R], R any](m Expr[R], h H) R {
+	return evalFrames(Erased(m.Value), m.Frame, sharedProcessor[H, R]{h: h})
+}
+
 // ChainFrames links two frame chains together.
 // Returns the other operand when either side is ReturnFrame (the identity element
 // for frame composition), avoiding unnecessary chainedFrame allocation.
@@ -247,6 +298,23 @@ func RunPure[A any](c Expr[A]) A {
 	return evalFrames(Erased(c.Value), c.Frame, handlerProcessor[pureEval[A], A]{h: pureEval[A]{}})
 }
 
+// pureFallback adapts a RunPureOr fallback function to Handler.
+type pureFallback[R any] struct {
+	f func(Operation) (Resumed, bool)
+}
+
+func (p pureFallback[R]) Dispatch(op Operation) (Resumed, bool) {
+	return p.f(op)
+}
+
+// RunPureOr evaluates an almost-pure computation like [RunPure], but passes
+// each [EffectFrame] operation to onEffect instead of panicking. onEffect
+// returns (resumeValue, true) to continue, or (finalResult, false) to
+// short-circuit, as a handler's Dispatch does.
+func RunPureOr[A any](c Expr[A], onEffect func(Operation) (Resumed, bool)) A {
+	return evalFrames(Erased(c.Value), c.Frame, handlerProcessor[pureFallback[A], A]{h: pureFallback[A]{f: onEffect}})
+}
+
 // ExprBind creates a bind frame linking computation m to function f.
 func ExprBind[A, B any](m Expr[A], f func(A) Expr[B]) Expr[B] {
 	if _, ok := m.Frame.(ReturnFrame); ok {
@@ -254,6 +322,25 @@ func ExprBind[A, B any](m Expr[A], f func(A) Expr[B]) Expr[B] {
 		return f(m.Value)
 	}
 
+	// Fuse with a map frame ending m, so the mapped A reaches f directly
+	// instead of being boxed into the Erased register.
+	if first, prev, ok := endingMap[A](m.Frame); ok {
+		var zero B
+		return Expr[B]{
+			Value: zero,
+			Frame: ChainFrames(first, &BindFrame[Erased, Erased]{
+				F: func(x Erased) Expr[Erased] {
+					result := f(prev.apply(x))
+					return Expr[Erased]{
+						Value: Erased(result.Value),
+						Frame: result.Frame,
+					}
+				},
+				Next: ReturnFrame{},
+			}),
+		}
+	}
+
 	bindFrame := &BindFrame[Erased, Erased]{
 		F: func(a Erased) Expr[Erased] {
 			result := f(a.(A))
@@ -273,18 +360,36 @@ func ExprBind[A, B any](m Expr[A], f func(A) Expr[B]) Expr[B] {
 }
 
 // ExprMap creates a map frame transforming computation m with function f.
+// When m ends with a map frame built by ExprMap, f is composed into a new
+// frame replacing it, so the intermediate value is not boxed.
 func ExprMap[A, B any](m Expr[A], f func(A) B) Expr[B] {
 	if _, ok := m.Frame.(ReturnFrame); ok {
 		// Optimization: if m is already completed, apply f directly
 		return ExprReturn(f(m.Value))
 	}
 
+	// Fuse with a map frame ending m, so the intermediate A is passed
+	// directly instead of being boxed into the Erased register.
+	if first, prev, ok := endingMap[A](m.Frame); ok && prev.depth() < maxMapFusion {
+		fusion := mapThen[A, B]{prev: prev, g: f, n: prev.depth() + 1}
+		var zero B
+		return Expr[B]{
+			Value: zero,
+			Frame: ChainFrames(first, &MapFrame[Erased, Erased]{
+				F:    fusion.erase(func(b B) Erased { return b }),
+				Next: ReturnFrame{},
+				fuse: fusion,
+			}),
+		}
+	}
+
 	// Create a map frame
 	mapFrame := &MapFrame[Erased, Erased]{
 		F: func(a Erased) Erased {
 			return f(a.(A))
 		},
 		Next: ReturnFrame{},
+		fuse: mapStart[A, B]{f: f},
 	}
 
 	var zero B
@@ -294,6 +399,65 @@ func ExprMap[A, B any](m Expr[A], f func(A) B) Expr[B] {
 	}
 }
 
+// endingMap reports whether frame ends with a map frame built by ExprMap
+// producing A, and returns the frames before it and its typed function.
+func endingMap[A any](frame Frame) (Frame, mapFusion[A], bool) {
+	cf, ok := frame.(*chainedFrame)
+	if !ok || cf.pooled {
+		return nil, nil, false
+	}
+	mf, ok := cf.rest.(*MapFrame[Erased, Erased])
+	if !ok {
+		return nil, nil, false
+	}
+	if _, ok := mf.Next.(ReturnFrame); !ok {
+		return nil, nil, false
+	}
+	prev, ok := mf.fuse.(mapFusion[A])
+	return cf.first, prev, ok
+}
+
+// maxMapFusion bounds how many ExprMap functions one frame composes, which
+// bounds the native call depth of applying it.
+const maxMapFusion = 16
+
+// mapFusion is the typed function of a map frame built by ExprMap. erase
+// returns the frame's F with g applied to the typed result, and apply
+// returns the typed result itself.
+type mapFusion[A any] interface {
+	erase(g func(A) Erased) func(Erased) Erased
+	apply(x Erased) A
+	depth() int
+}
+
+// mapStart is a single mapped function.
+type mapStart[X, A any] struct{ f func(X) A }
+
+func (s mapStart[X, A]) erase(g func(A) Erased) func(Erased) Erased {
+	f := s.f
+	return func(x Erased) Erased { return g(f(x.(X))) }
+}
+
+func (s mapStart[X, A]) apply(x Erased) A { return s.f(x.(X)) }
+
+func (mapStart[X, A]) depth() int { return 1 }
+
+// mapThen is prev followed by g.
+type mapThen[A, B any] struct {
+	prev mapFusion[A]
+	g    func(A) B
+	n    int
+}
+
+func (s mapThen[A, B]) erase(h func(B) Erased) func(Erased) Erased {
+	g := s.g
+	return s.prev.erase(func(a A) Erased { return h(g(a)) })
+}
+
+func (s mapThen[A, B]) apply(x Erased) B { return s.g(s.prev.apply(x)) }
+
+func (s mapThen[A, B]) depth() int { return s.n }
+
 // ExprThen creates a then frame sequencing m before n (discarding m's result).
 func ExprThen[A, B any](m Expr[A], n Expr[B]) Expr[B] {
 	if _, ok := m.Frame.(ReturnFrame); ok {
@@ -6,6 +6,7 @@ package kont_test
 
 import (
 	"strconv"
+	"sync"
 	"testing"
 
 	"code.hybscloud.com/kont"
@@ -839,6 +840,30 @@ func TestExprMapNonReturnDeep(t *testing.T) {
 	}
 }
 
+func TestExprMapFusedChain(t *testing.T) {
+	// Maps over an effect fuse into one frame; beyond the fusion bound and
+	// across shared prefixes the results must be unchanged.
+	base := kont.ExprMap(kont.ExprPerform(kont.Ask[int]{}), strconv.Itoa)
+	m := kont.ExprMap(base, func(s string) int { return len(s) })
+	for range 40 {
+		m = kont.ExprMap(m, func(x int) int { return x + 1 })
+	}
+	branch := kont.ExprMap(base, func(s string) string { return s + "!" })
+
+	for range 2 {
+		if got := kont.RunReaderExpr(123, m); got != 43 {
+			t.Fatalf("chain = %d, want 43", got)
+		}
+		if got := kont.RunReaderExpr(123, branch); got != "123!" {
+			t.Fatalf("branch = %q, want 123!", got)
+		}
+	}
+	_, susp := kont.StepExpr(m)
+	if got, next := susp.Resume(5); next != nil || got != 41 {
+		t.Fatalf("stepped chain = %d, want 41", got)
+	}
+}
+
 func TestExprThenNonReturnDeep(t *testing.T) {
 	m := kont.ExprSuspend[int](&kont.BindFrame[any, any]{
 		F: func(x any) kont.Expr[any] {
@@ -905,3 +930,95 @@ func BenchmarkTrampolineMixed(b *testing.B) {
 		_ = kont.RunPure(c)
 	}
 }
+
+func TestHandleExprSharedPooledFrames(t *testing.T) {
+	ef := kont.AcquireEffectFrame()
+	ef.Operation = kont.Get[int]{}
+	ef.Resume = func(v any) any { return v }
+	bf := kont.AcquireBindFrame()
+	bf.F = func(a any) kont.Expr[any] { return kont.ExprReturn[any](a.(int) + 1) }
+	bf.Next = kont.ReturnFrame{}
+	ef.Next = bf
+	expr := kont.Expr[int]{Frame: ef}
+
+	for i := range 3 {
+		h := kont.HandleFunc[int](func(kont.Operation) (kont.Resumed, bool) { return i * 10, true })
+		if got := kont.HandleExprShared(expr, h); got != i*10+1 {
+			t.Fatalf("run %d: got %d, want %d", i, got, i*10+1)
+		}
+	}
+	if ef.Operation == nil || bf.F == nil {
+		t.Fatal("shared evaluation must not zero caller frames")
+	}
+}
+
+func TestHandleExprSharedConcurrent(t *testing.T) {
+	expr := kont.ExprBind(kont.ExprPerform(kont.Get[int]{}), func(s int) kont.Expr[int] {
+		return kont.ExprThen(kont.ExprPerform(kont.Put[int]{Value: s * 2}), kont.ExprPerform(kont.Get[int]{}))
+	})
+	var wg sync.WaitGroup
+	for i := range 8 {
+		wg.Go(func() {
+			state := i
+			h := kont.HandleFunc[int](func(op kont.Operation) (kont.Resumed, bool) {
+				switch op := op.(type) {
+				case kont.Get[int]:
+					return state, true
+				case kont.Put[int]:
+					state = op.Value
+					return struct{}{}, true
+				}
+				panic("unexpected op")
+			})
+			for range 100 {
+				state = i
+				if got := kont.HandleExprShared(expr, h); got != i*2 {
+					t.Errorf("goroutine %d: got %d, want %d", i, got, i*2)
+					return
+				}
+			}
+		})
+	}
+	wg.Wait()
+}
+
+func TestHandleExprSharedShortCircuit(t *testing.T) {
+	expr := kont.ExprThen(kont.ExprPerform(kont.Get[int]{}), kont.ExprReturn(1))
+	h := kont.HandleFunc[int](func(kont.Operation) (kont.Resumed, bool) { return -1, false })
+	for range 2 {
+		if got := kont.HandleExprShared(expr, h); got != -1 {
+			t.Fatalf("got %d, want -1", got)
+		}
+	}
+}
+
+func TestRunPureOrFallback(t *testing.T) {
+	comp := kont.ExprBind(kont.ExprReturn(2), func(x int) kont.Expr[int] {
+		return kont.ExprMap(kont.ExprPerform(kont.Ask[int]{}), func(env int) int { return x * env })
+	})
+	got := kont.RunPureOr(comp, func(op kont.Operation) (kont.Resumed, bool) {
+		if _, ok := op.(kont.Ask[int]); ok {
+			return 21, true
+		}
+		panic("unexpected effect")
+	})
+	if got != 42 {
+		t.Fatalf("got %d, want 42", got)
+	}
+}
+
+func TestRunPureOrShortCircuit(t *testing.T) {
+	comp := kont.ExprThen(kont.ExprPerform(kont.Get[int]{}), kont.ExprReturn(1))
+	if got := kont.RunPureOr(comp, func(kont.Operation) (kont.Resumed, bool) { return -1, false }); got != -1 {
+		t.Fatalf("got %d, want -1", got)
+	}
+}
+
+func TestRunPureOrPure(t *testing.T) {
+	called := false
+	got := kont.RunPureOr(kont.ExprMap(kont.ExprReturn(3), func(x int) int { return x + 1 }),
+		func(kont.Operation) (kont.Resumed, bool) { called = true; return nil, true })
+	if got != 4 || called {
+		t.Fatalf("got %d (fallback called %v), want 4 without fallback", got, called)
+	}
+}
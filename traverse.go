@@ -0,0 +1,175 @@
+// ©Hayabusa Cloud Co., Ltd. 2026. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package kont
+
+import "sync"
+
+// Sequence runs ms in order and completes with their results. Iterations
+// run in a loop rather than as nested Binds, so long slices neither deepen
+// the stack nor build a closure chain up front.
+func Sequence[A any](ms []Eff[A]) Eff[[]A] {
+	return iterate([]A(nil), func(s []A) (Eff[[]A], bool) {
+		if len(s) == len(ms) {
+			return nil, false
+		}
+		if s == nil {
+			s = make([]A, 0, len(ms))
+		}
+		return Map(ms[len(s)], extend(s)), true
+	})
+}
+
+// SequenceExpr is the Expr counterpart of [Sequence].
+func SequenceExpr[A any](ms []Expr[A]) Expr[[]A] {
+	return iterateExpr([]A(nil), func(s []A) (Expr[[]A], bool) {
+		if len(s) == len(ms) {
+			return Expr[[]A]{}, false
+		}
+		if s == nil {
+			s = make([]A, 0, len(ms))
+		}
+		return ExprMap(ms[len(s)], extend(s)), true
+	})
+}
+
+// Traverse applies f to every element of xs and runs the resulting
+// computations in order like [Sequence]. f is applied as each element is
+// reached.
+func Traverse[A, B any](xs []A, f func(A) Eff[B]) Eff[[]B] {
+	return iterate([]B(nil), func(s []B) (Eff[[]B], bool) {
+		if len(s) == len(xs) {
+			return nil, false
+		}
+		if s == nil {
+			s = make([]B, 0, len(xs))
+		}
+		return Map(f(xs[len(s)]), extend(s)), true
+	})
+}
+
+// TraverseExpr is the Expr counterpart of [Traverse].
+func TraverseExpr[A, B any](xs []A, f func(A) Expr[B]) Expr[[]B] {
+	return iterateExpr([]B(nil), func(s []B) (Expr[[]B], bool) {
+		if len(s) == len(xs) {
+			return Expr[[]B]{}, false
+		}
+		if s == nil {
+			s = make([]B, 0, len(xs))
+		}
+		return ExprMap(f(xs[len(s)]), extend(s)), true
+	})
+}
+
+// ParTraverseValidated applies f to every element of xs and runs the resulting
+// Error computations concurrently, each in its own goroutine under its own
+// Error handler. Every branch runs to completion regardless of failures:
+// the result is Right with all values when no branch throws, otherwise Left
+// with every thrown error. Both slices preserve input order.
+// A panic in a branch is re-raised on the calling goroutine once all
+// branches have finished.
+func ParTraverseValidated[E, A, B any](xs []A, f func(A) Eff[B]) Either[[]E, []B] {
+	return parTraverseValidated(xs, func(x A) Either[E, B] {
+		return RunError[E, B](f(x))
+	})
+}
+
+// ParTraverseValidatedExpr is the Expr counterpart of [ParTraverseValidated].
+func ParTraverseValidatedExpr[E, A, B any](xs []A, f func(A) Expr[B]) Either[[]E, []B] {
+	return parTraverseValidated(xs, func(x A) Either[E, B] {
+		return RunErrorExpr[E, B](f(x))
+	})
+}
+
+func parTraverseValidated[E, A, B any](xs []A, run func(A) Either[E, B]) Either[[]E, []B] {
+	results := make([]Either[E, B], len(xs))
+	parallel(len(xs), func(i int) { results[i] = run(xs[i]) })
+
+	var errs []E
+	values := make([]B, 0, len(xs))
+	for _, r := range results {
+		if e, ok := r.GetLeft(); ok {
+			errs = append(errs, e)
+			continue
+		}
+		b, _ := r.GetRight()
+		values = append(values, b)
+	}
+	if errs != nil {
+		return Left[[]E, []B](errs)
+	}
+	return Right[[]E](values)
+}
+
+// RunParallel runs independent computations concurrently, each in its own
+// goroutine, stepping it and dispatching every operation with h. h is shared
+// by all goroutines and must be safe for concurrent use, such as
+// [ConcurrentStateHandler]. A computation h short-circuits ends with the
+// short-circuit value. Results preserve input order.
+// A panic in a computation is re-raised on the calling goroutine once all
+// computations have finished.
+func RunParallel[H Handler[H, A], A any](ms []Eff[A], h H) []A {
+	results := make([]A, len(ms))
+	parallel(len(ms), func(i int) {
+		a, s := Step(ms[i])
+		results[i], _ = stepBatch(a, s, h, -1, nil)
+	})
+	return results
+}
+
+// RunParallelExpr is the Expr counterpart of [RunParallel].
+func RunParallelExpr[H Handler[H, A], A any](ms []Expr[A], h H) []A {
+	results := make([]A, len(ms))
+	parallel(len(ms), func(i int) {
+		a, s := StepExpr(ms[i])
+		results[i], _ = stepBatch(a, s, h, -1, nil)
+	})
+	return results
+}
+
+// ParTraverse applies f to every element of xs and runs the resulting
+// computations like [RunParallel], under the shared handler h.
+func ParTraverse[H Handler[H, B], A, B any](xs []A, f func(A) Eff[B], h H) []B {
+	results := make([]B, len(xs))
+	parallel(len(xs), func(i int) {
+		b, s := Step(f(xs[i]))
+		results[i], _ = stepBatch(b, s, h, -1, nil)
+	})
+	return results
+}
+
+// ParTraverseExpr is the Expr counterpart of [ParTraverse].
+func ParTraverseExpr[H Handler[H, B], A, B any](xs []A, f func(A) Expr[B], h H) []B {
+	results := make([]B, len(xs))
+	parallel(len(xs), func(i int) {
+		b, s := StepExpr(f(xs[i]))
+		results[i], _ = stepBatch(b, s, h, -1, nil)
+	})
+	return results
+}
+
+// parallel calls run(i) for every i below n, each in its own goroutine, and
+// waits for all of them. The first panic is re-raised after all return.
+func parallel(n int, run func(int)) {
+	var (
+		wg       sync.WaitGroup
+		once     sync.Once
+		panicked any
+		didPanic bool
+	)
+	for i := range n {
+		wg.Go(func() {
+			defer func() {
+				if r := recover(); r != nil {
+					once.Do(func() { panicked, didPanic = r, true })
+				}
+			}()
+			run(i)
+		})
+	}
+	wg.Wait()
+	if didPanic {
+		panic(panicked)
+	}
+}
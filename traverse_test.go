@@ -0,0 +1,196 @@
+// ©Hayabusa Cloud Co., Ltd. 2026. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package kont_test
+
+import (
+	"slices"
+	"sync/atomic"
+	"testing"
+
+	"code.hybscloud.com/kont"
+)
+
+func checkEven(n int) kont.Eff[int] {
+	if n%2 != 0 {
+		return kont.ThrowError[string, int]("odd")
+	}
+	return kont.Pure(n * 10)
+}
+
+func TestParTraverseValidatedAllRight(t *testing.T) {
+	r := kont.ParTraverseValidated[string]([]int{2, 4, 6}, checkEven)
+	got, ok := r.GetRight()
+	if !ok || !slices.Equal(got, []int{20, 40, 60}) {
+		t.Fatalf("got %+v", r)
+	}
+}
+
+func TestParTraverseValidatedCollectsAllErrors(t *testing.T) {
+	var ran atomic.Int32
+	r := kont.ParTraverseValidated[string]([]int{1, 2, 3, 4, 5}, func(n int) kont.Eff[int] {
+		ran.Add(1)
+		if n%2 != 0 {
+			return kont.ThrowError[string, int]("odd" + string(rune('0'+n)))
+		}
+		return kont.Pure(n)
+	})
+	errs, ok := r.GetLeft()
+	if !ok || !slices.Equal(errs, []string{"odd1", "odd3", "odd5"}) {
+		t.Fatalf("got %+v", r)
+	}
+	if ran.Load() != 5 {
+		t.Fatalf("ran %d branches, want 5", ran.Load())
+	}
+}
+
+func TestParTraverseValidatedEmpty(t *testing.T) {
+	r := kont.ParTraverseValidated[string]([]int{}, checkEven)
+	got, ok := r.GetRight()
+	if !ok || len(got) != 0 {
+		t.Fatalf("got %+v", r)
+	}
+}
+
+func TestParTraverseValidatedPanicReraised(t *testing.T) {
+	defer func() {
+		if r := recover(); r != "boom" {
+			t.Fatalf("got panic %v, want boom", r)
+		}
+	}()
+	kont.ParTraverseValidated[string]([]int{1, 2}, func(n int) kont.Eff[int] {
+		if n == 2 {
+			panic("boom")
+		}
+		return kont.Pure(n)
+	})
+	t.Fatal("expected panic")
+}
+
+func TestParTraverseValidatedExpr(t *testing.T) {
+	r := kont.ParTraverseValidatedExpr[string]([]int{1, 2, 3}, func(n int) kont.Expr[int] {
+		if n == 2 {
+			return kont.ExprThrowError[string, int]("two")
+		}
+		return kont.ExprReturn(n)
+	})
+	errs, ok := r.GetLeft()
+	if !ok || !slices.Equal(errs, []string{"two"}) {
+		t.Fatalf("got %+v", r)
+	}
+}
+
+func TestRunParallel(t *testing.T) {
+	st, state := kont.ConcurrentStateHandler[int, int](0)
+	ms := make([]kont.Eff[int], 50)
+	for i := range ms {
+		ms[i] = kont.Bind(kont.Perform(kont.Modify[int]{F: func(s int) int { return s + 1 }}), func(int) kont.Eff[int] {
+			return kont.Pure(i)
+		})
+	}
+	got := kont.RunParallel(ms, st)
+	for i, v := range got {
+		if v != i {
+			t.Fatalf("result %d = %d", i, v)
+		}
+	}
+	if state() != 50 {
+		t.Fatalf("state %d, want 50", state())
+	}
+}
+
+func TestRunParallelExprShortCircuit(t *testing.T) {
+	h := kont.HandleFunc[int](func(kont.Operation) (kont.Resumed, bool) { return -1, false })
+	got := kont.RunParallelExpr([]kont.Expr[int]{kont.ExprReturn(1), kont.ExprPerform(kont.Get[int]{})}, h)
+	if !slices.Equal(got, []int{1, -1}) {
+		t.Fatalf("got %v", got)
+	}
+}
+
+func TestParTraverse(t *testing.T) {
+	st, state := kont.ConcurrentStateHandler[int, int](0)
+	got := kont.ParTraverse([]int{1, 2, 3, 4}, func(n int) kont.Eff[int] {
+		return kont.Map(kont.Perform(kont.Modify[int]{F: func(s int) int { return s + n }}), func(int) int { return n * n })
+	}, st)
+	if !slices.Equal(got, []int{1, 4, 9, 16}) || state() != 10 {
+		t.Fatalf("got %v, state %d", got, state())
+	}
+}
+
+func TestParTraverseExprPanicReraised(t *testing.T) {
+	defer func() {
+		if r := recover(); unhandledIn(r) != "StateHandler" {
+			t.Fatalf("got panic %v", r)
+		}
+	}()
+	st, _ := kont.ConcurrentStateHandler[int, int](0)
+	kont.ParTraverseExpr([]int{1, 2}, func(n int) kont.Expr[int] {
+		if n == 2 {
+			return kont.ExprPerform(kont.Ask[int]{})
+		}
+		return kont.ExprReturn(n)
+	}, st)
+	t.Fatal("expected panic")
+}
+
+func TestSequence(t *testing.T) {
+	incr := kont.Bind(kont.Perform(kont.Modify[int]{F: func(s int) int { return s + 1 }}), func(s int) kont.Eff[int] {
+		return kont.Pure(s * 10)
+	})
+	comp := kont.Sequence([]kont.Eff[int]{incr, kont.Pure(0), incr})
+	got, state := kont.RunState[int, []int](0, comp)
+	if !slices.Equal(got, []int{10, 0, 20}) || state != 2 {
+		t.Fatalf("got %v, state %d", got, state)
+	}
+	// The computation is a value: running it again starts afresh.
+	again, _ := kont.RunState[int, []int](5, comp)
+	if !slices.Equal(again, []int{60, 0, 70}) || !slices.Equal(got, []int{10, 0, 20}) {
+		t.Fatalf("rerun %v, first %v", again, got)
+	}
+}
+
+func TestSequenceExpr(t *testing.T) {
+	ms := []kont.Expr[int]{kont.ExprReturn(1), kont.ExprPerform(kont.Ask[int]{}), kont.ExprReturn(3)}
+	if got := kont.RunReaderExpr(2, kont.SequenceExpr(ms)); !slices.Equal(got, []int{1, 2, 3}) {
+		t.Fatalf("got %v", got)
+	}
+	if got := kont.RunPure(kont.SequenceExpr[int](nil)); len(got) != 0 {
+		t.Fatalf("got %v", got)
+	}
+}
+
+func TestTraverseStackSafe(t *testing.T) {
+	xs := make([]int, 1_000_000)
+	for i := range xs {
+		xs[i] = i
+	}
+	// Pure elements complete without suspending; the others suspend on Ask.
+	f := func(x int) kont.Eff[int] {
+		if x%2 == 0 {
+			return kont.Pure(x)
+		}
+		return kont.Map(kont.Perform(kont.Ask[int]{}), func(n int) int { return x + n })
+	}
+	got := kont.RunReader(1, kont.Traverse(xs, f))
+	if len(got) != len(xs) || got[len(got)-1] != len(xs) {
+		t.Fatalf("got %d elements", len(got))
+	}
+	gotExpr := kont.RunReaderExpr(1, kont.TraverseExpr(xs, func(x int) kont.Expr[int] { return kont.Reify(f(x)) }))
+	if !slices.Equal(gotExpr, got) {
+		t.Fatal("Expr result differs")
+	}
+}
+
+func TestTraverseMultiShot(t *testing.T) {
+	comp := kont.Traverse([]int{1, 2}, func(x int) kont.Eff[int] { return kont.Amb(x, -x) })
+	got := kont.RunNonDet(comp)
+	want := [][]int{{1, 2}, {1, -2}, {-1, 2}, {-1, -2}}
+	if !slices.EqualFunc(got, want, slices.Equal) {
+		t.Fatalf("got %v", got)
+	}
+	gotExpr := kont.RunNonDetExpr(kont.TraverseExpr([]int{1, 2}, func(x int) kont.Expr[int] { return kont.ExprAmb(x, -x) }))
+	if !slices.EqualFunc(gotExpr, want, slices.Equal) {
+		t.Fatalf("got %v", gotExpr)
+	}
+}
@@ -0,0 +1,164 @@
+// ©Hayabusa Cloud Co., Ltd. 2026. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package kont
+
+import "time"
+
+// Two-phase commit protocol over the stepping boundary.
+// Participants are ordinary computations that perform Prepare once their
+// tentative work is done; the coordinator collects every vote while the
+// participants stay suspended, dispatches Commit or Abort for each of them,
+// then resumes all of them with one Decision.
+
+// Decision is the coordinator's outcome delivered to prepared participants.
+type Decision uint8
+
+const (
+	// TwoPhaseCommit tells participants to make their tentative work durable.
+	TwoPhaseCommit Decision = iota + 1
+	// TwoPhaseAbort tells participants to roll back their tentative work.
+	TwoPhaseAbort
+)
+
+// Prepare is the effect operation a participant performs to vote.
+// Perform(Prepare{Vote: ok}) suspends until every participant has voted and
+// resumes with [TwoPhaseCommit] only if all votes were true, otherwise
+// [TwoPhaseAbort].
+type Prepare struct{ Vote bool }
+
+func (Prepare) OpResult() Decision { panic("phantom") }
+
+// Commit is the effect operation the coordinator dispatches for each
+// prepared participant, by its index, once the decision is
+// [TwoPhaseCommit]. Resumes with struct{}.
+type Commit struct{ Participant int }
+
+func (Commit) OpResult() struct{} { panic("phantom") }
+
+// Abort is the effect operation the coordinator dispatches for each
+// prepared participant, by its index, once the decision is
+// [TwoPhaseAbort]. Resumes with struct{}.
+type Abort struct{ Participant int }
+
+func (Abort) OpResult() struct{} { panic("phantom") }
+
+// PrepareCommit fuses Prepare + Bind: votes, then passes the decision to f.
+func PrepareCommit[B any](vote bool, f func(Decision) Cont[Resumed, B]) Cont[Resumed, B] {
+	return Bind(Perform(Prepare{Vote: vote}), f)
+}
+
+// RunTwoPhaseCommit drives participants through two-phase commit.
+//
+// Phase one steps each participant in order, dispatching its other effects to
+// h, until it performs [Prepare]. A participant that votes false, completes
+// without preparing, or is short-circuited by h decides [TwoPhaseAbort];
+// participants after it are never started. Phase two dispatches [Commit] or
+// [Abort] to h for every prepared participant, then resumes it with the
+// decision and runs it to completion under h; one whose Commit or Abort h
+// short-circuits is discarded instead.
+//
+// Returns the decision and each participant's result; participants that never
+// started or were short-circuited keep the value they produced (zero if unstarted).
+func RunTwoPhaseCommit[H Handler[H, A], A any](h H, participants ...Cont[Resumed, A]) (Decision, []A) {
+	return runTwoPhaseCommit(h, func(m Cont[Resumed, A]) (A, *Suspension[A], bool) {
+		a, susp := RunUntil(m, h, isPrepare)
+		return a, susp, true
+	}, participants)
+}
+
+// RunTwoPhaseCommitWithin is [RunTwoPhaseCommit] with a deadline of timeout
+// on phase one, as measured by after (time.After when nil;
+// [VirtualClock.After] for deterministic tests). Each participant is stepped
+// to its Prepare on its own goroutine; once the deadline passes, the
+// participant being stepped is abandoned with a zero result and the
+// decision is [TwoPhaseAbort]. Since an abandoned participant may still be
+// running, h must be safe for concurrent use. A panic in a participant is
+// re-raised here.
+func RunTwoPhaseCommitWithin[H Handler[H, A], A any](h H, timeout time.Duration, after func(time.Duration) <-chan time.Time, participants ...Cont[Resumed, A]) (Decision, []A) {
+	if after == nil {
+		after = time.After
+	}
+	expired := after(timeout)
+	return runTwoPhaseCommit(h, func(m Cont[Resumed, A]) (A, *Suspension[A], bool) {
+		done := make(chan preparedResult[A], 1)
+		go func() {
+			var r preparedResult[A]
+			defer func() {
+				if p := recover(); p != nil {
+					r.panicked = p
+				}
+				done <- r
+			}()
+			r.a, r.susp = RunUntil(m, h, isPrepare)
+		}()
+		select {
+		case r := <-done:
+			if r.panicked != nil {
+				panic(r.panicked)
+			}
+			return r.a, r.susp, true
+		case <-expired:
+			go func() {
+				if r := <-done; r.susp != nil {
+					r.susp.Discard()
+				}
+			}()
+			var zero A
+			return zero, nil, false
+		}
+	}, participants)
+}
+
+// preparedResult is the outcome of phase one for a participant stepped on
+// its own goroutine.
+type preparedResult[A any] struct {
+	a        A
+	susp     *Suspension[A]
+	panicked any
+}
+
+// runTwoPhaseCommit steps each participant to its Prepare with prepare,
+// which reports false when the participant timed out.
+func runTwoPhaseCommit[H Handler[H, A], A any](h H, prepare func(Cont[Resumed, A]) (A, *Suspension[A], bool), participants []Cont[Resumed, A]) (Decision, []A) {
+	results := make([]A, len(participants))
+	prepared := make([]*Suspension[A], 0, len(participants))
+	decision := TwoPhaseCommit
+	for i, m := range participants {
+		a, susp, ok := prepare(m)
+		if !ok {
+			decision = TwoPhaseAbort
+			break
+		}
+		if susp == nil {
+			results[i] = a
+			decision = TwoPhaseAbort
+			break
+		}
+		prepared = append(prepared, susp)
+		if !susp.Op().(Prepare).Vote {
+			decision = TwoPhaseAbort
+			break
+		}
+	}
+	for i, susp := range prepared {
+		var op Operation = Commit{Participant: i}
+		if decision == TwoPhaseAbort {
+			op = Abort{Participant: i}
+		}
+		if v, ok := h.Dispatch(op); !ok {
+			susp.Discard()
+			results[i] = valueOrZero[A](v)
+			continue
+		}
+		// A nil pred runs the participant to completion.
+		results[i], _ = ResumeUntil(susp, decision, h, nil)
+	}
+	return decision, results
+}
+
+func isPrepare(op Operation) bool {
+	_, ok := op.(Prepare)
+	return ok
+}
@@ -0,0 +1,204 @@
+// ©Hayabusa Cloud Co., Ltd. 2026. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package kont_test
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+	"time"
+
+	"code.hybscloud.com/kont"
+)
+
+// participant logs its progress through Tell and returns its final status.
+func participant(name string, vote bool) kont.Eff[string] {
+	return kont.TellWriter(name+":work", kont.PrepareCommit(vote, func(d kont.Decision) kont.Eff[string] {
+		if d == kont.TwoPhaseCommit {
+			return kont.TellWriter(name+":commit", kont.Pure(name+" committed"))
+		}
+		return kont.TellWriter(name+":rollback", kont.Pure(name+" aborted"))
+	}))
+}
+
+// coordinatorLog appends every Tell[string] value, and every Commit or
+// Abort as "commit i" or "abort i", to log.
+func coordinatorLog(log *[]string) func(kont.Operation) (kont.Resumed, bool) {
+	return func(op kont.Operation) (kont.Resumed, bool) {
+		switch op := op.(type) {
+		case kont.Tell[string]:
+			*log = append(*log, op.Value)
+		case kont.Commit:
+			*log = append(*log, fmt.Sprint("commit ", op.Participant))
+		case kont.Abort:
+			*log = append(*log, fmt.Sprint("abort ", op.Participant))
+		default:
+			panic(&kont.UnhandledError{Handler: "coordinatorLog", Op: op})
+		}
+		return struct{}{}, true
+	}
+}
+
+func TestTwoPhaseCommitAllVoteYes(t *testing.T) {
+	var log []string
+	h := kont.HandleFunc[string](coordinatorLog(&log))
+	decision, results := kont.RunTwoPhaseCommit(h, participant("a", true), participant("b", true))
+	if decision != kont.TwoPhaseCommit {
+		t.Fatalf("got decision %d, want TwoPhaseCommit", decision)
+	}
+	if !slices.Equal(results, []string{"a committed", "b committed"}) {
+		t.Fatalf("got %v", results)
+	}
+	want := []string{"a:work", "b:work", "commit 0", "a:commit", "commit 1", "b:commit"}
+	if !slices.Equal(log, want) {
+		t.Fatalf("got log %v, want %v", log, want)
+	}
+}
+
+func TestTwoPhaseCommitVoteNoAborts(t *testing.T) {
+	var log []string
+	h := kont.HandleFunc[string](coordinatorLog(&log))
+	decision, results := kont.RunTwoPhaseCommit(h,
+		participant("a", true),
+		participant("b", false),
+		participant("c", true),
+	)
+	if decision != kont.TwoPhaseAbort {
+		t.Fatalf("got decision %d, want TwoPhaseAbort", decision)
+	}
+	if !slices.Equal(results, []string{"a aborted", "b aborted", ""}) {
+		t.Fatalf("got %v", results)
+	}
+	want := []string{"a:work", "b:work", "abort 0", "a:rollback", "abort 1", "b:rollback"}
+	if !slices.Equal(log, want) {
+		t.Fatalf("got log %v, want %v", log, want)
+	}
+}
+
+func TestTwoPhaseCommitCompletedWithoutPrepare(t *testing.T) {
+	var log []string
+	h := kont.HandleFunc[string](coordinatorLog(&log))
+	decision, results := kont.RunTwoPhaseCommit(h, participant("a", true), kont.Pure("gave up"))
+	if decision != kont.TwoPhaseAbort {
+		t.Fatalf("got decision %d, want TwoPhaseAbort", decision)
+	}
+	if !slices.Equal(results, []string{"a aborted", "gave up"}) {
+		t.Fatalf("got %v", results)
+	}
+}
+
+func TestTwoPhaseCommitShortCircuit(t *testing.T) {
+	h := kont.HandleFunc[string](func(op kont.Operation) (kont.Resumed, bool) {
+		return "short-circuited", false
+	})
+	decision, results := kont.RunTwoPhaseCommit(h, participant("a", true))
+	if decision != kont.TwoPhaseAbort {
+		t.Fatalf("got decision %d, want TwoPhaseAbort", decision)
+	}
+	if results[0] != "short-circuited" {
+		t.Fatalf("got %q", results[0])
+	}
+}
+
+func TestTwoPhaseCommitPhaseTwoShortCircuit(t *testing.T) {
+	h := kont.HandleFunc[string](func(op kont.Operation) (kont.Resumed, bool) {
+		if c, ok := op.(kont.Commit); ok && c.Participant == 1 {
+			return "refused", false
+		}
+		return struct{}{}, true
+	})
+	decision, results := kont.RunTwoPhaseCommit(h, participant("a", true), participant("b", true))
+	if decision != kont.TwoPhaseCommit || !slices.Equal(results, []string{"a committed", "refused"}) {
+		t.Fatalf("got %d, %v", decision, results)
+	}
+}
+
+func TestTwoPhaseCommitNoParticipants(t *testing.T) {
+	var log []string
+	h := kont.HandleFunc[string](coordinatorLog(&log))
+	decision, results := kont.RunTwoPhaseCommit(h, []kont.Eff[string]{}...)
+	if decision != kont.TwoPhaseCommit || len(results) != 0 {
+		t.Fatalf("got %d, %v", decision, results)
+	}
+}
+
+func TestOpResultPanicPrepare(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Fatal("expected phantom panic")
+		}
+	}()
+	kont.Prepare{}.OpResult()
+}
+
+func TestOpResultPanicCommitAbort(t *testing.T) {
+	for name, op := range map[string]func(){
+		"Commit": func() { kont.Commit{}.OpResult() },
+		"Abort":  func() { kont.Abort{}.OpResult() },
+	} {
+		t.Run(name, func(t *testing.T) {
+			defer func() {
+				if recover() == nil {
+					t.Fatal("expected phantom panic")
+				}
+			}()
+			op()
+		})
+	}
+}
+
+func TestTwoPhaseCommitWithin(t *testing.T) {
+	clock := kont.NewVirtualClock(time.Unix(0, 0))
+	var log []string
+	h := kont.HandleFunc[string](coordinatorLog(&log))
+	decision, results := kont.RunTwoPhaseCommitWithin(h, time.Minute, clock.After, participant("a", true), participant("b", true))
+	if decision != kont.TwoPhaseCommit || !slices.Equal(results, []string{"a committed", "b committed"}) {
+		t.Fatalf("got %d, %v", decision, results)
+	}
+}
+
+func TestTwoPhaseCommitWithinTimesOutHungParticipant(t *testing.T) {
+	clock := kont.NewVirtualClock(time.Unix(0, 0))
+	hung := make(chan struct{})
+	hang := make(chan struct{})
+	defer close(hang)
+	var log []string
+	logOps := coordinatorLog(&log)
+	h := kont.HandleFunc[string](func(op kont.Operation) (kont.Resumed, bool) {
+		if _, ok := op.(kont.Ask[int]); ok {
+			close(hung)
+			<-hang // never returns within the deadline
+			return 0, true
+		}
+		return logOps(op)
+	})
+	go func() {
+		<-hung
+		clock.Advance(time.Minute)
+	}()
+	decision, results := kont.RunTwoPhaseCommitWithin(h, time.Minute, clock.After,
+		participant("a", true),
+		kont.Then(kont.Perform(kont.Ask[int]{}), participant("b", true)),
+		participant("c", true),
+	)
+	if decision != kont.TwoPhaseAbort || !slices.Equal(results, []string{"a aborted", "", ""}) {
+		t.Fatalf("got %d, %v", decision, results)
+	}
+	want := []string{"a:work", "abort 0", "a:rollback"}
+	if !slices.Equal(log, want) {
+		t.Fatalf("got log %v, want %v", log, want)
+	}
+}
+
+func TestTwoPhaseCommitWithinPropagatesPanic(t *testing.T) {
+	defer func() {
+		if r := recover(); r != "boom" {
+			t.Fatalf("got panic %v", r)
+		}
+	}()
+	h := kont.HandleFunc[string](func(kont.Operation) (kont.Resumed, bool) { panic("boom") })
+	kont.RunTwoPhaseCommitWithin(h, time.Minute, nil, participant("a", true))
+	t.Fatal("expected panic")
+}
@@ -0,0 +1,128 @@
+// ©Hayabusa Cloud Co., Ltd. 2026. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package kont
+
+// Validated represents a value that is either Valid or Invalid with one or
+// more errors. Unlike Either, combining Validated values accumulates the
+// errors of every Invalid operand instead of stopping at the first.
+type Validated[E, A any] struct {
+	errs  []E
+	value A
+}
+
+// Valid creates a Valid value.
+func Valid[E, A any](a A) Validated[E, A] {
+	return Validated[E, A]{value: a}
+}
+
+// Invalid creates an Invalid value carrying errs.
+// Panics if errs is empty.
+func Invalid[E, A any](errs ...E) Validated[E, A] {
+	if len(errs) == 0 {
+		panic("kont: Invalid requires at least one error")
+	}
+	return Validated[E, A]{errs: errs}
+}
+
+// IsValid returns true if this is a Valid value.
+func (v Validated[E, A]) IsValid() bool {
+	return v.errs == nil
+}
+
+// Value returns the Valid value and true, or zero and false.
+func (v Validated[E, A]) Value() (A, bool) {
+	if v.errs == nil {
+		return v.value, true
+	}
+	var zero A
+	return zero, false
+}
+
+// Errors returns the accumulated errors, or nil if Valid.
+func (v Validated[E, A]) Errors() []E {
+	return v.errs
+}
+
+// Either converts v to an Either with all errors on the Left.
+func (v Validated[E, A]) Either() Either[[]E, A] {
+	if v.errs == nil {
+		return Right[[]E](v.value)
+	}
+	return Left[[]E, A](v.errs)
+}
+
+// MapValidated applies a function to the Valid value.
+func MapValidated[E, A, B any](v Validated[E, A], f func(A) B) Validated[E, B] {
+	if v.errs == nil {
+		return Valid[E](f(v.value))
+	}
+	return Validated[E, B]{errs: v.errs}
+}
+
+// ZipValidated pairs two Validated values.
+// Returns Valid only if both are Valid; otherwise Invalid with the errors of
+// va followed by those of vb.
+func ZipValidated[E, A, B any](va Validated[E, A], vb Validated[E, B]) Validated[E, Pair[A, B]] {
+	if va.errs == nil && vb.errs == nil {
+		return Valid[E](Pair[A, B]{Fst: va.value, Snd: vb.value})
+	}
+	errs := make([]E, 0, len(va.errs)+len(vb.errs))
+	errs = append(errs, va.errs...)
+	errs = append(errs, vb.errs...)
+	return Validated[E, Pair[A, B]]{errs: errs}
+}
+
+// RunValidation runs an error-capable computation and returns Validated.
+// A Throw makes the result Invalid with that single error.
+func RunValidation[E, A any](m Cont[Resumed, A]) Validated[E, A] {
+	return validatedOf(RunError[E, A](m))
+}
+
+// RunValidationExpr runs an Expr error-capable computation and returns Validated.
+func RunValidationExpr[E, A any](m Expr[A]) Validated[E, A] {
+	return validatedOf(RunErrorExpr[E, A](m))
+}
+
+func validatedOf[E, A any](r Either[E, A]) Validated[E, A] {
+	if e, ok := r.GetLeft(); ok {
+		return Validated[E, A]{errs: []E{e}}
+	}
+	a, _ := r.GetRight()
+	return Valid[E](a)
+}
+
+// TraverseValidated applies f to every element of xs and runs each resulting
+// computation in order under its own Error handler. Every computation runs
+// regardless of earlier failures: the result is Valid with all values when
+// none throws, otherwise Invalid with every thrown error in input order.
+func TraverseValidated[E, A, B any](xs []A, f func(A) Eff[B]) Validated[E, []B] {
+	return traverseValidated(xs, func(x A) Validated[E, B] {
+		return RunValidation[E, B](f(x))
+	})
+}
+
+// TraverseValidatedExpr is the Expr counterpart of [TraverseValidated].
+func TraverseValidatedExpr[E, A, B any](xs []A, f func(A) Expr[B]) Validated[E, []B] {
+	return traverseValidated(xs, func(x A) Validated[E, B] {
+		return RunValidationExpr[E, B](f(x))
+	})
+}
+
+func traverseValidated[E, A, B any](xs []A, run func(A) Validated[E, B]) Validated[E, []B] {
+	var errs []E
+	values := make([]B, 0, len(xs))
+	for _, x := range xs {
+		r := run(x)
+		if r.errs != nil {
+			errs = append(errs, r.errs...)
+			continue
+		}
+		values = append(values, r.value)
+	}
+	if errs != nil {
+		return Validated[E, []B]{errs: errs}
+	}
+	return Valid[E](values)
+}
@@ -0,0 +1,102 @@
+// ©Hayabusa Cloud Co., Ltd. 2026. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package kont_test
+
+import (
+	"slices"
+	"testing"
+
+	"code.hybscloud.com/kont"
+)
+
+type form struct {
+	Name string
+	Age  int
+}
+
+func validateName(name string) kont.Eff[string] {
+	if name == "" {
+		return kont.ThrowError[string, string]("name required")
+	}
+	return kont.Pure(name)
+}
+
+func validateAge(age int) kont.Eff[int] {
+	if age < 0 {
+		return kont.ThrowError[string, int]("age negative")
+	}
+	return kont.Pure(age)
+}
+
+func validateForm(name string, age int) kont.Validated[string, form] {
+	return kont.MapValidated(
+		kont.ZipValidated(
+			kont.RunValidation[string](validateName(name)),
+			kont.RunValidation[string](validateAge(age)),
+		),
+		func(p kont.Pair[string, int]) form { return form{Name: p.Fst, Age: p.Snd} },
+	)
+}
+
+func TestZipValidatedValid(t *testing.T) {
+	v := validateForm("ada", 36)
+	got, ok := v.Value()
+	if !ok || got != (form{"ada", 36}) || v.Errors() != nil {
+		t.Fatalf("got %+v", v)
+	}
+}
+
+func TestZipValidatedAccumulatesErrors(t *testing.T) {
+	v := validateForm("", -1)
+	if v.IsValid() {
+		t.Fatal("expected Invalid")
+	}
+	if want := []string{"name required", "age negative"}; !slices.Equal(v.Errors(), want) {
+		t.Fatalf("got %v, want %v", v.Errors(), want)
+	}
+	if errs, ok := v.Either().GetLeft(); !ok || len(errs) != 2 {
+		t.Fatalf("Either: got %v", v.Either())
+	}
+}
+
+func TestTraverseValidated(t *testing.T) {
+	v := kont.TraverseValidated[string]([]int{2, 4, 6}, checkEven)
+	if got, ok := v.Value(); !ok || !slices.Equal(got, []int{20, 40, 60}) {
+		t.Fatalf("got %+v", v)
+	}
+
+	ran := 0
+	v = kont.TraverseValidated[string]([]int{1, 2, 3}, func(n int) kont.Eff[int] {
+		ran++
+		return checkEven(n)
+	})
+	if ran != 3 || !slices.Equal(v.Errors(), []string{"odd", "odd"}) {
+		t.Fatalf("ran %d, errors %v", ran, v.Errors())
+	}
+}
+
+func TestTraverseValidatedExpr(t *testing.T) {
+	v := kont.TraverseValidatedExpr[string]([]int{1, 2}, func(n int) kont.Expr[int] {
+		if n%2 != 0 {
+			return kont.ExprThrowError[string, int]("odd")
+		}
+		return kont.ExprReturn(n)
+	})
+	if !slices.Equal(v.Errors(), []string{"odd"}) {
+		t.Fatalf("got %+v", v)
+	}
+	if r := kont.RunValidationExpr[string](kont.ExprReturn(1)); !r.IsValid() {
+		t.Fatalf("got %+v", r)
+	}
+}
+
+func TestInvalidRequiresError(t *testing.T) {
+	defer func() {
+		if r := recover(); r != "kont: Invalid requires at least one error" {
+			t.Fatalf("got panic %v", r)
+		}
+	}()
+	kont.Invalid[string, int]()
+}
@@ -0,0 +1,416 @@
+// ©Hayabusa Cloud Co., Ltd. 2026. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package kont
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Worker-pool driver.
+// A Pool multiplexes many stepped computations over a few worker goroutines.
+// A worker advances one computation until it completes or performs an
+// operation the Park callback takes, such as a socket read; the suspension
+// is then held off the workers until the callback resumes it, so a parked
+// computation costs memory but no goroutine. The pool answers AskCtx and
+// CheckCancelled from a context that Shutdown cancels, so computations can
+// observe a drain and stop early.
+
+// ErrPoolClosed is returned when submitting to a pool that is shutting down.
+var ErrPoolClosed = errors.New("kont: pool closed")
+
+// PoolOptions configures a [Pool].
+type PoolOptions struct {
+	// Workers is the number of worker goroutines. Zero means
+	// runtime.GOMAXPROCS(0).
+	Workers int
+	// MaxLive bounds the computations submitted and not yet completed,
+	// parked ones included: Submit blocks and TrySubmit fails at the bound.
+	// Zero means unbounded.
+	MaxLive int
+	// Park is offered every operation other than AskCtx and CheckCancelled
+	// first. It reports true to park the
+	// computation, and must then call resume exactly once, from any
+	// goroutine, with the operation's result; it must not call resume when
+	// it reports false. Nil parks nothing.
+	Park func(op Operation, resume func(Resumed)) bool
+	// Dispatch handles operations Park does not take. It is called from
+	// the workers and must be safe for concurrent use. A computation whose
+	// operation Dispatch short-circuits is stopped. Nil means every
+	// operation must be parked.
+	Dispatch func(Operation) (Resumed, bool)
+	// Now reads the clock for [Pool.Stats]. Nil means time.Now.
+	Now func() time.Time
+	// Panic chooses what a worker does when a computation panics, in its
+	// own code or in Park or Dispatch. The zero value, PanicCrash, lets
+	// the panic crash the process.
+	Panic PanicPolicy
+	// OnPanic, if set, is called from the worker with [PanicError] of
+	// each panic Panic recovers.
+	OnPanic func(error)
+}
+
+// PoolStats is a snapshot of a [Pool], suitable for publishing through
+// expvar or a debug handler.
+type PoolStats struct {
+	// Workers is the number of worker goroutines.
+	Workers int
+	// Live counts computations submitted and not yet completed; those
+	// neither queued nor parked are running on a worker.
+	Live int
+	// Queued counts computations ready to run and waiting for a worker.
+	Queued int
+	// Parked counts computations held by Park.
+	Parked int
+	// ParkedOps counts parked computations by the type of their pending
+	// operation, as formatted by %T.
+	ParkedOps map[string]int
+	// OldestParked is how long the longest-parked computation has been
+	// held, or zero if none is.
+	OldestParked time.Duration
+}
+
+// poolTask is a computation ready to run: not yet started, or susp
+// resumed with v. start is kept to restart the computation.
+type poolTask struct {
+	start func() (struct{}, *Suspension[struct{}])
+	susp  *Suspension[struct{}]
+	v     Resumed
+}
+
+// Pool drives computations on a fixed set of worker goroutines.
+// It is safe for concurrent use. A panic in a computation is handled as
+// PoolOptions.Panic chooses.
+type Pool struct {
+	opts    PoolOptions
+	slots   chan struct{}
+	workers sync.WaitGroup
+
+	ctx       context.Context
+	cancel    context.CancelFunc
+	abandoned atomic.Bool
+
+	mu      sync.Mutex
+	ready   sync.Cond
+	queue   []poolTask
+	live    int
+	parked  map[*poolParked]struct{}
+	closing bool
+	drained chan struct{}
+}
+
+// poolParked is a computation held by Park.
+type poolParked struct {
+	susp  *Suspension[struct{}]
+	op    Operation
+	since time.Time
+}
+
+// NewPool creates a pool and starts its workers.
+func NewPool(opts PoolOptions) *Pool {
+	if opts.Workers <= 0 {
+		opts.Workers = runtime.GOMAXPROCS(0)
+	}
+	if opts.Now == nil {
+		opts.Now = time.Now
+	}
+	p := &Pool{opts: opts, parked: make(map[*poolParked]struct{}), drained: make(chan struct{})}
+	p.ctx, p.cancel = context.WithCancel(context.Background())
+	p.ready.L = &p.mu
+	if opts.MaxLive > 0 {
+		p.slots = make(chan struct{}, opts.MaxLive)
+	}
+	for range opts.Workers {
+		p.workers.Go(p.work)
+	}
+	return p
+}
+
+// Submit adds m to the pool, blocking while MaxLive computations are live.
+// Returns ctx.Err() if ctx is done first, or [ErrPoolClosed] once Shutdown
+// has been called.
+func (p *Pool) Submit(ctx context.Context, m Cont[Resumed, struct{}]) error {
+	return p.submit(ctx, func() (struct{}, *Suspension[struct{}]) { return Step(m) })
+}
+
+// SubmitExpr is the Expr counterpart of [Pool.Submit].
+func (p *Pool) SubmitExpr(ctx context.Context, m Expr[struct{}]) error {
+	return p.submit(ctx, func() (struct{}, *Suspension[struct{}]) { return StepExpr(m) })
+}
+
+// TrySubmit adds m to the pool without blocking. Reports false if MaxLive
+// computations are live or the pool is shutting down.
+func (p *Pool) TrySubmit(m Cont[Resumed, struct{}]) bool {
+	return p.trySubmit(func() (struct{}, *Suspension[struct{}]) { return Step(m) })
+}
+
+// TrySubmitExpr is the Expr counterpart of [Pool.TrySubmit].
+func (p *Pool) TrySubmitExpr(m Expr[struct{}]) bool {
+	return p.trySubmit(func() (struct{}, *Suspension[struct{}]) { return StepExpr(m) })
+}
+
+func (p *Pool) submit(ctx context.Context, start func() (struct{}, *Suspension[struct{}])) error {
+	if p.slots != nil {
+		select {
+		case p.slots <- struct{}{}:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	if !p.admit(start) {
+		return ErrPoolClosed
+	}
+	return nil
+}
+
+func (p *Pool) trySubmit(start func() (struct{}, *Suspension[struct{}])) bool {
+	if p.slots != nil {
+		select {
+		case p.slots <- struct{}{}:
+		default:
+			return false
+		}
+	}
+	return p.admit(start)
+}
+
+// admit queues start unless the pool is shutting down, returning its slot
+// if so.
+func (p *Pool) admit(start func() (struct{}, *Suspension[struct{}])) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.closing {
+		if p.slots != nil {
+			<-p.slots
+		}
+		return false
+	}
+	p.live++
+	p.queue = append(p.queue, poolTask{start: start})
+	p.ready.Signal()
+	return true
+}
+
+// Live returns the number of computations submitted and not yet completed.
+func (p *Pool) Live() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.live
+}
+
+// Parked returns the number of computations waiting for Park to resume them.
+func (p *Pool) Parked() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return len(p.parked)
+}
+
+// Stats returns a snapshot of the pool's computations.
+func (p *Pool) Stats() PoolStats {
+	now := p.opts.Now()
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	st := PoolStats{
+		Workers:   p.opts.Workers,
+		Live:      p.live,
+		Queued:    len(p.queue),
+		Parked:    len(p.parked),
+		ParkedOps: make(map[string]int),
+	}
+	for w := range p.parked {
+		st.ParkedOps[fmt.Sprintf("%T", w.op)]++
+		st.OldestParked = max(st.OldestParked, now.Sub(w.since))
+	}
+	return st
+}
+
+// Shutdown stops accepting computations, cancels the context their AskCtx
+// and CheckCancelled see, and waits for the live ones to complete, then
+// stops the workers. If ctx is done first, parked and queued computations
+// are discarded, releasing their suspensions, and running ones are
+// discarded at their next operation; Shutdown then returns ctx.Err(), and
+// the workers stop once the running computations have stopped.
+func (p *Pool) Shutdown(ctx context.Context) error {
+	p.mu.Lock()
+	if !p.closing {
+		p.closing = true
+		if p.live == 0 {
+			close(p.drained)
+		}
+		p.ready.Broadcast()
+	}
+	p.mu.Unlock()
+	p.cancel()
+	select {
+	case <-p.drained:
+		p.workers.Wait()
+		return nil
+	case <-ctx.Done():
+		p.abandon()
+		return ctx.Err()
+	}
+}
+
+// abandon discards the parked and queued computations and makes running
+// ones stop at their next operation.
+func (p *Pool) abandon() {
+	if p.abandoned.Swap(true) {
+		return
+	}
+	p.mu.Lock()
+	queue, parked := p.queue, p.parked
+	p.queue, p.parked = nil, make(map[*poolParked]struct{})
+	p.mu.Unlock()
+	for w := range parked {
+		w.susp.Discard()
+		p.finish()
+	}
+	for _, t := range queue {
+		if t.susp != nil {
+			t.susp.Discard()
+		}
+		p.finish()
+	}
+}
+
+// work runs queued tasks until the pool has shut down and drained.
+func (p *Pool) work() {
+	for {
+		p.mu.Lock()
+		for len(p.queue) == 0 && !(p.closing && p.live == 0) {
+			p.ready.Wait()
+		}
+		if len(p.queue) == 0 {
+			p.mu.Unlock()
+			return
+		}
+		t := p.queue[0]
+		p.queue[0] = poolTask{}
+		p.queue = p.queue[1:]
+		p.mu.Unlock()
+		p.run(t)
+	}
+}
+
+// run advances t until it parks or completes.
+func (p *Pool) run(t poolTask) {
+	if p.opts.Panic != PanicCrash {
+		defer p.recoverTask(t.start)
+	}
+	var susp *Suspension[struct{}]
+	if t.susp == nil {
+		_, susp = t.start()
+	} else {
+		_, susp = t.susp.Resume(t.v)
+	}
+	for susp != nil {
+		if p.abandoned.Load() {
+			susp.Discard()
+			break
+		}
+		op := susp.Op()
+		switch op := op.(type) {
+		case AskCtx:
+			_, susp = susp.Resume(p.ctx)
+			continue
+		case CheckCancelled:
+			c := op.ctx
+			if c == nil {
+				c = p.ctx
+			}
+			if c.Err() != nil {
+				susp.Discard()
+				susp = nil
+				continue
+			}
+			_, susp = susp.Resume(struct{}{})
+			continue
+		}
+		if p.opts.Park != nil && p.park(susp, op, t.start) {
+			return
+		}
+		if p.opts.Dispatch == nil {
+			unhandledEffect("Pool", op)
+		}
+		v, shouldResume := p.opts.Dispatch(op)
+		if !shouldResume {
+			susp.Discard()
+			break
+		}
+		_, susp = susp.Resume(v)
+	}
+	p.finish()
+}
+
+// park offers op to Park, holding susp as parked while Park holds it.
+// Reports true if Park took op, or if Shutdown discarded susp meanwhile.
+func (p *Pool) park(susp *Suspension[struct{}], op Operation, start func() (struct{}, *Suspension[struct{}])) bool {
+	w := &poolParked{susp: susp, op: op, since: p.opts.Now()}
+	p.mu.Lock()
+	p.parked[w] = struct{}{}
+	p.mu.Unlock()
+	var resumed atomic.Bool
+	if p.opts.Park(op, func(v Resumed) {
+		if resumed.Swap(true) {
+			panic("kont: pool suspension resumed twice")
+		}
+		p.mu.Lock()
+		defer p.mu.Unlock()
+		if _, ok := p.parked[w]; !ok {
+			return // discarded by Shutdown
+		}
+		delete(p.parked, w)
+		p.queue = append(p.queue, poolTask{start: start, susp: susp, v: v})
+		p.ready.Signal()
+	}) {
+		return true
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if _, ok := p.parked[w]; !ok {
+		return true
+	}
+	delete(p.parked, w)
+	return false
+}
+
+// recoverTask applies the panic policy to a panic raised while running the
+// computation started by start.
+func (p *Pool) recoverTask(start func() (struct{}, *Suspension[struct{}])) {
+	r := recover()
+	if r == nil {
+		return
+	}
+	if p.opts.OnPanic != nil {
+		p.opts.OnPanic(PanicError(r))
+	}
+	if p.opts.Panic == PanicRestart && !p.abandoned.Load() {
+		p.mu.Lock()
+		p.queue = append(p.queue, poolTask{start: start})
+		p.ready.Signal()
+		p.mu.Unlock()
+		return
+	}
+	p.finish()
+}
+
+// finish retires a completed computation.
+func (p *Pool) finish() {
+	if p.slots != nil {
+		<-p.slots
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.live--
+	if p.live == 0 && p.closing {
+		close(p.drained)
+		p.ready.Broadcast()
+	}
+}
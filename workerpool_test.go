@@ -0,0 +1,244 @@
+// ©Hayabusa Cloud Co., Ltd. 2026. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package kont_test
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"code.hybscloud.com/kont"
+)
+
+// netRead stands in for a socket read completed by a poller.
+type netRead struct{ conn int }
+
+func (netRead) OpResult() int { panic("phantom") }
+
+func parkNetRead(op kont.Operation, resume func(kont.Resumed)) bool {
+	r, ok := op.(netRead)
+	if !ok {
+		return false
+	}
+	go resume(r.conn * 2)
+	return true
+}
+
+func TestPool(t *testing.T) {
+	var sum atomic.Int64
+	p := kont.NewPool(kont.PoolOptions{
+		Workers:  4,
+		MaxLive:  64,
+		Park:     parkNetRead,
+		Dispatch: func(op kont.Operation) (kont.Resumed, bool) { return 1, true },
+	})
+	ctx := context.Background()
+	for i := range 2000 {
+		conn := kont.Bind(kont.Perform(netRead{conn: i}), func(n int) kont.Eff[struct{}] {
+			return kont.Map(kont.Perform(kont.Ask[int]{}), func(one int) struct{} {
+				sum.Add(int64(n + one))
+				return struct{}{}
+			})
+		})
+		if err := p.Submit(ctx, conn); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := p.Shutdown(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if want := int64(2000*1999 + 2000); sum.Load() != want {
+		t.Fatalf("sum %d, want %d", sum.Load(), want)
+	}
+	if p.Live() != 0 || p.Parked() != 0 {
+		t.Fatalf("live %d, parked %d", p.Live(), p.Parked())
+	}
+	if err := p.SubmitExpr(ctx, kont.ExprReturn(struct{}{})); !errors.Is(err, kont.ErrPoolClosed) {
+		t.Fatalf("submit after shutdown: %v", err)
+	}
+}
+
+func TestPoolBackpressure(t *testing.T) {
+	var resumes []func(kont.Resumed)
+	parked := make(chan func(kont.Resumed), 2)
+	p := kont.NewPool(kont.PoolOptions{
+		Workers: 1,
+		MaxLive: 2,
+		Park: func(op kont.Operation, resume func(kont.Resumed)) bool {
+			parked <- resume
+			return true
+		},
+	})
+	wait := kont.ExprMap(kont.ExprPerform(netRead{}), func(int) struct{} { return struct{}{} })
+	for range 2 {
+		if !p.TrySubmitExpr(wait) {
+			t.Fatal("TrySubmit failed below MaxLive")
+		}
+		resumes = append(resumes, <-parked)
+	}
+	if p.Parked() != 2 || p.TrySubmit(kont.Pure(struct{}{})) {
+		t.Fatalf("parked %d; TrySubmit succeeded at MaxLive", p.Parked())
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := p.Submit(ctx, kont.Pure(struct{}{})); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Submit at MaxLive: %v", err)
+	}
+	if err := p.Shutdown(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Shutdown with parked computations: %v", err)
+	}
+	if p.Live() != 0 || p.Parked() != 0 {
+		t.Fatalf("after Shutdown deadline: live %d, parked %d", p.Live(), p.Parked())
+	}
+	for _, resume := range resumes {
+		resume(0) // discarded: ignored
+	}
+	if err := p.Shutdown(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestPoolShortCircuit(t *testing.T) {
+	var reached atomic.Bool
+	p := kont.NewPool(kont.PoolOptions{
+		Dispatch: func(kont.Operation) (kont.Resumed, bool) { return nil, false },
+	})
+	comp := kont.Map(kont.Perform(kont.Ask[int]{}), func(int) struct{} {
+		reached.Store(true)
+		return struct{}{}
+	})
+	if !p.TrySubmit(comp) {
+		t.Fatal("TrySubmit failed")
+	}
+	if err := p.Shutdown(context.Background()); err != nil || reached.Load() {
+		t.Fatalf("err %v, reached %v", err, reached.Load())
+	}
+}
+
+func TestPoolShutdownCancels(t *testing.T) {
+	var reached atomic.Bool
+	parked := make(chan func(kont.Resumed), 1)
+	p := kont.NewPool(kont.PoolOptions{
+		Park: func(op kont.Operation, resume func(kont.Resumed)) bool {
+			parked <- resume
+			return true
+		},
+	})
+	comp := kont.Then(kont.Perform(netRead{}), kont.Map(kont.CheckCtx(), func(struct{}) struct{} {
+		reached.Store(true)
+		return struct{}{}
+	}))
+	if !p.TrySubmit(comp) {
+		t.Fatal("TrySubmit failed")
+	}
+	resume := <-parked
+	done := make(chan error)
+	go func() { done <- p.Shutdown(context.Background()) }()
+	for p.TrySubmit(kont.Pure(struct{}{})) {
+		time.Sleep(time.Millisecond) // until Shutdown has begun
+	}
+	resume(0)
+	if err := <-done; err != nil || reached.Load() {
+		t.Fatalf("err %v, reached %v", err, reached.Load())
+	}
+}
+
+func TestPoolShutdownDiscardsParked(t *testing.T) {
+	var stopped atomic.Bool
+	src := func(yield func(int) bool) {
+		defer stopped.Store(true)
+		for i := 0; yield(i); i++ {
+		}
+	}
+	p := kont.NewPool(kont.PoolOptions{
+		Park: func(op kont.Operation, resume func(kont.Resumed)) bool { return true },
+	})
+	if !p.TrySubmit(kont.FromSeq(src)) {
+		t.Fatal("TrySubmit failed")
+	}
+	for p.Parked() == 0 {
+		time.Sleep(time.Millisecond)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := p.Shutdown(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Shutdown: %v", err)
+	}
+	if !stopped.Load() || p.Live() != 0 {
+		t.Fatalf("stopped %v, live %d", stopped.Load(), p.Live())
+	}
+}
+
+func TestPoolStats(t *testing.T) {
+	clock := kont.NewVirtualClock(time.Unix(0, 0))
+	parked := make(chan func(kont.Resumed), 2)
+	p := kont.NewPool(kont.PoolOptions{
+		Workers: 2,
+		Now:     clock.Now,
+		Park: func(op kont.Operation, resume func(kont.Resumed)) bool {
+			parked <- resume
+			return true
+		},
+	})
+	wait := kont.ExprMap(kont.ExprPerform(netRead{}), func(int) struct{} { return struct{}{} })
+	p.TrySubmitExpr(wait)
+	r1 := <-parked
+	clock.Advance(time.Second)
+	p.TrySubmitExpr(wait)
+	r2 := <-parked
+	st := p.Stats()
+	if st.Workers != 2 || st.Live != 2 || st.Queued != 0 || st.Parked != 2 {
+		t.Fatalf("stats %+v", st)
+	}
+	if st.ParkedOps["kont_test.netRead"] != 2 || st.OldestParked != time.Second {
+		t.Fatalf("parked ops %v, oldest %v", st.ParkedOps, st.OldestParked)
+	}
+	r1(0)
+	r2(0)
+	if err := p.Shutdown(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if st := p.Stats(); st.Live != 0 || len(st.ParkedOps) != 0 || st.OldestParked != 0 {
+		t.Fatalf("stats after shutdown %+v", st)
+	}
+}
+
+func TestPoolPanicPolicy(t *testing.T) {
+	for _, policy := range []kont.PanicPolicy{kont.PanicStop, kont.PanicRestart} {
+		var runs, completed, panics atomic.Int32
+		p := kont.NewPool(kont.PoolOptions{
+			Workers: 1,
+			Panic:   policy,
+			OnPanic: func(err error) {
+				if kont.Kind(err) == kont.KindHandlerFailure {
+					panics.Add(1)
+				}
+			},
+			Dispatch: func(kont.Operation) (kont.Resumed, bool) { return 0, true },
+		})
+		comp := kont.Map(kont.Perform(kont.Ask[int]{}), func(int) struct{} {
+			if runs.Add(1) == 1 {
+				panic("boom")
+			}
+			completed.Add(1)
+			return struct{}{}
+		})
+		if !p.TrySubmit(comp) {
+			t.Fatal("TrySubmit failed")
+		}
+		if err := p.Shutdown(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+		want := int32(0)
+		if policy == kont.PanicRestart {
+			want = 1
+		}
+		if panics.Load() != 1 || completed.Load() != want {
+			t.Fatalf("policy %d: panics %d, completed %d", policy, panics.Load(), completed.Load())
+		}
+	}
+}
@@ -72,12 +72,14 @@ type Pair[A, B any] struct {
 // TellWriter fuses Tell + Then: performs Tell, then runs next.
 func TellWriter[W, B any](w W, next Cont[Resumed, B]) Cont[Resumed, B] {
 	resume := thenMarkerResume[B]
+	at := captureProvenance()
 	return func(k func(B) Resumed) Resumed {
 		m := acquireMarker()
 		m.op = Tell[W]{Value: w}
 		m.f = next
 		m.k = k
 		m.resume = resume
+		m.at = at
 		return m
 	}
 }
@@ -104,7 +106,7 @@ func (h *writerHandler[W, R]) Dispatch(op Operation) (Resumed, bool) {
 	}); ok {
 		return wop.DispatchWriter(h.ctx)
 	}
-	unhandledEffect("WriterHandler")
+	unhandledEffect("WriterHandler", op)
 	return nil, false
 }
 
@@ -145,3 +147,100 @@ func RunWriterExpr[W, A any](m Expr[A]) (A, []W) {
 	result := HandleExpr(m, h)
 	return result, output
 }
+
+// dispatchWriterTo dispatches a Writer operation for handlers that do not
+// accumulate into a slice. Tell is passed to add directly; Listen and Censor
+// run against a temporary buffer whose final contents are then passed to add.
+// Reports false as its third result when op is not a Writer operation.
+func dispatchWriterTo[W any](op Operation, add func(W)) (Resumed, bool, bool) {
+	if t, ok := op.(Tell[W]); ok {
+		add(t.Value)
+		return struct{}{}, true, true
+	}
+	if wop, ok := op.(interface {
+		DispatchWriter(ctx *WriterContext[W]) (Resumed, bool)
+	}); ok {
+		var buffered []W
+		v, shouldResume := wop.DispatchWriter(&WriterContext[W]{Output: &buffered})
+		for _, w := range buffered {
+			add(w)
+		}
+		return v, shouldResume, true
+	}
+	return nil, false, false
+}
+
+// writerMonoidHandler implements Handler for monoid-accumulating writer handling.
+type writerMonoidHandler[W, R any] struct {
+	acc     *W
+	combine func(W, W) W
+}
+
+// Dispatch implements Handler for monoid-accumulating writer handling.
+func (h *writerMonoidHandler[W, R]) Dispatch(op Operation) (Resumed, bool) {
+	if v, shouldResume, ok := dispatchWriterTo(op, h.add); ok {
+		return v, shouldResume
+	}
+	unhandledEffect("WriterMonoidHandler", op)
+	return nil, false
+}
+
+func (h *writerMonoidHandler[W, R]) add(w W) {
+	*h.acc = h.combine(*h.acc, w)
+}
+
+// WriterMonoidHandler creates a Writer handler that folds output into a
+// single value with combine, starting from empty. combine must be
+// associative with empty as its identity.
+// Returns a concrete handler and a function to retrieve the accumulated value.
+func WriterMonoidHandler[W, R any](empty W, combine func(W, W) W) (*writerMonoidHandler[W, R], func() W) {
+	acc := empty
+	return &writerMonoidHandler[W, R]{acc: &acc, combine: combine}, func() W { return acc }
+}
+
+// RunWriterMonoid runs a writer computation, folding output with combine.
+func RunWriterMonoid[W, A any](empty W, combine func(W, W) W, m Cont[Resumed, A]) (A, W) {
+	h, output := WriterMonoidHandler[W, A](empty, combine)
+	result := Handle(m, h)
+	return result, output()
+}
+
+// RunWriterMonoidExpr runs an Expr writer computation, folding output with combine.
+func RunWriterMonoidExpr[W, A any](empty W, combine func(W, W) W, m Expr[A]) (A, W) {
+	h, output := WriterMonoidHandler[W, A](empty, combine)
+	result := HandleExpr(m, h)
+	return result, output()
+}
+
+// streamWriterHandler implements Handler for streaming writer handling.
+type streamWriterHandler[W, R any] struct {
+	sink func(W)
+}
+
+// Dispatch implements Handler for streaming writer handling.
+// Tell invokes the sink directly; Listen and Censor buffer their body's
+// output and stream it once the body completes.
+func (h *streamWriterHandler[W, R]) Dispatch(op Operation) (Resumed, bool) {
+	if v, shouldResume, ok := dispatchWriterTo(op, h.sink); ok {
+		return v, shouldResume
+	}
+	unhandledEffect("StreamWriterHandler", op)
+	return nil, false
+}
+
+// StreamWriterHandler creates a Writer handler that passes every output
+// value to sink instead of accumulating it.
+// Returns a concrete handler.
+func StreamWriterHandler[W, R any](sink func(W)) *streamWriterHandler[W, R] {
+	return &streamWriterHandler[W, R]{sink: sink}
+}
+
+// RunWriterStream runs a writer computation, streaming output to sink.
+func RunWriterStream[W, A any](sink func(W), m Cont[Resumed, A]) A {
+	return Handle(m, StreamWriterHandler[W, A](sink))
+}
+
+// RunWriterStreamExpr runs an Expr writer computation, streaming output to sink.
+func RunWriterStreamExpr[W, A any](sink func(W), m Expr[A]) A {
+	return HandleExpr(m, StreamWriterHandler[W, A](sink))
+}
@@ -268,3 +268,106 @@ func TestListenNestedWithConcreteTypes(t *testing.T) {
 		t.Fatalf("logs = %v, want [2, 1, 3]", logs)
 	}
 }
+
+func TestRunWriterMonoidSum(t *testing.T) {
+	comp := kont.TellWriter(3, kont.TellWriter(4, kont.TellWriter(5, kont.Pure("done"))))
+	got, sum := kont.RunWriterMonoid(0, func(a, b int) int { return a + b }, comp)
+	if got != "done" || sum != 12 {
+		t.Fatalf("got (%q, %d), want (done, 12)", got, sum)
+	}
+}
+
+func TestRunWriterMonoidString(t *testing.T) {
+	comp := kont.TellWriter("a", kont.TellWriter("b", kont.Pure(0)))
+	_, s := kont.RunWriterMonoid("", func(a, b string) string { return a + b }, comp)
+	if s != "ab" {
+		t.Fatalf("got %q, want ab", s)
+	}
+}
+
+func TestRunWriterMonoidListenCensor(t *testing.T) {
+	comp := kont.TellWriter(1, kont.Bind(
+		kont.ListenWriter[int](kont.TellWriter(10, kont.Pure(0))),
+		func(p kont.Pair[int, []int]) kont.Eff[int] {
+			return kont.CensorWriter(func(ws []int) []int { return nil }, kont.TellWriter(100, kont.Pure(len(p.Snd))))
+		},
+	))
+	got, sum := kont.RunWriterMonoid(0, func(a, b int) int { return a + b }, comp)
+	if got != 1 || sum != 11 {
+		t.Fatalf("got (%d, %d), want (1, 11)", got, sum)
+	}
+}
+
+func TestRunWriterMonoidExpr(t *testing.T) {
+	comp := kont.ExprThen(kont.ExprPerform(kont.Tell[int]{Value: 2}),
+		kont.ExprThen(kont.ExprPerform(kont.Tell[int]{Value: 5}), kont.ExprReturn(true)))
+	got, hi := kont.RunWriterMonoidExpr(0, func(a, b int) int { return max(a, b) }, comp)
+	if !got || hi != 5 {
+		t.Fatalf("got (%v, %d), want (true, 5)", got, hi)
+	}
+}
+
+func TestWriterMonoidHandlerUnhandledPanic(t *testing.T) {
+	defer func() {
+		if r := recover(); unhandledIn(r) != "WriterMonoidHandler" {
+			t.Fatalf("got panic %v", r)
+		}
+	}()
+	h, _ := kont.WriterMonoidHandler[int, int](0, func(a, b int) int { return a + b })
+	kont.Handle(kont.Perform(kont.Get[int]{}), h)
+}
+
+func TestRunWriterStream(t *testing.T) {
+	var got []string
+	comp := kont.TellWriter("a", kont.TellWriter("b", kont.Pure(2)))
+	if n := kont.RunWriterStream(func(w string) { got = append(got, w) }, comp); n != 2 {
+		t.Fatalf("got %d, want 2", n)
+	}
+	if !slices.Equal(got, []string{"a", "b"}) {
+		t.Fatalf("got %v", got)
+	}
+}
+
+func TestRunWriterStreamListenCensor(t *testing.T) {
+	ch := make(chan int, 8)
+	comp := kont.TellWriter(1, kont.Bind(
+		kont.ListenWriter[int](kont.TellWriter(2, kont.Pure(0))),
+		func(p kont.Pair[int, []int]) kont.Eff[int] {
+			return kont.CensorWriter(func(ws []int) []int {
+				out := make([]int, len(ws))
+				for i, w := range ws {
+					out[i] = -w
+				}
+				return out
+			}, kont.TellWriter(3, kont.Pure(len(p.Snd))))
+		},
+	))
+	if n := kont.RunWriterStream(func(w int) { ch <- w }, comp); n != 1 {
+		t.Fatalf("got %d, want 1 listened value", n)
+	}
+	close(ch)
+	var got []int
+	for w := range ch {
+		got = append(got, w)
+	}
+	if !slices.Equal(got, []int{1, 2, -3}) {
+		t.Fatalf("got %v, want [1 2 -3]", got)
+	}
+}
+
+func TestRunWriterStreamExpr(t *testing.T) {
+	var n int
+	comp := kont.ExprThen(kont.ExprPerform(kont.Tell[int]{Value: 4}), kont.ExprReturn("ok"))
+	if got := kont.RunWriterStreamExpr(func(w int) { n += w }, comp); got != "ok" || n != 4 {
+		t.Fatalf("got (%q, %d), want (ok, 4)", got, n)
+	}
+}
+
+func TestStreamWriterHandlerUnhandledPanic(t *testing.T) {
+	defer func() {
+		if r := recover(); unhandledIn(r) != "StreamWriterHandler" {
+			t.Fatalf("got panic %v", r)
+		}
+	}()
+	kont.RunWriterStream(func(int) {}, kont.Perform(kont.Get[int]{}))
+}